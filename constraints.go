@@ -0,0 +1,342 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Constraint is the interface implemented by the model constraints declared
+// on the Options.Constraints map.
+type Constraint interface {
+	// Validate returns an error if the constraint definition is not valid for
+	// the given model.
+	Validate(model *Model) error
+	// ConstraintFields returns the names of the model fields involved in the
+	// constraint.
+	ConstraintFields() []string
+}
+
+// Constraints represents the constraints map of a model, where the key is the
+// constraint name.
+type Constraints map[string]Constraint
+
+// UniqueConstraint implements the Constraint interface for the uniqueness of
+// a group of fields. If a Condition is given, the constraint only applies to
+// the rows matching it.
+type UniqueConstraint struct {
+	// Fields is the list of fields whose values must be unique together.
+	Fields []string
+	// Condition restricts the constraint to the matching rows if not nil.
+	Condition Conditioner
+}
+
+// Validate implements the Validate method of the Constraint interface.
+func (c UniqueConstraint) Validate(model *Model) error {
+	if len(c.Fields) == 0 {
+		return fmt.Errorf("unique constraint with no fields")
+	}
+	for _, name := range c.Fields {
+		if _, ok := model.fields[name]; !ok {
+			return fmt.Errorf("unknown constraint field: %s", name)
+		}
+		if model.isInherited(name) {
+			return fmt.Errorf("cannot constrain parent field: %s", name)
+		}
+	}
+	if c.Condition != nil {
+		return validateConditioner(model, c.Condition)
+	}
+	return nil
+}
+
+// ConstraintFields implements the ConstraintFields method of the Constraint
+// interface.
+func (c UniqueConstraint) ConstraintFields() []string {
+	fields := make([]string, len(c.Fields))
+	copy(fields, c.Fields)
+	if c.Condition != nil {
+		fields = append(fields, conditionerFields(c.Condition)...)
+	}
+	return fields
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c UniqueConstraint) MarshalJSON() ([]byte, error) {
+	result := struct {
+		Fields    []string
+		Condition json.RawMessage `json:",omitempty"`
+	}{Fields: c.Fields}
+	if c.Condition != nil {
+		data, err := MarshalConditioner(c.Condition)
+		if err != nil {
+			return nil, err
+		}
+		result.Condition = data
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *UniqueConstraint) UnmarshalJSON(data []byte) error {
+	r := struct {
+		Fields    []string
+		Condition json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	c.Fields = r.Fields
+	c.Condition = nil
+	if len(r.Condition) > 0 {
+		cond, err := UnmarshalConditioner(r.Condition)
+		if err != nil {
+			return err
+		}
+		c.Condition = cond
+	}
+	return nil
+}
+
+// CheckConstraint implements the Constraint interface for a condition that
+// every row must satisfy.
+type CheckConstraint struct {
+	// Check is the condition enforced by the constraint.
+	Check Conditioner
+}
+
+// Validate implements the Validate method of the Constraint interface.
+func (c CheckConstraint) Validate(model *Model) error {
+	if c.Check == nil {
+		return fmt.Errorf("check constraint with no condition")
+	}
+	return validateConditioner(model, c.Check)
+}
+
+// ConstraintFields implements the ConstraintFields method of the Constraint
+// interface.
+func (c CheckConstraint) ConstraintFields() []string {
+	if c.Check == nil {
+		return nil
+	}
+	return conditionerFields(c.Check)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c CheckConstraint) MarshalJSON() ([]byte, error) {
+	if c.Check == nil {
+		return nil, fmt.Errorf("check constraint with no condition")
+	}
+	data, err := MarshalConditioner(c.Check)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]json.RawMessage{"Check": data})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *CheckConstraint) UnmarshalJSON(data []byte) error {
+	r := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	raw, ok := r["Check"]
+	if !ok {
+		return fmt.Errorf("check constraint with no condition")
+	}
+	cond, err := UnmarshalConditioner(raw)
+	if err != nil {
+		return err
+	}
+	c.Check = cond
+	return nil
+}
+
+// constraintsRegistry holds a global registry with the available constraints.
+var constraintsRegistry = Constraints{
+	"UniqueConstraint": UniqueConstraint{},
+	"CheckConstraint":  CheckConstraint{},
+}
+
+// MarshalConstraint returns the JSON encoding of the given constraint, in the
+// form: {"ConstraintType": {...}}.
+func MarshalConstraint(c Constraint) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("nil constraint")
+	}
+	ct := reflect.Indirect(reflect.ValueOf(c)).Type()
+	for name, registered := range constraintsRegistry {
+		if reflect.TypeOf(registered) == ct {
+			return json.Marshal(map[string]Constraint{name: c})
+		}
+	}
+	return nil, fmt.Errorf("unregistered constraint type: %T", c)
+}
+
+// UnmarshalConstraint returns the constraint represented by the given JSON
+// data, in the form: {"ConstraintType": {...}}.
+func UnmarshalConstraint(data []byte) (Constraint, error) {
+	cMap := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &cMap); err != nil {
+		return nil, err
+	}
+	if len(cMap) != 1 {
+		return nil, fmt.Errorf("invalid constraint definition: %s", data)
+	}
+	for cType, raw := range cMap {
+		registered, ok := constraintsRegistry[cType]
+		if !ok {
+			return nil, fmt.Errorf("invalid constraint type: %s", cType)
+		}
+		cp := reflect.New(reflect.TypeOf(registered))
+		if err := json.Unmarshal(raw, cp.Interface()); err != nil {
+			return nil, err
+		}
+		return cp.Elem().Interface().(Constraint), nil
+	}
+	return nil, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (constraints Constraints) MarshalJSON() ([]byte, error) {
+	result := map[string]json.RawMessage{}
+	for name, c := range constraints {
+		data, err := MarshalConstraint(c)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = data
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (constraints *Constraints) UnmarshalJSON(data []byte) error {
+	rawMap := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &rawMap); err != nil {
+		return err
+	}
+	result := Constraints{}
+	for name, raw := range rawMap {
+		c, err := UnmarshalConstraint(raw)
+		if err != nil {
+			return err
+		}
+		result[name] = c
+	}
+	*constraints = result
+	return nil
+}
+
+// conditionerMarshaler is used to serialize conditioners.
+type conditionerMarshaler struct {
+	Q    Q               `json:",omitempty"`
+	Root json.RawMessage `json:",omitempty"`
+	Next json.RawMessage `json:",omitempty"`
+	Or   bool            `json:",omitempty"`
+	Not  bool            `json:",omitempty"`
+}
+
+// MarshalConditioner returns the JSON encoding of the given conditioner.
+func MarshalConditioner(c Conditioner) ([]byte, error) {
+	root, isChain := c.Root()
+	next, isOr, isNot := c.Next()
+	result := conditionerMarshaler{Or: isOr, Not: isNot}
+	if isChain {
+		data, err := MarshalConditioner(root)
+		if err != nil {
+			return nil, err
+		}
+		result.Root = data
+	} else {
+		result.Q = Q(c.Conditions())
+	}
+	if next != nil {
+		data, err := MarshalConditioner(next)
+		if err != nil {
+			return nil, err
+		}
+		result.Next = data
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalConditioner returns the conditioner represented by the given JSON
+// data.
+func UnmarshalConditioner(data []byte) (Conditioner, error) {
+	r := conditionerMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	var cond Conditioner = Q{}
+	if len(r.Root) > 0 {
+		root, err := UnmarshalConditioner(r.Root)
+		if err != nil {
+			return nil, err
+		}
+		cond = root
+	} else if r.Q != nil {
+		cond = r.Q
+	}
+	if len(r.Next) == 0 {
+		return cond, nil
+	}
+	next, err := UnmarshalConditioner(r.Next)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case r.Or && r.Not:
+		return cond.OrNot(next), nil
+	case r.Or:
+		return cond.Or(next), nil
+	case r.Not:
+		return cond.AndNot(next), nil
+	}
+	return cond.And(next), nil
+}
+
+// conditionerFields returns the names of the fields in the conditions of the
+// given conditioner.
+func conditionerFields(c Conditioner) []string {
+	fields := []string{}
+	root, isChain := c.Root()
+	if isChain {
+		fields = append(fields, conditionerFields(root)...)
+	} else {
+		for condition := range c.Conditions() {
+			name := strings.Split(strings.Split(condition, " ")[0], "__")[0]
+			fields = append(fields, name)
+		}
+	}
+	if next, _, _ := c.Next(); next != nil {
+		fields = append(fields, conditionerFields(next)...)
+	}
+	return fields
+}
+
+// validateConditioner returns an error if any of the conditions of the given
+// conditioner is not a valid lookup for the model.
+func validateConditioner(model *Model, c Conditioner) error {
+	root, isChain := c.Root()
+	if isChain {
+		if err := validateConditioner(model, root); err != nil {
+			return err
+		}
+	} else {
+		for condition := range c.Conditions() {
+			lkp, err := parseLookup(model, condition)
+			if err != nil {
+				return err
+			}
+			if model.isInherited(lkp.name) {
+				return fmt.Errorf("parent field in condition: %s", lkp.name)
+			}
+		}
+	}
+	if next, _, _ := c.Next(); next != nil {
+		return validateConditioner(model, next)
+	}
+	return nil
+}
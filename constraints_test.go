@@ -0,0 +1,146 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type unregisteredConstraint struct {
+	UniqueConstraint
+}
+
+// TestConstraints tests the model constraints
+func TestConstraints(t *testing.T) {
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":     IntegerField{Auto: true},
+			"email":  CharField{MaxLength: 100},
+			"active": BooleanField{},
+		},
+		meta: Options{Table: "users_user"},
+	}
+
+	t.Run("UniqueNoFields", func(t *testing.T) {
+		if err := (UniqueConstraint{}).Validate(model); err == nil {
+			t.Error("expected unique constraint with no fields error")
+		}
+	})
+
+	t.Run("UniqueUnknownField", func(t *testing.T) {
+		c := UniqueConstraint{Fields: []string{"username"}}
+		if err := c.Validate(model); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
+	t.Run("UniqueInvalidCondition", func(t *testing.T) {
+		c := UniqueConstraint{
+			Fields:    []string{"email"},
+			Condition: Q{"active": true}.Or(Q{"deleted": false}),
+		}
+		if err := c.Validate(model); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
+	t.Run("CheckNoCondition", func(t *testing.T) {
+		if err := (CheckConstraint{}).Validate(model); err == nil {
+			t.Error("expected check constraint with no condition error")
+		}
+	})
+
+	t.Run("ConstraintFields", func(t *testing.T) {
+		c := UniqueConstraint{
+			Fields:    []string{"email"},
+			Condition: Q{"active": true}.AndNot(Q{"id >": 10}),
+		}
+		fields := c.ConstraintFields()
+		expected := []string{"email", "active", "id"}
+		if len(fields) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, fields)
+		}
+		for i, name := range expected {
+			if fields[i] != name {
+				t.Errorf("expected %v, got %v", expected, fields)
+			}
+		}
+	})
+
+	t.Run("MarshalUnregistered", func(t *testing.T) {
+		if _, err := MarshalConstraint(unregisteredConstraint{}); err == nil {
+			t.Error("expected unregistered constraint type error")
+		}
+	})
+
+	t.Run("UnmarshalInvalidType", func(t *testing.T) {
+		data := []byte(`{"ForeignKey": {}}`)
+		if _, err := UnmarshalConstraint(data); err == nil {
+			t.Error("expected invalid constraint type error")
+		}
+	})
+
+	t.Run("MarshalUnique", func(t *testing.T) {
+		c := UniqueConstraint{Fields: []string{"email"}}
+		data, err := MarshalConstraint(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"UniqueConstraint":{"Fields":["email"]}}`
+		if string(data) != expected {
+			t.Errorf("expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("MarshalCheck", func(t *testing.T) {
+		c := CheckConstraint{Check: Q{"active": true}}
+		data, err := MarshalConstraint(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"CheckConstraint":{"Check":{"Q":{"active":true}}}}`
+		if string(data) != expected {
+			t.Errorf("expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		constraints := Constraints{
+			"unique_email": UniqueConstraint{
+				Fields:    []string{"email"},
+				Condition: Q{"active": true}.OrNot(Q{"id": 1}),
+			},
+			"id_check": CheckConstraint{Check: Q{"id >": 0}},
+		}
+		data, err := json.Marshal(constraints)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Constraints{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		unique, ok := result["unique_email"].(UniqueConstraint)
+		if !ok {
+			t.Fatalf("expected UniqueConstraint, got %v", result)
+		}
+		next, isOr, isNot := unique.Condition.Next()
+		if next == nil || !isOr || !isNot {
+			t.Errorf("expected OrNot condition chain")
+		}
+		if _, ok := result["id_check"].(CheckConstraint); !ok {
+			t.Fatalf("expected CheckConstraint, got %T", result["id_check"])
+		}
+		if err := unique.Validate(model); err != nil {
+			t.Errorf("expected valid constraint, got %s", err)
+		}
+		again, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("expected %s, got %s", data, again)
+		}
+	})
+}
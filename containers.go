@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -56,6 +57,10 @@ func (vals Values) Get(key string) (Value, bool) {
 // Set implements the Setter interface.
 func (vals Values) Set(key string, val Value, field Field) error {
 	recipient := field.Recipient()
+	if enc, ok := encryptedField(field); ok && enc.Base != nil {
+		// Encrypted values are held decrypted by the base field recipient.
+		recipient = enc.Base.Recipient()
+	}
 	if err := setRecipient(recipient, val); err != nil {
 		return err
 	}
@@ -74,6 +79,32 @@ type Choice struct {
 	Label string // Label is the choice label.
 }
 
+// sameChoice returns true if the given value matches the choice value. Integers
+// of different types holding the same number are considered equal.
+func sameChoice(val Value, choice Value) bool {
+	if reflect.DeepEqual(val, choice) {
+		return true
+	}
+	isInt := func(v Value) bool {
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64:
+			return true
+		}
+		return false
+	}
+	if !isInt(val) || !isInt(choice) {
+		return false
+	}
+	a, err := toInt64(val, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return false
+	}
+	b, err := toInt64(choice, math.MinInt64, math.MaxInt64)
+	return err == nil && a == b
+}
+
 // isValidContainer checks if the given container is valid. It must be either
 // a type implementing the Builder interface or a struct.
 func isValidContainer(container Container) bool {
@@ -196,6 +227,17 @@ func asString(src interface{}) string {
 	return fmt.Sprintf("%v", src)
 }
 
+// timeLayouts holds the layouts used to parse time strings coming from the
+// database, in order of preference.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
 // convertAssignRows copies to dest the value in src, converting it if possible.
 // An error is returned if the copy would result in loss of information.
 // dest should be a pointer type.
@@ -219,15 +261,11 @@ func setRecipient(dest, src interface{}) error {
 			*d = append((*d)[:0], s...)
 			return nil
 		case *time.Time:
-			if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
-				*d = t
-				return nil
-			} else if t, err := time.Parse("2006-01-02", s); err == nil {
-				*d = t
-				return nil
-			} else if t, err := time.Parse("15:04:05", s); err == nil {
-				*d = t
-				return nil
+			for _, layout := range timeLayouts {
+				if t, err := time.Parse(layout, s); err == nil {
+					*d = t
+					return nil
+				}
 			}
 		}
 	case []byte:
@@ -0,0 +1,237 @@
+package gomodel
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ContentType is the model identifying the registered models by their app and
+// model names. It's registered to the reserved gomodel app by the Start
+// function, and its table and objects are created on each database the first
+// time a model content type is requested on it.
+var ContentType = New(
+	"ContentType",
+	Fields{
+		"app":   CharField{MaxLength: 50},
+		"model": CharField{MaxLength: 100},
+	},
+	Options{
+		Constraints: Constraints{
+			"gomodel_contenttype_app_model_unique": UniqueConstraint{
+				Fields: []string{"app", "model"},
+			},
+		},
+	},
+)
+
+// contentTypes caches the content type ids by database and model name, and the
+// models by database and content type id.
+var contentTypes = struct {
+	sync.Mutex
+	tables map[string]bool
+	ids    map[string]map[string]Value
+	models map[string]map[string]*Model
+}{
+	tables: map[string]bool{},
+	ids:    map[string]map[string]Value{},
+	models: map[string]map[string]*Model{},
+}
+
+// clearContentTypes empties the content types cache.
+func clearContentTypes() {
+	contentTypes.Lock()
+	defer contentTypes.Unlock()
+	contentTypes.tables = map[string]bool{}
+	contentTypes.ids = map[string]map[string]Value{}
+	contentTypes.models = map[string]map[string]*Model{}
+}
+
+// cacheContentType stores the content type id of the model on the given
+// database and returns the cached id, that is the existing one if another
+// goroutine cached it first.
+func cacheContentType(dbName string, id Value, model *Model) Value {
+	contentTypes.Lock()
+	defer contentTypes.Unlock()
+	name := model.app.name + "." + model.name
+	if contentTypes.ids[dbName] == nil {
+		contentTypes.ids[dbName] = map[string]Value{}
+		contentTypes.models[dbName] = map[string]*Model{}
+	}
+	if cached, ok := contentTypes.ids[dbName][name]; ok {
+		return cached
+	}
+	contentTypes.ids[dbName][name] = id
+	contentTypes.models[dbName][fmt.Sprint(id)] = model
+	return id
+}
+
+// contentTypeError returns a *DatabaseError for the given model, database and
+// error.
+func contentTypeError(model *Model, dbName string, err error) error {
+	trace := ErrorTrace{App: model.app, Model: model, Err: err}
+	return &DatabaseError{dbName, trace}
+}
+
+// contentTypeTarget returns the engine and database name of the given target,
+// that can be a *Transaction or a string representing a database identifier,
+// and the QuerySet of content types on it.
+func contentTypeTarget(target interface{}) (Engine, string, QuerySet) {
+	qs := ContentType.Objects.GetQuerySet()
+	switch t := target.(type) {
+	case *Transaction:
+		return t.Engine, t.DB.id, qs.WithTx(t)
+	case string:
+		if db, ok := dbRegistry[t]; ok {
+			return db.Engine, t, qs.WithDB(t)
+		}
+	}
+	return nil, "", nil
+}
+
+// contentTypeID returns the id of the content type of the given model on the
+// given target, creating the content type table and object if not found.
+// Proxy models share the content type of the concrete model.
+//
+// The ids are cached by database, except for those resolved on a transaction
+// that might be rolled back. The cache lock is not held during the database
+// calls, since the content type signals might request other content types.
+func contentTypeID(target interface{}, model *Model) (Value, error) {
+	if model.proxyFor != nil {
+		model = model.proxyFor
+	}
+	if model.app == nil {
+		err := fmt.Errorf("model not registered")
+		return nil, contentTypeError(model, "", err)
+	}
+	if ContentType.Model.app == nil {
+		err := fmt.Errorf("content types not registered")
+		return nil, contentTypeError(model, "", err)
+	}
+	eng, dbName, qs := contentTypeTarget(target)
+	if eng == nil {
+		err := fmt.Errorf("invalid target")
+		return nil, contentTypeError(model, "", err)
+	}
+	name := model.app.name + "." + model.name
+	contentTypes.Lock()
+	id, found := contentTypes.ids[dbName][name]
+	table := contentTypes.tables[dbName]
+	contentTypes.Unlock()
+	if found {
+		return id, nil
+	}
+	_, isTx := target.(*Transaction)
+	if !table {
+		if err := eng.CreateTable(ContentType.Model, false); err != nil {
+			return nil, contentTypeError(ContentType.Model, dbName, err)
+		}
+		if !isTx {
+			contentTypes.Lock()
+			contentTypes.tables[dbName] = true
+			contentTypes.Unlock()
+		}
+	}
+	values := Values{"app": model.app.name, "model": model.name}
+	ct, err := qs.Get(Q(values))
+	if _, ok := err.(*ObjectNotFoundError); ok {
+		ct, err = ContentType.Objects.CreateOn(target, values)
+		if err != nil {
+			// The content type might have been created by a concurrent
+			// lookup, violating the unique constraint.
+			if existing, getErr := qs.Get(Q(values)); getErr == nil {
+				ct, err = existing, nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isTx {
+		return ct.Get("pk"), nil
+	}
+	return cacheContentType(dbName, ct.Get("pk"), model), nil
+}
+
+// contentTypeModel returns the registered model identified by the content type
+// with the given id on the given target.
+func contentTypeModel(target interface{}, id Value) (*Model, error) {
+	if ContentType.Model.app == nil {
+		err := fmt.Errorf("content types not registered")
+		return nil, contentTypeError(ContentType.Model, "", err)
+	}
+	eng, dbName, qs := contentTypeTarget(target)
+	if eng == nil {
+		err := fmt.Errorf("invalid target")
+		return nil, contentTypeError(ContentType.Model, "", err)
+	}
+	contentTypes.Lock()
+	model, found := contentTypes.models[dbName][fmt.Sprint(id)]
+	contentTypes.Unlock()
+	if found {
+		return model, nil
+	}
+	ct, err := qs.Get(Q{"pk": id})
+	if err != nil {
+		return nil, err
+	}
+	appName, _ := ct.Get("app").(string)
+	name, _ := ct.Get("model").(string)
+	app, ok := registry[appName]
+	if !ok || app.models[name] == nil {
+		err := fmt.Errorf("unknown content type: %s.%s", appName, name)
+		return nil, contentTypeError(ContentType.Model, dbName, err)
+	}
+	model = app.models[name]
+	if _, isTx := target.(*Transaction); !isTx {
+		cacheContentType(dbName, id, model)
+	}
+	return model, nil
+}
+
+// GetContentType returns the content type instance identifying the given
+// model, created on the default database if not found.
+func GetContentType(model *Model) (*Instance, error) {
+	return getContentType("default", model)
+}
+
+// GetContentTypeOn works as GetContentType, but the content type is resolved
+// on the given target, that can be a *Transaction or a string representing a
+// database identifier.
+func GetContentTypeOn(target interface{}, model *Model) (*Instance, error) {
+	return getContentType(target, model)
+}
+
+// getContentType returns the content type instance identifying the given
+// model on the given target.
+func getContentType(target interface{}, model *Model) (*Instance, error) {
+	id, err := contentTypeID(target, model)
+	if err != nil {
+		return nil, err
+	}
+	if model.proxyFor != nil {
+		model = model.proxyFor
+	}
+	instance := newInstance(ContentType.Model, Values{
+		"id":    id,
+		"app":   model.app.name,
+		"model": model.name,
+	})
+	instance.snapshot()
+	return instance, nil
+}
+
+// ContentTypeModel returns the registered model identified by the given
+// content type instance, loaded from the default database if not cached.
+func ContentTypeModel(contentType *Instance) (*Model, error) {
+	return contentTypeModel("default", contentType.Get("pk"))
+}
+
+// ContentTypeModelOn works as ContentTypeModel, but the content type is loaded
+// from the given target, that can be a *Transaction or a string representing a
+// database identifier.
+func ContentTypeModelOn(
+	target interface{},
+	contentType *Instance,
+) (*Model, error) {
+	return contentTypeModel(target, contentType.Get("pk"))
+}
@@ -0,0 +1,230 @@
+package gomodel
+
+import (
+	"fmt"
+	"testing"
+)
+
+// createdRows mocks the rows of a content type created by a concurrent
+// lookup, which is only found by the second query.
+type createdRows struct {
+	next int
+}
+
+func (r *createdRows) Close() error {
+	return nil
+}
+
+func (r createdRows) Err() error {
+	return nil
+}
+
+func (r *createdRows) Next() bool {
+	r.next++
+	return r.next == 2
+}
+
+func (r *createdRows) Scan(dest ...interface{}) error {
+	for _, rec := range dest {
+		switch v := rec.(type) {
+		case *int32:
+			*v = 5
+		case *string:
+			*v = "Post"
+		}
+	}
+	return nil
+}
+
+// TestContentTypes tests the content type lookups
+func TestContentTypes(t *testing.T) {
+	// Models setup
+	app := &Application{name: "blog", models: map[string]*Model{}}
+	post := New("Post", Fields{"title": CharField{MaxLength: 100}}, Options{})
+	if err := post.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	draft := New(
+		"Draft",
+		Fields{},
+		Options{Extends: []*Model{post.Model}, Proxy: true},
+	)
+	if err := draft.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	registry["blog"] = app
+	defer ClearRegistry()
+	// DB setup
+	err := Start(map[string]Database{
+		"default": {Driver: "mocker"},
+		"other":   {Driver: "mocker"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { dbRegistry = map[string]Database{} }()
+	defer clearContentTypes()
+	mockedEngine := dbRegistry["default"].Engine.(MockedEngine)
+	otherEngine := dbRegistry["other"].Engine.(MockedEngine)
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		model := New("Tag", Fields{}, Options{}).Model
+		if _, err := GetContentType(model); err == nil {
+			t.Error("expected model not registered error")
+		}
+	})
+
+	t.Run("CreateTableError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.CreateTable = fmt.Errorf("db error")
+		_, err := GetContentType(post.Model)
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		mockedEngine.Results.InsertRow.Id = 3
+		ct, err := GetContentType(post.Model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("CreateTable") != 1 {
+			t.Error("expected engine CreateTable to be called")
+		}
+		values := mockedEngine.Args.InsertRow.Values
+		if values["app"] != "blog" || values["model"] != "Post" {
+			t.Errorf("expected blog.Post content type, got %v", values)
+		}
+		if ct.Get("pk") != int32(3) || ct.Get("model") != "Post" {
+			t.Errorf("expected content type 3, got %v", ct.Container())
+		}
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		mockedEngine.Reset()
+		ct, err := GetContentType(draft.Model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("GetRows") != 0 {
+			t.Error("expected engine GetRows not to be called")
+		}
+		if ct.Get("pk") != int32(3) || ct.Get("model") != "Post" {
+			t.Errorf("expected concrete content type, got %v", ct.Container())
+		}
+	})
+
+	t.Run("OtherDatabase", func(t *testing.T) {
+		mockedEngine.Reset()
+		otherEngine.Reset()
+		otherEngine.Results.GetRows.Rows = &rowsMocker{}
+		otherEngine.Results.InsertRow.Id = 7
+		ct, err := GetContentTypeOn("other", post.Model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if otherEngine.Calls("InsertRow") != 1 {
+			t.Error("expected content type to be created on other database")
+		}
+		if mockedEngine.Calls("GetRows") != 0 {
+			t.Error("expected default engine GetRows not to be called")
+		}
+		if ct.Get("pk") != int32(7) {
+			t.Errorf("expected content type 7, got %v", ct.Container())
+		}
+	})
+
+	t.Run("CreatedConcurrently", func(t *testing.T) {
+		otherEngine.Reset()
+		delete(contentTypes.ids["other"], "blog.Post")
+		otherEngine.Results.GetRows.Rows = &createdRows{}
+		otherEngine.Results.InsertRow.Err = fmt.Errorf("unique violation")
+		ct, err := GetContentTypeOn("other", post.Model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if otherEngine.Calls("GetRows") != 2 {
+			t.Error("expected content type to be queried again")
+		}
+		if ct.Get("pk") != int32(5) {
+			t.Errorf("expected content type 5, got %v", ct.Container())
+		}
+	})
+
+	t.Run("CreateError", func(t *testing.T) {
+		otherEngine.Reset()
+		delete(contentTypes.ids["other"], "blog.Post")
+		otherEngine.Results.GetRows.Rows = &rowsMocker{}
+		otherEngine.Results.InsertRow.Err = fmt.Errorf("db error")
+		if _, err := GetContentTypeOn("other", post.Model); err == nil {
+			t.Error("expected db error")
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		otherEngine.Reset()
+		otherEngine.Results.GetRows.Rows = &rowsMocker{}
+		tx := &Transaction{Engine: otherEngine, DB: dbRegistry["other"]}
+		tag := New("Tag", Fields{}, Options{}).Model
+		if err := tag.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := GetContentTypeOn(tx, tag); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := contentTypes.ids["other"]["blog.Tag"]; ok {
+			t.Error("expected transaction content type not to be cached")
+		}
+	})
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		if _, err := GetContentTypeOn("unknown", post.Model); err == nil {
+			t.Error("expected invalid target error")
+		}
+	})
+
+	t.Run("SignalReceiver", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		category := New("Category", Fields{}, Options{}).Model
+		if err := category.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		disconnect := Connect(
+			PostSave,
+			ContentType.Model,
+			func(event Event) error {
+				_, err := GetContentType(post.Model)
+				return err
+			},
+		)
+		defer disconnect()
+		if _, err := GetContentType(category); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Model", func(t *testing.T) {
+		ct := newInstance(ContentType.Model, Values{"id": int32(3)})
+		model, err := ContentTypeModel(ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if model != post.Model {
+			t.Errorf("expected Post model, got %s", model.name)
+		}
+	})
+
+	t.Run("ModelNotFound", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		ct := newInstance(ContentType.Model, Values{"id": int32(4)})
+		_, err := ContentTypeModel(ct)
+		if _, ok := err.(*ObjectNotFoundError); !ok {
+			t.Errorf("expected ObjectNotFoundError, got %T", err)
+		}
+	})
+}
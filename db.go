@@ -55,6 +55,30 @@ func (tx Transaction) Rollback() error {
 	return tx.RollbackTx()
 }
 
+// atomic runs the given function on the target transaction, or on a new one
+// that is committed if the function succeeds and rolled back otherwise. The
+// function runs on the target itself if the database doesn't support
+// transactions.
+func atomic(target interface{}, fn func(target interface{}) error) error {
+	name, ok := target.(string)
+	db, found := dbRegistry[name]
+	if !ok || !found || !db.TxSupport() {
+		return fn(target)
+	}
+	tx, err := db.BeginTx()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return &DatabaseError{name, ErrorTrace{Err: err}}
+	}
+	return nil
+}
+
 // dbRegistry is a global map containing all registered databases.
 var dbRegistry = map[string]Database{}
 
@@ -70,6 +94,9 @@ func Databases() map[string]Database {
 // Start opens a db connection for each database in the given map and stores
 // them in the db registry. It will panic if any of the selected drivers is
 // not supported or the connection fails to open.
+//
+// The reserved gomodel app is registered as well, holding the ContentType
+// model.
 func Start(options map[string]Database) error {
 	for name, db := range options {
 		engine, ok := enginesRegistry[db.Driver]
@@ -91,11 +118,10 @@ func Start(options map[string]Database) error {
 	if _, ok := dbRegistry["default"]; !ok {
 		panic("gomodels: missing default database")
 	}
-	registry["gomodel"] = &Application{
-		name:   "gomodel",
-		models: map[string]*Model{},
-	}
-	return nil
+	app := &Application{name: "gomodel", models: map[string]*Model{}}
+	registry["gomodel"] = app
+	clearContentTypes()
+	return ContentType.Model.Register(app)
 }
 
 // Stop close all the db connections and removes them from the db registry.
@@ -60,6 +60,10 @@ func TestStart(t *testing.T) {
 		if mockedEngine.Calls("Start") != 1 {
 			t.Error("expected engine Start method to be called")
 		}
+		app, ok := registry["gomodel"]
+		if !ok || app.models["ContentType"] != ContentType.Model {
+			t.Error("expected ContentType model to be registered")
+		}
 	})
 }
 
@@ -44,7 +44,8 @@ type Engine interface {
 	RenameTable(old *Model, new *Model) error
 	// DropTable drops the table for the given model.
 	DropTable(model *Model) error
-	// AddIndex creates a new index for the given model and fields.
+	// AddIndex creates a new index for the given model and fields. The model
+	// index definition is used instead if it contains the named index.
 	AddIndex(model *Model, name string, fields ...string) error
 	// DropIndex drops the named index for the given model.
 	DropIndex(model *Model, name string) error
@@ -52,6 +53,19 @@ type Engine interface {
 	AddColumns(model *Model, fields Fields) error
 	// DropColumns drops the columns given by fields from the model table.
 	DropColumns(model *Model, fields ...string) error
+	// AlterChoices updates the database constraint enforcing the choices of
+	// the named model field, removing it if the choices are not strict.
+	AlterChoices(model *Model, field string) error
+	// AddEnumValues adds the given values to the enum type of the named field,
+	// where the model holds the field including the new values.
+	AddEnumValues(model *Model, field string, values ...Value) error
+	// DropEnumValues removes the given values from the enum type of the named
+	// field, where the model holds the field without them.
+	DropEnumValues(model *Model, field string, values ...Value) error
+	// AddConstraint creates the named model constraint on the database.
+	AddConstraint(model *Model, name string) error
+	// DropConstraint drops the named model constraint from the database.
+	DropConstraint(model *Model, name string) error
 	// SelectQuery returns the SELECT SQL query details for the given model and
 	// query options.
 	SelectQuery(model *Model, options QueryOptions) (Query, error)
@@ -59,13 +73,34 @@ type Engine interface {
 	// the query options, where options.Start is the first row index (starting
 	// at 0) and options.End the last row index (-1 for all rows).
 	GetRows(model *Model, options QueryOptions) (Rows, error)
-	// InsertRow inserts the given values in the model table.
+	// InsertRow inserts the given values in the model table, returning the
+	// new pk if the model pk field is auto incremented.
 	InsertRow(model *Model, values Values) (int64, error)
+	// InsertRowReturning works as InsertRow, but returns the Rows holding the
+	// given fields columns of the inserted row, including the values assigned
+	// by the database.
+	InsertRowReturning(
+		model *Model,
+		values Values,
+		fields ...string,
+	) (Rows, error)
 	// UpdateRows updates the model rows selected by the given conditioner with
 	// the given values.
 	UpdateRows(model *Model, values Values, options QueryOptions) (int64, error)
+	// UpdateRowsReturning works as UpdateRows, but returns the Rows holding
+	// the options.Fields columns of the updated rows. It returns an error if
+	// the database doesn't support the operation.
+	UpdateRowsReturning(
+		model *Model,
+		values Values,
+		options QueryOptions,
+	) (Rows, error)
 	// DeleteRows deletes the model rows selected by the given conditioner.
 	DeleteRows(model *Model, options QueryOptions) (int64, error)
+	// DeleteRowsReturning works as DeleteRows, but returns the Rows holding
+	// the options.Fields columns of the deleted rows. It returns an error if
+	// the database doesn't support the operation.
+	DeleteRowsReturning(model *Model, options QueryOptions) (Rows, error)
 	// CountRows counts the model rows selected by the given conditioner.
 	CountRows(model *Model, options QueryOptions) (int64, error)
 	// Exists returns whether any model row exists for the given conditioner.
@@ -129,6 +164,15 @@ var scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
 	return row.Scan(dest)
 }
 
+// beginTx holds the function to start transactions on sql.DB connections.
+var beginTx = func(db sqlDB) (sqlTx, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
 // baseSQLEngine implements common Engine methods for SQL drivers.
 type baseSQLEngine struct {
 	driver      string            // Driver name.
@@ -199,6 +243,74 @@ func (e baseSQLEngine) sqlColumnOptions(field Field, forceNull bool) string {
 	return strings.Join(options, " ")
 }
 
+// defaultClause returns the DEFAULT clause for the DBDefault expression of the
+// given field, or a blank string if the field doesn't have one.
+func (e baseSQLEngine) defaultClause(field Field) string {
+	if expr := dbDefault(field); expr != "" {
+		return fmt.Sprintf(" DEFAULT (%s)", expr)
+	}
+	return ""
+}
+
+// generatedClause returns the GENERATED clause for the given field, or a blank
+// string if it's not a generated field.
+func (e baseSQLEngine) generatedClause(field Field) string {
+	if gen, ok := generatedField(field); ok {
+		return fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", gen.Expression)
+	}
+	return ""
+}
+
+// choicesCheck returns the column constraint enforcing the choices of the
+// named field, or a blank string if the field choices are not strict. Enum
+// fields are enforced by a check constraint on drivers other than postgres.
+func (e baseSQLEngine) choicesCheck(name string, field Field) (string, error) {
+	var choices []Choice
+	strict := true
+	if enum, ok := enumField(field); ok {
+		if e.driver == "postgres" {
+			return "", nil
+		}
+		choices = enum.choices()
+	} else if cf, ok := field.(ChoicesField); ok {
+		choices, strict = cf.FieldChoices()
+	}
+	if !strict || len(choices) == 0 {
+		return "", nil
+	}
+	literals := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		val, err := field.DriverValue(choice.Value, e.driver)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid choice: %s", name, err)
+		}
+		literal, ok := sqlLiteral(val)
+		if !ok || val == nil {
+			return "", fmt.Errorf("%s: invalid choice: %v", name, choice.Value)
+		}
+		literals = append(literals, literal)
+	}
+	column := field.DBColumn(name)
+	return fmt.Sprintf(
+		" CONSTRAINT %s CHECK (%s IN (%s))",
+		e.escape(column+"_choices"),
+		e.escape(column),
+		strings.Join(literals, ", "),
+	), nil
+}
+
+// dataType returns the column type of the named field for the engine driver,
+// or an error if the field type is not supported.
+func (e baseSQLEngine) dataType(name string, field Field) (string, error) {
+	dataType := field.DataType(e.driver)
+	if dataType == "" {
+		return "", fmt.Errorf(
+			"%s: field type not supported by %s", name, e.driver,
+		)
+	}
+	return dataType, nil
+}
+
 // escape returns the escaped given string.
 func (e baseSQLEngine) escape(s string) string {
 	return fmt.Sprintf("%[1]s%[2]s%[1]s", e.escapeChar, s)
@@ -215,17 +327,51 @@ func (e baseSQLEngine) placeholder(index int) string {
 
 // CreateTable implements the CreateTable method of the Engine interface.
 func (e baseSQLEngine) CreateTable(model *Model, force bool) error {
-	fields := model.Fields()
+	fields := model.LocalFields()
+	if err := e.createEnumTypes(fields); err != nil {
+		return err
+	}
 	columns := make([]string, 0, len(fields))
 	for name, field := range fields {
+		dataType, err := e.dataType(name, field)
+		if err != nil {
+			return err
+		}
+		check, err := e.choicesCheck(name, field)
+		if err != nil {
+			return err
+		}
+		options := e.sqlColumnOptions(field, false)
+		if name == model.ParentLink() {
+			options = e.parentLinkOptions(model)
+		}
 		sqlColumn := fmt.Sprintf(
-			"%s %s%s",
+			"%s %s%s%s%s%s",
 			e.escape(field.DBColumn(name)),
-			field.DataType(e.driver),
-			e.sqlColumnOptions(field, false),
+			dataType,
+			e.generatedClause(field),
+			options,
+			e.defaultClause(field),
+			check,
 		)
 		columns = append(columns, sqlColumn)
 	}
+	indexes := []string{}
+	for name, constraint := range model.meta.Constraints {
+		if unique, ok := partialUnique(constraint); ok {
+			index, err := e.uniqueIndex(model, name, unique)
+			if err != nil {
+				return err
+			}
+			indexes = append(indexes, index)
+			continue
+		}
+		def, err := e.tableConstraint(model, name, constraint)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, def)
+	}
 	skip := ""
 	if !force {
 		skip = "IF NOT EXISTS "
@@ -234,8 +380,15 @@ func (e baseSQLEngine) CreateTable(model *Model, force bool) error {
 		"CREATE TABLE %s%s (%s)",
 		skip, e.escape(model.Table()), strings.Join(columns, ", "),
 	)
-	_, err := e.executor().Exec(stmt)
-	return err
+	if _, err := e.executor().Exec(stmt); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if _, err := e.executor().Exec(index); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // RenameTable implements the RenameTable method of the Engine interface.
@@ -251,23 +404,72 @@ func (e baseSQLEngine) RenameTable(old *Model, new *Model) error {
 // DropTable implements the DropTable method of the Engine interface.
 func (e baseSQLEngine) DropTable(model *Model) error {
 	stmt := fmt.Sprintf("DROP TABLE %s", e.escape(model.Table()))
-	_, err := e.executor().Exec(stmt)
-	return err
+	if _, err := e.executor().Exec(stmt); err != nil {
+		return err
+	}
+	return e.dropEnumTypes(model.LocalFields())
 }
 
-// AddIndex implements the AddIndex method of the Engine interface.
-func (e baseSQLEngine) AddIndex(m *Model, name string, fields ...string) error {
-	modelFields := m.Fields()
-	columns := make([]string, 0, len(fields))
-	for _, fieldName := range fields {
-		column := modelFields[fieldName].DBColumn(fieldName)
-		columns = append(columns, fmt.Sprintf("\"%s\"", column))
+// indexStatement returns the statement creating the named index.
+func (e baseSQLEngine) indexStatement(
+	m *Model,
+	name string,
+	idx Index,
+) (string, error) {
+	columns := make([]string, 0, len(idx.Fields)+len(idx.Expressions))
+	for _, fieldName := range idx.Fields {
+		desc := strings.HasPrefix(fieldName, "-")
+		fieldName = strings.TrimPrefix(fieldName, "-")
+		field, ok := m.fields[fieldName]
+		if !ok {
+			return "", fmt.Errorf("unknown indexed field: %s", fieldName)
+		}
+		column := e.escape(field.DBColumn(fieldName))
+		if desc {
+			column += " DESC"
+		}
+		columns = append(columns, column)
+	}
+	for _, expr := range idx.Expressions {
+		columns = append(columns, fmt.Sprintf("(%s)", expr))
+	}
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	method := ""
+	if idx.Method != "" && e.driver == "postgres" {
+		method = fmt.Sprintf(" USING %s", idx.Method)
 	}
 	stmt := fmt.Sprintf(
-		"CREATE INDEX %s ON %s (%s)",
-		e.escape(name), e.escape(m.Table()), strings.Join(columns, ", "),
+		"CREATE %sINDEX %s ON %s%s (%s)",
+		unique, e.escape(name), e.escape(m.Table()), method,
+		strings.Join(columns, ", "),
 	)
-	_, err := e.executor().Exec(stmt)
+	if idx.Condition != nil {
+		pred, err := e.inlinePredicate(m, idx.Condition)
+		if err != nil {
+			return "", err
+		}
+		stmt = fmt.Sprintf("%s WHERE %s", stmt, pred)
+	}
+	return stmt, nil
+}
+
+// AddIndex implements the AddIndex method of the Engine interface.
+func (e baseSQLEngine) AddIndex(m *Model, name string, fields ...string) error {
+	idx := Index{Fields: fields}
+	if def, ok := m.indexes()[name]; ok {
+		idx = def
+	}
+	if len(idx.Fields) == 0 && len(idx.Expressions) == 0 {
+		return fmt.Errorf("index with no fields: %s", name)
+	}
+	stmt, err := e.indexStatement(m, name, idx)
+	if err != nil {
+		return err
+	}
+	_, err = e.executor().Exec(stmt)
 	return err
 }
 
@@ -280,17 +482,31 @@ func (e baseSQLEngine) DropIndex(model *Model, name string) error {
 
 // AddColumns implements the AddColumns method of the Engine interface.
 func (e baseSQLEngine) AddColumns(model *Model, fields Fields) error {
+	if err := e.createEnumTypes(fields); err != nil {
+		return err
+	}
 	addColumns := make([]string, 0, len(fields))
 	notNullFields := make([]string, 0, len(fields))
 	for name, field := range fields {
 		if !field.IsNull() {
 			notNullFields = append(notNullFields, name)
 		}
+		dataType, err := e.dataType(name, field)
+		if err != nil {
+			return err
+		}
+		check, err := e.choicesCheck(name, field)
+		if err != nil {
+			return err
+		}
 		addColumn := fmt.Sprintf(
-			"ADD COLUMN %s %s %s",
+			"ADD COLUMN %s %s%s %s%s%s",
 			e.escape(field.DBColumn(name)),
-			field.DataType(e.driver),
+			dataType,
+			e.generatedClause(field),
 			e.sqlColumnOptions(field, true),
+			e.defaultClause(field),
+			check,
 		)
 		addColumns = append(addColumns, addColumn)
 	}
@@ -306,20 +522,23 @@ func (e baseSQLEngine) AddColumns(model *Model, fields Fields) error {
 		setColumns := make([]string, 0, len(notNullFields))
 		for _, name := range notNullFields {
 			field := fields[name]
-			val, ok := field.DefaultValue()
-			if !ok {
+			if val, ok := field.DefaultValue(); ok {
+				values[name] = val
+			} else if dbDefault(field) == "" {
 				return fmt.Errorf(
 					"%s: cannot add not null column without default", name,
 				)
 			}
-			values[name] = val
 			setColumn := fmt.Sprintf(
 				"ALTER COLUMN %s SET NOT NULL", e.escape(field.DBColumn(name)),
 			)
 			setColumns = append(setColumns, setColumn)
 		}
-		if _, err := e.UpdateRows(model, values, QueryOptions{}); err != nil {
-			return err
+		if len(values) > 0 {
+			_, err := e.UpdateRows(model, values, QueryOptions{})
+			if err != nil {
+				return err
+			}
 		}
 		stmt := fmt.Sprintf(
 			"ALTER TABLE %s %s",
@@ -332,6 +551,29 @@ func (e baseSQLEngine) AddColumns(model *Model, fields Fields) error {
 	return nil
 }
 
+// AlterChoices implements the AlterChoices method of the Engine interface.
+func (e baseSQLEngine) AlterChoices(model *Model, name string) error {
+	field, ok := model.Fields()[name]
+	if !ok {
+		return fmt.Errorf("unknown field %s", name)
+	}
+	column := field.DBColumn(name)
+	stmt := fmt.Sprintf(
+		"ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s",
+		e.escape(model.Table()), e.escape(column+"_choices"),
+	)
+	if _, err := e.executor().Exec(stmt); err != nil {
+		return err
+	}
+	check, err := e.choicesCheck(name, field)
+	if err != nil || check == "" {
+		return err
+	}
+	stmt = fmt.Sprintf("ALTER TABLE %s ADD%s", e.escape(model.Table()), check)
+	_, err = e.executor().Exec(stmt)
+	return err
+}
+
 // DropColumns implements the DropColumns method of the Engine interface.
 func (e baseSQLEngine) DropColumns(model *Model, fields ...string) error {
 	oldFields := model.Fields()
@@ -376,24 +618,33 @@ func (e baseSQLEngine) predicate(
 		values = append(values, rootPred.Args...)
 	} else {
 		for condition, value := range options.Conditioner.Conditions() {
-			args := strings.Split(condition, " ")
-			name := args[0]
-			if name == "pk" {
-				name = model.pk
+			lkp, err := parseLookup(model, condition)
+			if err != nil {
+				return Query{}, err
+			}
+			column := lkp.field.DBColumn(lkp.name)
+			cond, args, ok, err := e.lookupCondition(column, lkp, value, pIndex)
+			if err != nil {
+				return Query{}, err
+			} else if ok {
+				conditions = append(conditions, cond)
+				values = append(values, args...)
+				pIndex += len(args)
+				continue
+			} else if len(lkp.transforms) > 0 {
+				return Query{}, fmt.Errorf("unsupported lookup: %s", condition)
 			}
 			operator := "="
-			if len(args) > 1 {
-				op, ok := e.operators[args[1]]
+			if lkp.operator != "" {
+				op, ok := e.operators[lkp.operator]
 				if !ok {
-					return Query{}, fmt.Errorf("invalid operator: %s", args[1])
+					return Query{}, fmt.Errorf(
+						"invalid operator: %s", lkp.operator,
+					)
 				}
 				operator = op
 			}
-			if _, ok := model.fields[name]; !ok {
-				return Query{}, fmt.Errorf("unknown field %s", name)
-			}
-			column := model.fields[name].DBColumn(name)
-			driverVal, err := model.fields[name].DriverValue(value, e.driver)
+			driverVal, err := lkp.field.DriverValue(value, e.driver)
 			if err != nil {
 				return Query{}, err
 			}
@@ -451,7 +702,7 @@ func (e baseSQLEngine) SelectQuery(m *Model, opt QueryOptions) (Query, error) {
 		columns = append(columns, e.escape(field.DBColumn(name)))
 	}
 	query.Stmt = fmt.Sprintf(
-		"SELECT %s FROM %s", strings.Join(columns, ", "), e.escape(m.Table()),
+		"SELECT %s FROM %s", strings.Join(columns, ", "), e.fromClause(m),
 	)
 	if opt.Conditioner != nil {
 		pred, err := e.predicate(m, opt, 1)
@@ -481,8 +732,9 @@ func (e baseSQLEngine) GetRows(model *Model, opt QueryOptions) (Rows, error) {
 	return e.executor().Query(query.Stmt, query.Args...)
 }
 
-// InsertRow implements the InsertRow method of the Engine interface.
-func (e baseSQLEngine) InsertRow(model *Model, values Values) (int64, error) {
+// insertQuery returns the INSERT query details for the given model and
+// values.
+func (e baseSQLEngine) insertQuery(model *Model, values Values) (Query, error) {
 	cols := make([]string, 0, len(model.fields))
 	vals := make([]interface{}, 0, len(model.fields))
 	placeholders := make([]string, 0, len(model.fields))
@@ -491,11 +743,15 @@ func (e baseSQLEngine) InsertRow(model *Model, values Values) (int64, error) {
 	for name, val := range values {
 		field, ok := fields[name]
 		if !ok {
-			return 0, fmt.Errorf("unknown field %s", name)
+			return Query{}, fmt.Errorf("unknown field %s", name)
+		}
+		if isGenerated(field) {
+			err := fmt.Errorf("%s: cannot insert generated field", name)
+			return Query{}, err
 		}
 		driverVal, err := field.DriverValue(val, e.driver)
 		if err != nil {
-			return 0, err
+			return Query{}, err
 		}
 		if driverVal != nil {
 			cols = append(cols, e.escape(field.DBColumn(name)))
@@ -510,6 +766,28 @@ func (e baseSQLEngine) InsertRow(model *Model, values Values) (int64, error) {
 		strings.Join(cols, ", "),
 		strings.Join(placeholders, ", "),
 	)
+	if len(cols) == 0 {
+		stmt = fmt.Sprintf(
+			"INSERT INTO %s DEFAULT VALUES", e.escape(model.Table()),
+		)
+	}
+	return Query{stmt, vals}, nil
+}
+
+// InsertRow implements the InsertRow method of the Engine interface.
+func (e baseSQLEngine) InsertRow(model *Model, values Values) (int64, error) {
+	if model.parent != nil {
+		return e.insertChild(model, values)
+	}
+	query, err := e.insertQuery(model, values)
+	if err != nil {
+		return 0, err
+	}
+	stmt, vals := query.Stmt, query.Args
+	if pkField, ok := model.fields[model.pk]; !ok || !pkField.IsAuto() {
+		_, err := e.executor().Exec(stmt, vals...)
+		return 0, err
+	}
 	if e.driver == "postgres" {
 		stmt = fmt.Sprintf(
 			"%s RETURNING %s",
@@ -532,12 +810,35 @@ func (e baseSQLEngine) InsertRow(model *Model, values Values) (int64, error) {
 	return pk, nil
 }
 
-// UpdateRows implements the UpdateRows method of the Engine interface.
-func (e baseSQLEngine) UpdateRows(
+// InsertRowReturning implements the InsertRowReturning method of the Engine
+// interface.
+func (e baseSQLEngine) InsertRowReturning(
+	model *Model,
+	values Values,
+	fields ...string,
+) (Rows, error) {
+	if model.parent != nil {
+		return e.insertChildReturning(model, values, fields)
+	}
+	query, err := e.insertQuery(model, values)
+	if err != nil {
+		return nil, err
+	}
+	returning, err := e.returning(model, fields)
+	if err != nil {
+		return nil, err
+	}
+	query.Stmt = fmt.Sprintf("%s %s", query.Stmt, returning)
+	return e.executor().Query(query.Stmt, query.Args...)
+}
+
+// updateQuery returns the UPDATE query details for the given model, values and
+// query options.
+func (e baseSQLEngine) updateQuery(
 	model *Model,
 	values Values,
 	options QueryOptions,
-) (int64, error) {
+) (Query, error) {
 	vals := make([]interface{}, 0, len(model.fields))
 	cols := make([]string, 0, len(model.fields))
 	fields := model.Fields()
@@ -545,11 +846,11 @@ func (e baseSQLEngine) UpdateRows(
 	for name, val := range values {
 		field, ok := fields[name]
 		if !ok {
-			return 0, fmt.Errorf("unknown field %s", name)
+			return Query{}, fmt.Errorf("unknown field %s", name)
 		}
 		driverVal, err := field.DriverValue(val, e.driver)
 		if err != nil {
-			return 0, err
+			return Query{}, err
 		}
 		col := fmt.Sprintf(
 			"%s = %s", e.escape(field.DBColumn(name)), e.placeholder(index),
@@ -564,12 +865,66 @@ func (e baseSQLEngine) UpdateRows(
 	if options.Conditioner != nil {
 		pred, err := e.predicate(model, options, index)
 		if err != nil {
-			return 0, err
+			return Query{}, err
 		}
 		stmt = fmt.Sprintf("%s WHERE %s", stmt, pred.Stmt)
 		vals = append(vals, pred.Args...)
 	}
-	result, err := e.executor().Exec(stmt, vals...)
+	return Query{stmt, vals}, nil
+}
+
+// deleteQuery returns the DELETE query details for the given model and query
+// options.
+func (e baseSQLEngine) deleteQuery(m *Model, opt QueryOptions) (Query, error) {
+	stmt := fmt.Sprintf("DELETE FROM %s", e.escape(m.Table()))
+	args := make([]interface{}, 0)
+	if opt.Conditioner != nil {
+		pred, err := e.predicate(m, opt, 1)
+		if err != nil {
+			return Query{}, err
+		}
+		stmt = fmt.Sprintf("%s WHERE %s", stmt, pred.Stmt)
+		args = pred.Args
+	}
+	return Query{stmt, args}, nil
+}
+
+// returning returns the RETURNING clause for the given model fields.
+func (e baseSQLEngine) returning(m *Model, fields []string) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no returning fields")
+	}
+	if m.parent != nil {
+		return "", fmt.Errorf("returning not supported for child models")
+	}
+	columns := make([]string, 0, len(fields))
+	for _, name := range fields {
+		if name == "pk" {
+			name = m.pk
+		}
+		field, ok := m.fields[name]
+		if !ok {
+			return "", fmt.Errorf("unknown field: %s", name)
+		}
+		columns = append(columns, e.escape(field.DBColumn(name)))
+	}
+	return fmt.Sprintf("RETURNING %s", strings.Join(columns, ", ")), nil
+}
+
+// UpdateRows implements the UpdateRows method of the Engine interface.
+func (e baseSQLEngine) UpdateRows(
+	model *Model,
+	values Values,
+	options QueryOptions,
+) (int64, error) {
+	if model.parent != nil {
+		return e.updateChild(model, values, options)
+	}
+	query, err := e.updateQuery(model, values, options)
+	if err != nil {
+		return 0, err
+	}
+	result, err := e.executor().Exec(query.Stmt, query.Args...)
 	if err != nil {
 		return 0, err
 	}
@@ -580,19 +935,35 @@ func (e baseSQLEngine) UpdateRows(
 	return rows, nil
 }
 
+// UpdateRowsReturning implements the UpdateRowsReturning method of the Engine
+// interface.
+func (e baseSQLEngine) UpdateRowsReturning(
+	model *Model,
+	values Values,
+	options QueryOptions,
+) (Rows, error) {
+	query, err := e.updateQuery(model, values, options)
+	if err != nil {
+		return nil, err
+	}
+	returning, err := e.returning(model, options.Fields)
+	if err != nil {
+		return nil, err
+	}
+	query.Stmt = fmt.Sprintf("%s %s", query.Stmt, returning)
+	return e.executor().Query(query.Stmt, query.Args...)
+}
+
 // DeleteRows implements the DeleteRows method of the engine interface.
 func (e baseSQLEngine) DeleteRows(m *Model, opt QueryOptions) (int64, error) {
-	stmt := fmt.Sprintf("DELETE FROM %s", e.escape(m.Table()))
-	args := make([]interface{}, 0)
-	if opt.Conditioner != nil {
-		pred, err := e.predicate(m, opt, 1)
-		if err != nil {
-			return 0, err
-		}
-		stmt = fmt.Sprintf("%s WHERE %s", stmt, pred.Stmt)
-		args = pred.Args
+	if m.parent != nil {
+		return e.deleteChild(m, opt)
+	}
+	query, err := e.deleteQuery(m, opt)
+	if err != nil {
+		return 0, err
 	}
-	result, err := e.executor().Exec(stmt, args...)
+	result, err := e.executor().Exec(query.Stmt, query.Args...)
 	if err != nil {
 		return 0, err
 	}
@@ -603,9 +974,27 @@ func (e baseSQLEngine) DeleteRows(m *Model, opt QueryOptions) (int64, error) {
 	return rows, nil
 }
 
+// DeleteRowsReturning implements the DeleteRowsReturning method of the Engine
+// interface.
+func (e baseSQLEngine) DeleteRowsReturning(
+	m *Model,
+	opt QueryOptions,
+) (Rows, error) {
+	query, err := e.deleteQuery(m, opt)
+	if err != nil {
+		return nil, err
+	}
+	returning, err := e.returning(m, opt.Fields)
+	if err != nil {
+		return nil, err
+	}
+	query.Stmt = fmt.Sprintf("%s %s", query.Stmt, returning)
+	return e.executor().Query(query.Stmt, query.Args...)
+}
+
 // CountRows implement the CountRows method of the Engine interface.
 func (e baseSQLEngine) CountRows(m *Model, opt QueryOptions) (int64, error) {
-	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s", e.escape(m.Table()))
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s", e.fromClause(m))
 	args := make([]interface{}, 0)
 	if opt.Conditioner != nil {
 		pred, err := e.predicate(m, opt, 1)
@@ -0,0 +1,177 @@
+package gomodel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// partialUnique returns the given constraint as a UniqueConstraint if it has
+// a condition, since those are created as partial unique indexes instead of
+// table constraints.
+func partialUnique(c Constraint) (UniqueConstraint, bool) {
+	switch u := c.(type) {
+	case UniqueConstraint:
+		return u, u.Condition != nil
+	case *UniqueConstraint:
+		if u != nil {
+			return *u, u.Condition != nil
+		}
+	}
+	return UniqueConstraint{}, false
+}
+
+// inlinePredicate returns the SQL predicate for the given conditioner with
+// the values inlined as literals, since constraint definitions can't use
+// query parameters.
+func (e baseSQLEngine) inlinePredicate(
+	model *Model,
+	cond Conditioner,
+) (string, error) {
+	pred, err := e.predicate(model, QueryOptions{Conditioner: cond}, 1)
+	if err != nil {
+		return "", err
+	}
+	pattern := `\?`
+	if e.pHolderChar == "$" {
+		pattern = `\$\d+`
+	}
+	next := 0
+	stmt := regexp.MustCompile(pattern).ReplaceAllStringFunc(
+		pred.Stmt,
+		func(placeholder string) string {
+			index := next
+			if placeholder != "?" {
+				n, _ := strconv.Atoi(placeholder[1:])
+				index = n - 1
+			}
+			next += 1
+			if index < 0 || index >= len(pred.Args) {
+				err = fmt.Errorf("invalid placeholder: %s", placeholder)
+				return placeholder
+			}
+			literal, ok := sqlLiteral(pred.Args[index])
+			if !ok {
+				err = fmt.Errorf("invalid value: %v", pred.Args[index])
+			}
+			return literal
+		},
+	)
+	return stmt, err
+}
+
+// constraintColumns returns the escaped and comma separated columns of the
+// given model fields.
+func (e baseSQLEngine) constraintColumns(
+	model *Model,
+	fields []string,
+) (string, error) {
+	columns := make([]string, 0, len(fields))
+	for _, name := range fields {
+		field, ok := model.fields[name]
+		if !ok {
+			return "", fmt.Errorf("unknown constraint field: %s", name)
+		}
+		columns = append(columns, e.escape(field.DBColumn(name)))
+	}
+	return strings.Join(columns, ", "), nil
+}
+
+// tableConstraint returns the table constraint definition for the named
+// constraint.
+func (e baseSQLEngine) tableConstraint(
+	model *Model,
+	name string,
+	constraint Constraint,
+) (string, error) {
+	switch c := constraint.(type) {
+	case *UniqueConstraint:
+		return e.tableConstraint(model, name, *c)
+	case *CheckConstraint:
+		return e.tableConstraint(model, name, *c)
+	case UniqueConstraint:
+		columns, err := e.constraintColumns(model, c.Fields)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			"CONSTRAINT %s UNIQUE (%s)", e.escape(name), columns,
+		), nil
+	case CheckConstraint:
+		pred, err := e.inlinePredicate(model, c.Check)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			"CONSTRAINT %s CHECK (%s)", e.escape(name), pred,
+		), nil
+	}
+	return "", fmt.Errorf(
+		"%s: constraint type not supported by %s", name, e.driver,
+	)
+}
+
+// uniqueIndex returns the statement creating the partial unique index for
+// the given conditional unique constraint.
+func (e baseSQLEngine) uniqueIndex(
+	model *Model,
+	name string,
+	c UniqueConstraint,
+) (string, error) {
+	columns, err := e.constraintColumns(model, c.Fields)
+	if err != nil {
+		return "", err
+	}
+	pred, err := e.inlinePredicate(model, c.Condition)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"CREATE UNIQUE INDEX %s ON %s (%s) WHERE %s",
+		e.escape(name), e.escape(model.Table()), columns, pred,
+	), nil
+}
+
+// AddConstraint implements the AddConstraint method of the Engine interface.
+func (e baseSQLEngine) AddConstraint(model *Model, name string) error {
+	constraint, ok := model.meta.Constraints[name]
+	if !ok {
+		return fmt.Errorf("unknown constraint %s", name)
+	}
+	var stmt string
+	if unique, ok := partialUnique(constraint); ok {
+		index, err := e.uniqueIndex(model, name, unique)
+		if err != nil {
+			return err
+		}
+		stmt = index
+	} else {
+		def, err := e.tableConstraint(model, name, constraint)
+		if err != nil {
+			return err
+		}
+		stmt = fmt.Sprintf(
+			"ALTER TABLE %s ADD %s", e.escape(model.Table()), def,
+		)
+	}
+	_, err := e.executor().Exec(stmt)
+	return err
+}
+
+// DropConstraint implements the DropConstraint method of the Engine interface.
+func (e baseSQLEngine) DropConstraint(model *Model, name string) error {
+	constraint, ok := model.meta.Constraints[name]
+	if !ok {
+		return fmt.Errorf("unknown constraint %s", name)
+	}
+	stmt := fmt.Sprintf(
+		"ALTER TABLE %s DROP CONSTRAINT %s",
+		e.escape(model.Table()), e.escape(name),
+	)
+	if _, ok := partialUnique(constraint); ok {
+		stmt = fmt.Sprintf("DROP INDEX %s", e.escape(name))
+	}
+	_, err := e.executor().Exec(stmt)
+	return err
+}
@@ -0,0 +1,233 @@
+package gomodel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// enumTypeLabels returns the labels of the named postgres enum type, and false
+// if the type doesn't exist.
+func (e baseSQLEngine) enumTypeLabels(
+	typeName string,
+) ([]string, bool, error) {
+	var result sql.NullString
+	query := Query{
+		Stmt: "SELECT array_to_json(array_agg(e.enumlabel))::text " +
+			"FROM pg_type t JOIN pg_enum e ON e.enumtypid = t.oid " +
+			"WHERE t.typname = $1 AND pg_type_is_visible(t.oid)",
+		Args: []interface{}{typeName},
+	}
+	if err := scanRow(e.executor(), &result, query); err != nil {
+		return nil, false, err
+	}
+	if !result.Valid {
+		return nil, false, nil
+	}
+	labels := []string{}
+	if err := json.Unmarshal([]byte(result.String), &labels); err != nil {
+		return nil, false, err
+	}
+	return labels, true, nil
+}
+
+// missingLabels returns the expected labels not found in the existing ones,
+// sorted.
+func missingLabels(existing []string, expected []string) []string {
+	found := make(map[string]bool, len(existing))
+	for _, label := range existing {
+		found[label] = true
+	}
+	missing := []string{}
+	for _, label := range expected {
+		if !found[label] {
+			missing = append(missing, label)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// createEnumTypes creates the postgres enum types of the given fields. Existing
+// types are reused if they hold all the values, and an error is returned
+// otherwise. It does nothing for other drivers.
+func (e baseSQLEngine) createEnumTypes(fields Fields) error {
+	if e.driver != "postgres" {
+		return nil
+	}
+	for name, field := range fields {
+		enum, ok := enumField(field)
+		if !ok {
+			continue
+		}
+		typeName := enum.TypeName
+		if typeName == "" {
+			return fmt.Errorf("%s: missing enum type name", name)
+		}
+		labels, found, err := e.enumTypeLabels(typeName)
+		if err != nil {
+			return err
+		}
+		if found {
+			missing := missingLabels(labels, enum.labels())
+			if len(missing) > 0 {
+				return fmt.Errorf(
+					"%s: enum type %s exists without values: %s",
+					name, typeName, strings.Join(missing, ", "),
+				)
+			}
+			continue
+		}
+		literals := make([]string, 0, len(enum.Values))
+		for _, label := range enum.labels() {
+			literals = append(literals, quoteLiteral(label))
+		}
+		stmt := fmt.Sprintf(
+			"CREATE TYPE %s AS ENUM (%s)",
+			e.escape(typeName), strings.Join(literals, ", "),
+		)
+		if _, err := e.executor().Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropEnumTypes drops the postgres enum types of the given fields no longer
+// used by any other column. It does nothing for other drivers.
+func (e baseSQLEngine) dropEnumTypes(fields Fields) error {
+	if e.driver != "postgres" {
+		return nil
+	}
+	for _, field := range fields {
+		enum, ok := enumField(field)
+		if !ok || enum.TypeName == "" {
+			continue
+		}
+		var used bool
+		query := Query{
+			Stmt: "SELECT EXISTS (SELECT 1 FROM pg_type t " +
+				"JOIN pg_depend d ON d.refobjid = t.oid " +
+				"WHERE t.typname = $1 AND pg_type_is_visible(t.oid) " +
+				"AND d.deptype = 'n')",
+			Args: []interface{}{enum.TypeName},
+		}
+		if err := scanRow(e.executor(), &used, query); err != nil {
+			return err
+		}
+		if used {
+			continue
+		}
+		stmt := fmt.Sprintf("DROP TYPE IF EXISTS %s", e.escape(enum.TypeName))
+		if _, err := e.executor().Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addValueInTx returns true if the postgres server can add values to enum
+// types within a transaction, supported since postgres 12.
+func (e baseSQLEngine) addValueInTx() (bool, error) {
+	var version int
+	query := Query{Stmt: "SELECT current_setting('server_version_num')::int"}
+	if err := scanRow(e.executor(), &version, query); err != nil {
+		return false, err
+	}
+	return version >= 120000, nil
+}
+
+// AddEnumValues implements the AddEnumValues method of the Engine interface.
+// Postgres servers prior to 12 can't add enum values within a transaction, so
+// they're added outside it and kept if the transaction is rolled back.
+func (e baseSQLEngine) AddEnumValues(
+	model *Model,
+	name string,
+	values ...Value,
+) error {
+	enum, ok := enumField(model.fields[name])
+	if !ok {
+		return fmt.Errorf("unknown enum field %s", name)
+	}
+	if e.driver != "postgres" {
+		return e.AlterChoices(model, name)
+	}
+	ex := e.executor()
+	if e.tx != nil {
+		inTx, err := e.addValueInTx()
+		if err != nil {
+			return err
+		}
+		if !inTx {
+			ex = e.db
+		}
+	}
+	for _, val := range values {
+		label, _, ok := enumLabel(val)
+		if !ok {
+			return fmt.Errorf("%s: invalid enum value: %v", name, val)
+		}
+		stmt := fmt.Sprintf(
+			"ALTER TYPE %s ADD VALUE IF NOT EXISTS %s",
+			e.escape(enum.TypeName), quoteLiteral(label),
+		)
+		if _, err := ex.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropEnumValues implements the DropEnumValues method of the Engine
+// interface. Postgres doesn't support removing values from enum types, so the
+// type is rebuilt with the model field values and the column converted to it.
+// The conversion fails if any row holds a removed value, or if other columns
+// use the type.
+func (e baseSQLEngine) DropEnumValues(
+	model *Model,
+	name string,
+	values ...Value,
+) error {
+	field := model.fields[name]
+	enum, ok := enumField(field)
+	if !ok {
+		return fmt.Errorf("unknown enum field %s", name)
+	}
+	if e.driver != "postgres" {
+		return e.AlterChoices(model, name)
+	}
+	typeName := e.escape(enum.TypeName)
+	oldType := e.escape(enum.TypeName + "_old")
+	stmt := fmt.Sprintf("ALTER TYPE %s RENAME TO %s", typeName, oldType)
+	if _, err := e.executor().Exec(stmt); err != nil {
+		return err
+	}
+	if err := e.createEnumTypes(Fields{name: enum}); err != nil {
+		return err
+	}
+	alter := fmt.Sprintf(
+		"ALTER TABLE %s ALTER COLUMN %s",
+		e.escape(model.Table()), e.escape(field.DBColumn(name)),
+	)
+	stmts := []string{fmt.Sprintf(
+		"%s TYPE %s USING %s::text::%s",
+		alter, typeName, e.escape(field.DBColumn(name)), typeName,
+	)}
+	if expr := dbDefault(field); expr != "" {
+		// The default can't be converted automatically.
+		stmts = []string{
+			alter + " DROP DEFAULT",
+			stmts[0],
+			fmt.Sprintf("%s SET DEFAULT (%s)", alter, expr),
+		}
+	}
+	stmts = append(stmts, fmt.Sprintf("DROP TYPE %s", oldType))
+	for _, stmt := range stmts {
+		if _, err := e.executor().Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
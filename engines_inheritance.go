@@ -0,0 +1,416 @@
+package gomodel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyColumn returns the unescaped column holding the row key on the model
+// table: the parent link for child models and the pk for the others.
+func keyColumn(m *Model) string {
+	name := m.pk
+	if m.parent != nil {
+		name = m.ParentLink()
+	}
+	return m.fields[name].DBColumn(name)
+}
+
+// parentLinkOptions returns the options of the parent link column of a child
+// model, which is the table primary key and references the parent key column.
+func (e baseSQLEngine) parentLinkOptions(m *Model) string {
+	return fmt.Sprintf(
+		" NOT NULL PRIMARY KEY REFERENCES %s (%s)",
+		e.escape(m.parent.Table()), e.escape(keyColumn(m.parent)),
+	)
+}
+
+// fromClause returns the FROM clause source for the given model, joining the
+// parent tables of child models.
+func (e baseSQLEngine) fromClause(m *Model) string {
+	from := e.escape(m.Table())
+	for child := m; child.parent != nil; child = child.parent {
+		from = fmt.Sprintf(
+			"%s JOIN %s ON %s.%s = %s.%s",
+			from,
+			e.escape(child.parent.Table()),
+			e.escape(child.parent.Table()),
+			e.escape(keyColumn(child.parent)),
+			e.escape(child.Table()),
+			e.escape(keyColumn(child)),
+		)
+	}
+	return from
+}
+
+// matchingKeys returns the keys of the child model rows matching the given
+// query options.
+func (e baseSQLEngine) matchingKeys(
+	m *Model,
+	opt QueryOptions,
+) ([]interface{}, error) {
+	stmt := fmt.Sprintf(
+		"SELECT %s.%s FROM %s",
+		e.escape(m.Table()), e.escape(keyColumn(m)), e.fromClause(m),
+	)
+	args := make([]interface{}, 0)
+	if opt.Conditioner != nil {
+		pred, err := e.predicate(m, opt, 1)
+		if err != nil {
+			return nil, err
+		}
+		stmt = fmt.Sprintf("%s WHERE %s", stmt, pred.Stmt)
+		args = pred.Args
+	}
+	rows, err := e.executor().Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	keys := []interface{}{}
+	for rows.Next() {
+		var key interface{}
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// keysCondition returns the condition matching the given keys on the model
+// table, with placeholders starting at the given index.
+func (e baseSQLEngine) keysCondition(
+	m *Model,
+	keys []interface{},
+	index int,
+) string {
+	placeholders := make([]string, 0, len(keys))
+	for i := range keys {
+		placeholders = append(placeholders, e.placeholder(index+i))
+	}
+	return fmt.Sprintf(
+		"%s IN (%s)",
+		e.escape(keyColumn(m)), strings.Join(placeholders, ", "),
+	)
+}
+
+// atomic runs the given function on the engine transaction, or on a new one
+// that is committed if the function succeeds and rolled back otherwise.
+func (e baseSQLEngine) atomic(fn func(tx baseSQLEngine) error) error {
+	if e.tx != nil {
+		return fn(e)
+	}
+	tx, err := beginTx(e.db)
+	if err != nil {
+		return err
+	}
+	e.tx = tx
+	if err := fn(e); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertChild inserts the parent row of a child model first, and then the
+// child row linked to it, within a transaction.
+func (e baseSQLEngine) insertChild(m *Model, values Values) (int64, error) {
+	var pk int64
+	err := e.atomic(func(tx baseSQLEngine) (err error) {
+		pk, err = tx.insertChildRows(m, values)
+		return err
+	})
+	return pk, err
+}
+
+// insertChildRows runs the insert statements of insertChild.
+func (e baseSQLEngine) insertChildRows(m *Model, values Values) (int64, error) {
+	parentValues := Values{}
+	localValues := Values{}
+	link := m.ParentLink()
+	for name, val := range values {
+		if m.isInherited(name) {
+			parentValues[name] = val
+		} else if name != link {
+			localValues[name] = val
+		}
+	}
+	pk, err := e.InsertRow(m.parent, parentValues)
+	if err != nil {
+		return 0, err
+	}
+	if m.parent.fields[m.parent.pk].IsAuto() {
+		localValues[link] = pk
+	} else if val, ok := parentValues[m.parent.pk]; ok && val != nil {
+		localValues[link] = val
+	} else {
+		return 0, fmt.Errorf("missing parent pk value")
+	}
+	query, err := e.insertQuery(m, localValues)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := e.executor().Exec(query.Stmt, query.Args...); err != nil {
+		return 0, err
+	}
+	return pk, nil
+}
+
+// insertChildReturning works as insertChild, but returns the given fields of
+// the inserted row, read through the RETURNING clause of each insert.
+func (e baseSQLEngine) insertChildReturning(
+	m *Model,
+	values Values,
+	fields []string,
+) (Rows, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no returning fields")
+	}
+	var row Values
+	err := e.atomic(func(tx baseSQLEngine) (err error) {
+		row, err = tx.insertReturningValues(m, values, fields)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(fields))
+	for _, name := range fields {
+		if name == "pk" {
+			name = m.pk
+		}
+		result = append(result, row[name])
+	}
+	return &valueRows{rows: [][]interface{}{result}}, nil
+}
+
+// insertReturningValues inserts the given values on the model table, and on
+// the parent tables first for child models, and returns the values of the
+// given fields read through the RETURNING clause of each insert.
+func (e baseSQLEngine) insertReturningValues(
+	m *Model,
+	values Values,
+	fields []string,
+) (Values, error) {
+	parentValues := Values{}
+	localValues := Values{}
+	link := m.ParentLink()
+	for name, val := range values {
+		if m.isInherited(name) {
+			parentValues[name] = val
+		} else if name != link {
+			localValues[name] = val
+		}
+	}
+	parentFields := make([]string, 0, len(fields)+1)
+	localFields := make([]string, 0, len(fields))
+	hasPk := false
+	for _, name := range fields {
+		if name == "pk" {
+			name = m.pk
+		}
+		hasPk = hasPk || name == m.pk
+		if m.isInherited(name) {
+			parentFields = append(parentFields, name)
+		} else {
+			localFields = append(localFields, name)
+		}
+	}
+	result := Values{}
+	if m.parent != nil {
+		if !hasPk {
+			parentFields = append(parentFields, m.pk)
+		}
+		row, err := e.insertReturningValues(
+			m.parent, parentValues, parentFields,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = row
+		localValues[link] = row[m.pk]
+	}
+	query, err := e.insertQuery(m, localValues)
+	if err != nil {
+		return nil, err
+	}
+	if len(localFields) == 0 {
+		_, err := e.executor().Exec(query.Stmt, query.Args...)
+		return result, err
+	}
+	columns := make([]string, 0, len(localFields))
+	for _, name := range localFields {
+		field, ok := m.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+		columns = append(columns, e.escape(field.DBColumn(name)))
+	}
+	stmt := fmt.Sprintf(
+		"%s RETURNING %s", query.Stmt, strings.Join(columns, ", "),
+	)
+	rows, err := e.executor().Query(stmt, query.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("insert returned no rows")
+	}
+	row := make([]interface{}, len(localFields))
+	recipients := make([]interface{}, len(localFields))
+	for i := range row {
+		recipients[i] = &row[i]
+	}
+	if err := rows.Scan(recipients...); err != nil {
+		return nil, err
+	}
+	for i, name := range localFields {
+		if b, ok := row[i].([]byte); ok {
+			row[i] = string(b)
+		}
+		result[name] = row[i]
+	}
+	return result, rows.Err()
+}
+
+// valueRows holds rows already read from the database, so that they can be
+// scanned after the transaction reading them is committed.
+type valueRows struct {
+	rows [][]interface{}
+	next int
+}
+
+// Close implements the Close method of the Rows interface.
+func (r *valueRows) Close() error {
+	return nil
+}
+
+// Err implements the Err method of the Rows interface.
+func (r *valueRows) Err() error {
+	return nil
+}
+
+// Next implements the Next method of the Rows interface.
+func (r *valueRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+// Scan implements the Scan method of the Rows interface.
+func (r *valueRows) Scan(dest ...interface{}) error {
+	if r.next < 1 || r.next > len(r.rows) {
+		return fmt.Errorf("scan called without calling next")
+	}
+	row := r.rows[r.next-1]
+	if len(dest) != len(row) {
+		return fmt.Errorf(
+			"expected %d destination arguments, got %d", len(row), len(dest),
+		)
+	}
+	for i, val := range row {
+		if err := setRecipient(dest[i], val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateChild updates the rows of a child model and its parents within a
+// transaction, setting the given values on the tables storing the fields.
+func (e baseSQLEngine) updateChild(
+	m *Model,
+	values Values,
+	opt QueryOptions,
+) (int64, error) {
+	var rows int64
+	err := e.atomic(func(tx baseSQLEngine) (err error) {
+		rows, err = tx.updateChildRows(m, values, opt)
+		return err
+	})
+	return rows, err
+}
+
+// updateChildRows runs the update statements of updateChild.
+func (e baseSQLEngine) updateChildRows(
+	m *Model,
+	values Values,
+	opt QueryOptions,
+) (int64, error) {
+	for name := range values {
+		if _, ok := m.fields[name]; !ok {
+			return 0, fmt.Errorf("unknown field %s", name)
+		}
+	}
+	keys, err := e.matchingKeys(m, opt)
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	for table := m; table != nil; table = table.parent {
+		tableValues := Values{}
+		for name, val := range values {
+			isLocal := !table.isInherited(name)
+			if _, ok := table.fields[name]; ok && isLocal {
+				tableValues[name] = val
+			}
+		}
+		delete(tableValues, table.pk)
+		if table.parent != nil {
+			delete(tableValues, table.ParentLink())
+		}
+		if len(tableValues) == 0 {
+			continue
+		}
+		query, err := e.updateQuery(table, tableValues, QueryOptions{})
+		if err != nil {
+			return 0, err
+		}
+		stmt := fmt.Sprintf(
+			"%s WHERE %s",
+			query.Stmt, e.keysCondition(table, keys, len(query.Args)+1),
+		)
+		args := append(query.Args, keys...)
+		if _, err := e.executor().Exec(stmt, args...); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(keys)), nil
+}
+
+// deleteChild deletes the rows of a child model and its parents within a
+// transaction.
+func (e baseSQLEngine) deleteChild(m *Model, opt QueryOptions) (int64, error) {
+	var rows int64
+	err := e.atomic(func(tx baseSQLEngine) (err error) {
+		rows, err = tx.deleteChildRows(m, opt)
+		return err
+	})
+	return rows, err
+}
+
+// deleteChildRows runs the delete statements of deleteChild.
+func (e baseSQLEngine) deleteChildRows(
+	m *Model,
+	opt QueryOptions,
+) (int64, error) {
+	keys, err := e.matchingKeys(m, opt)
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	for table := m; table != nil; table = table.parent {
+		stmt := fmt.Sprintf(
+			"DELETE FROM %s WHERE %s",
+			e.escape(table.Table()), e.keysCondition(table, keys, 1),
+		)
+		if _, err := e.executor().Exec(stmt, keys...); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(keys)), nil
+}
@@ -0,0 +1,446 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lookup represents a parsed conditioner key, in the form
+// "name__transform__transform operator".
+type lookup struct {
+	name       string
+	field      Field
+	transforms []string
+	operator   string
+}
+
+// parseLookup parses the given conditioner key for the given model. The name
+// can be followed by transforms (e.g. JSON key paths) separated by double
+// underscores, and an operator separated by a space.
+func parseLookup(model *Model, key string) (lookup, error) {
+	args := strings.Split(key, " ")
+	lkp := lookup{name: args[0]}
+	if len(args) > 1 {
+		lkp.operator = args[1]
+	}
+	if lkp.name == "pk" {
+		lkp.name = model.pk
+	}
+	if field, ok := model.fields[lkp.name]; ok {
+		lkp.field = field
+		return lkp, nil
+	}
+	parts := strings.Split(lkp.name, "__")
+	lkp.name = parts[0]
+	if lkp.name == "pk" {
+		lkp.name = model.pk
+	}
+	field, ok := model.fields[lkp.name]
+	if !ok {
+		return lkp, fmt.Errorf("unknown field %s", lkp.name)
+	}
+	lkp.field = field
+	lkp.transforms = parts[1:]
+	return lkp, nil
+}
+
+// lookupCondition returns the SQL condition and arguments for lookups that
+// require field specific transforms or operators. The returned boolean is
+// false if the lookup must be resolved as a regular comparison.
+func (e baseSQLEngine) lookupCondition(
+	column string,
+	lkp lookup,
+	value Value,
+	pIndex int,
+) (string, []interface{}, bool, error) {
+	if err := encryptedLookup(lkp, value); err != nil {
+		return "", nil, false, err
+	}
+	if isJSONLookup(lkp) {
+		cond, args, err := e.jsonCondition(column, lkp, value, pIndex)
+		return cond, args, true, err
+	} else if isArrayLookup(lkp) {
+		cond, args, err := e.arrayCondition(column, lkp, value, pIndex)
+		return cond, args, true, err
+	}
+	return "", nil, false, nil
+}
+
+// encryptedLookup returns an error if the lookup can't be resolved on an
+// encrypted field. Only null checks and equality comparisons of deterministic
+// fields are supported, since the database only sees the ciphertext.
+func encryptedLookup(lkp lookup, value Value) error {
+	field, ok := encryptedField(lkp.field)
+	if !ok {
+		return nil
+	}
+	isEqual := lkp.operator == "" || lkp.operator == "="
+	if len(lkp.transforms) > 0 || !isEqual {
+		return fmt.Errorf("unsupported lookup on encrypted field %s", lkp.name)
+	}
+	if value != nil && !field.Deterministic {
+		return fmt.Errorf(
+			"equality lookup on non deterministic encrypted field %s",
+			lkp.name,
+		)
+	}
+	return nil
+}
+
+// arrayOperators maps the operators supported by array lookups to the postgres
+// array operators.
+var arrayOperators = map[string]string{
+	"contains":     "@>",
+	"contained_by": "<@",
+	"overlap":      "&&",
+}
+
+// isArrayLookup returns true if the lookup must be resolved by the
+// arrayCondition method.
+func isArrayLookup(lkp lookup) bool {
+	if _, ok := lkp.field.(arrayLookuper); !ok {
+		return false
+	}
+	return len(lkp.transforms) > 0 || arrayOperators[lkp.operator] != ""
+}
+
+// arrayCondition returns the SQL condition and arguments for an array lookup.
+func (e baseSQLEngine) arrayCondition(
+	column string,
+	lkp lookup,
+	value Value,
+	pIndex int,
+) (string, []interface{}, error) {
+	if e.driver != "postgres" {
+		return "", nil, fmt.Errorf(
+			"array lookups not supported by %s", e.driver,
+		)
+	}
+	expr := e.escape(column)
+	if len(lkp.transforms) > 0 {
+		if len(lkp.transforms) > 1 || lkp.transforms[0] != "len" {
+			return "", nil, fmt.Errorf(
+				"unsupported array transform: %s",
+				strings.Join(lkp.transforms, "__"),
+			)
+		}
+		operator := "="
+		if lkp.operator != "" {
+			op, ok := e.operators[lkp.operator]
+			if !ok {
+				return "", nil, fmt.Errorf("invalid operator: %s", lkp.operator)
+			}
+			operator = op
+		}
+		length, err := toInt64(value, 0, math.MaxInt32)
+		if err != nil {
+			return "", nil, err
+		}
+		cond := fmt.Sprintf(
+			"COALESCE(array_length(%s, 1), 0) %s %s",
+			expr, operator, e.placeholder(pIndex),
+		)
+		return cond, []interface{}{length}, nil
+	}
+	driverVal, err := lkp.field.DriverValue(value, e.driver)
+	if err != nil {
+		return "", nil, err
+	} else if driverVal == nil {
+		return "", nil, fmt.Errorf("%s value can't be null", lkp.operator)
+	}
+	cond := fmt.Sprintf(
+		"%s %s CAST(%s AS %s)",
+		expr, arrayOperators[lkp.operator], e.placeholder(pIndex),
+		lkp.field.DataType(e.driver),
+	)
+	return cond, []interface{}{driverVal}, nil
+}
+
+// jsonOperators holds the operators supported by JSON lookups in addition to
+// the engine comparison ones.
+var jsonOperators = map[string]bool{
+	"has_key":  true,
+	"contains": true,
+}
+
+// isJSONLookup returns true if the lookup must be resolved by the jsonCondition
+// method.
+func isJSONLookup(lkp lookup) bool {
+	if _, ok := lkp.field.(jsonLookuper); !ok {
+		return false
+	}
+	return len(lkp.transforms) > 0 || jsonOperators[lkp.operator]
+}
+
+// jsonValue returns the given value encoded as a JSON document, and decoded
+// back into a generic value. Unlike field values, strings are always encoded
+// as JSON strings; json.RawMessage can be used for encoded documents.
+func jsonValue(value Value) (string, interface{}, error) {
+	var data []byte
+	if s, ok := value.(string); ok {
+		encoded, err := json.Marshal(s)
+		if err != nil {
+			return "", nil, err
+		}
+		data = encoded
+	} else {
+		doc := NullJSON{}
+		if err := doc.Scan(value); err != nil {
+			return "", nil, err
+		}
+		data = doc.JSON
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return "", nil, err
+	}
+	return string(data), generic, nil
+}
+
+// postgresTextArray returns the postgres text array literal for the given
+// elements.
+func postgresTextArray(elems []string) string {
+	quoted := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		elem = strings.Replace(elem, `\`, `\\`, -1)
+		elem = strings.Replace(elem, `"`, `\"`, -1)
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, elem))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(quoted, ","))
+}
+
+// sqliteJSONPath returns the json1 path for the given key path.
+func sqliteJSONPath(path []string) string {
+	jsonPath := "$"
+	for _, key := range path {
+		if _, err := strconv.Atoi(key); err == nil {
+			jsonPath += fmt.Sprintf("[%s]", key)
+		} else {
+			jsonPath += fmt.Sprintf(`."%s"`, key)
+		}
+	}
+	return jsonPath
+}
+
+// jsonCondition returns the SQL condition and arguments for a JSON lookup.
+func (e baseSQLEngine) jsonCondition(
+	column string,
+	lkp lookup,
+	value Value,
+	pIndex int,
+) (string, []interface{}, error) {
+	if e.driver == "postgres" {
+		return e.postgresJSONCondition(column, lkp, value, pIndex)
+	} else if e.driver == "sqlite3" {
+		return e.sqliteJSONCondition(column, lkp, value, pIndex)
+	}
+	return "", nil, fmt.Errorf("json lookups not supported by %s", e.driver)
+}
+
+// postgresJSONCondition returns the jsonb condition for the given lookup.
+func (e baseSQLEngine) postgresJSONCondition(
+	column string,
+	lkp lookup,
+	value Value,
+	pIndex int,
+) (string, []interface{}, error) {
+	args := []interface{}{}
+	expr := e.escape(column)
+	if len(lkp.transforms) > 0 {
+		expr = fmt.Sprintf(
+			"(%s #> CAST(%s AS text[]))", expr, e.placeholder(pIndex),
+		)
+		args = append(args, postgresTextArray(lkp.transforms))
+		pIndex += 1
+	}
+	switch lkp.operator {
+	case "has_key":
+		key, ok := value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("has_key value must be a string")
+		}
+		cond := fmt.Sprintf("%s ? %s", expr, e.placeholder(pIndex))
+		return cond, append(args, key), nil
+	case "contains":
+		doc, _, err := jsonValue(value)
+		if err != nil {
+			return "", nil, err
+		}
+		cond := fmt.Sprintf(
+			"%s @> CAST(%s AS jsonb)", expr, e.placeholder(pIndex),
+		)
+		return cond, append(args, doc), nil
+	}
+	operator := "="
+	if lkp.operator != "" {
+		op, ok := e.operators[lkp.operator]
+		if !ok {
+			return "", nil, fmt.Errorf("invalid operator: %s", lkp.operator)
+		}
+		operator = op
+	}
+	if value == nil && operator == "=" {
+		return fmt.Sprintf("%s IS NULL", expr), args, nil
+	}
+	doc, _, err := jsonValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+	cond := fmt.Sprintf(
+		"%s %s CAST(%s AS jsonb)", expr, operator, e.placeholder(pIndex),
+	)
+	return cond, append(args, doc), nil
+}
+
+// sqliteJSONCondition returns the json1 condition for the given lookup.
+func (e baseSQLEngine) sqliteJSONCondition(
+	column string,
+	lkp lookup,
+	value Value,
+	pIndex int,
+) (string, []interface{}, error) {
+	column = e.escape(column)
+	switch lkp.operator {
+	case "has_key":
+		key, ok := value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("has_key value must be a string")
+		}
+		path := append(append([]string{}, lkp.transforms...), key)
+		cond := fmt.Sprintf(
+			"json_type(%s, %s) IS NOT NULL", column, e.placeholder(pIndex),
+		)
+		return cond, []interface{}{sqliteJSONPath(path)}, nil
+	case "contains":
+		_, doc, err := jsonValue(value)
+		if err != nil {
+			return "", nil, err
+		}
+		conds, args, err := e.sqliteJSONContains(
+			column, lkp.transforms, doc, pIndex,
+		)
+		if err != nil {
+			return "", nil, err
+		}
+		return strings.Join(conds, " AND "), args, nil
+	}
+	operator := "="
+	if lkp.operator != "" {
+		op, ok := e.operators[lkp.operator]
+		if !ok {
+			return "", nil, fmt.Errorf("invalid operator: %s", lkp.operator)
+		}
+		operator = op
+	}
+	path := sqliteJSONPath(lkp.transforms)
+	if value == nil && operator == "=" {
+		cond := fmt.Sprintf(
+			"json_extract(%s, %s) IS NULL", column, e.placeholder(pIndex),
+		)
+		return cond, []interface{}{path}, nil
+	}
+	doc, generic, err := jsonValue(value)
+	if err != nil {
+		return "", nil, err
+	}
+	placeholder := e.placeholder(pIndex + 1)
+	var arg interface{} = generic
+	switch generic.(type) {
+	case map[string]interface{}, []interface{}:
+		placeholder = fmt.Sprintf("json(%s)", placeholder)
+		arg = doc
+	}
+	cond := fmt.Sprintf(
+		"json_extract(%s, %s) %s %s",
+		column, e.placeholder(pIndex), operator, placeholder,
+	)
+	return cond, []interface{}{path, arg}, nil
+}
+
+// sqliteJSONContains returns the list of json1 conditions checking that the
+// document at the given path contains the given decoded value.
+func (e baseSQLEngine) sqliteJSONContains(
+	column string,
+	path []string,
+	value interface{},
+	pIndex int,
+) ([]string, []interface{}, error) {
+	conds := []string{}
+	args := []interface{}{}
+	jsonPath := sqliteJSONPath(path)
+	switch val := value.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			cond := fmt.Sprintf(
+				"json_type(%s, %s) = 'object'", column, e.placeholder(pIndex),
+			)
+			return []string{cond}, []interface{}{jsonPath}, nil
+		}
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := append(append([]string{}, path...), key)
+			keyConds, keyArgs, err := e.sqliteJSONContains(
+				column, keyPath, val[key], pIndex,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			conds = append(conds, keyConds...)
+			args = append(args, keyArgs...)
+			pIndex += len(keyArgs)
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			cond := fmt.Sprintf(
+				"json_type(%s, %s) = 'array'", column, e.placeholder(pIndex),
+			)
+			return []string{cond}, []interface{}{jsonPath}, nil
+		}
+		for _, elem := range val {
+			switch elem.(type) {
+			case map[string]interface{}, []interface{}:
+				return nil, nil, fmt.Errorf(
+					"nested documents in arrays not supported by %s", e.driver,
+				)
+			}
+			cond := fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM json_each(%s, %s) WHERE value = %s)",
+				column, e.placeholder(pIndex), e.placeholder(pIndex+1),
+			)
+			if elem == nil {
+				cond = fmt.Sprintf(
+					"EXISTS (SELECT 1 FROM json_each(%s, %s) "+
+						"WHERE type = 'null')",
+					column, e.placeholder(pIndex),
+				)
+				conds = append(conds, cond)
+				args = append(args, jsonPath)
+				pIndex += 1
+				continue
+			}
+			conds = append(conds, cond)
+			args = append(args, jsonPath, elem)
+			pIndex += 2
+		}
+	case nil:
+		cond := fmt.Sprintf(
+			"json_type(%s, %s) = 'null'", column, e.placeholder(pIndex),
+		)
+		return []string{cond}, []interface{}{jsonPath}, nil
+	default:
+		cond := fmt.Sprintf(
+			"json_extract(%s, %s) = %s",
+			column, e.placeholder(pIndex), e.placeholder(pIndex+1),
+		)
+		return []string{cond}, []interface{}{jsonPath, val}, nil
+	}
+	return conds, args, nil
+}
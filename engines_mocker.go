@@ -8,20 +8,25 @@ import (
 // MockedEngineResults holds the results of the Engine interface methods to
 // be returned by a MockedEngine.
 type MockedEngineResults struct {
-	Stop        error
-	TxSupport   bool
-	BeginTx     error
-	CommitTx    error
-	RollbackTx  error
-	CreateTable error
-	RenameTable error
-	CopyTable   error
-	DropTable   error
-	AddIndex    error
-	DropIndex   error
-	AddColumns  error
-	DropColumns error
-	SelectQuery struct {
+	Stop           error
+	TxSupport      bool
+	BeginTx        error
+	CommitTx       error
+	RollbackTx     error
+	CreateTable    error
+	RenameTable    error
+	CopyTable      error
+	DropTable      error
+	AddIndex       error
+	DropIndex      error
+	AddColumns     error
+	DropColumns    error
+	AlterChoices   error
+	AddEnumValues  error
+	DropEnumValues error
+	AddConstraint  error
+	DropConstraint error
+	SelectQuery    struct {
 		Query Query
 		Err   error
 	}
@@ -33,14 +38,26 @@ type MockedEngineResults struct {
 		Id  int64
 		Err error
 	}
+	InsertRowReturning struct {
+		Rows Rows
+		Err  error
+	}
 	UpdateRows struct {
 		Number int64
 		Err    error
 	}
+	UpdateRowsReturning struct {
+		Rows Rows
+		Err  error
+	}
 	DeleteRows struct {
 		Number int64
 		Err    error
 	}
+	DeleteRowsReturning struct {
+		Rows Rows
+		Err  error
+	}
 	CountRows struct {
 		Number int64
 		Err    error
@@ -82,6 +99,28 @@ type MockedEngineArgs struct {
 		Model  *Model
 		Fields []string
 	}
+	AlterChoices struct {
+		Model *Model
+		Field string
+	}
+	AddEnumValues struct {
+		Model  *Model
+		Field  string
+		Values []Value
+	}
+	DropEnumValues struct {
+		Model  *Model
+		Field  string
+		Values []Value
+	}
+	AddConstraint struct {
+		Model *Model
+		Name  string
+	}
+	DropConstraint struct {
+		Model *Model
+		Name  string
+	}
 	SelectQuery struct {
 		Model   *Model
 		Options QueryOptions
@@ -94,15 +133,29 @@ type MockedEngineArgs struct {
 		Model  *Model
 		Values Values
 	}
+	InsertRowReturning struct {
+		Model  *Model
+		Values Values
+		Fields []string
+	}
 	UpdateRows struct {
 		Model   *Model
 		Values  Values
 		Options QueryOptions
 	}
+	UpdateRowsReturning struct {
+		Model   *Model
+		Values  Values
+		Options QueryOptions
+	}
 	DeleteRows struct {
 		Model   *Model
 		Options QueryOptions
 	}
+	DeleteRowsReturning struct {
+		Model   *Model
+		Options QueryOptions
+	}
 	CountRows struct {
 		Model   *Model
 		Options QueryOptions
@@ -264,6 +317,56 @@ func (e MockedEngine) DropColumns(model *Model, fields ...string) error {
 	return e.Results.DropColumns
 }
 
+// AlterChoices mocks the AlterChoices method of the Engine interface.
+func (e MockedEngine) AlterChoices(model *Model, field string) error {
+	e.calls["AlterChoices"] += 1
+	e.Args.AlterChoices.Model = model
+	e.Args.AlterChoices.Field = field
+	return e.Results.AlterChoices
+}
+
+// AddEnumValues mocks the AddEnumValues method of the Engine interface.
+func (e MockedEngine) AddEnumValues(
+	model *Model,
+	field string,
+	values ...Value,
+) error {
+	e.calls["AddEnumValues"] += 1
+	e.Args.AddEnumValues.Model = model
+	e.Args.AddEnumValues.Field = field
+	e.Args.AddEnumValues.Values = values
+	return e.Results.AddEnumValues
+}
+
+// DropEnumValues mocks the DropEnumValues method of the Engine interface.
+func (e MockedEngine) DropEnumValues(
+	model *Model,
+	field string,
+	values ...Value,
+) error {
+	e.calls["DropEnumValues"] += 1
+	e.Args.DropEnumValues.Model = model
+	e.Args.DropEnumValues.Field = field
+	e.Args.DropEnumValues.Values = values
+	return e.Results.DropEnumValues
+}
+
+// AddConstraint mocks the AddConstraint method of the Engine interface.
+func (e MockedEngine) AddConstraint(model *Model, name string) error {
+	e.calls["AddConstraint"] += 1
+	e.Args.AddConstraint.Model = model
+	e.Args.AddConstraint.Name = name
+	return e.Results.AddConstraint
+}
+
+// DropConstraint mocks the DropConstraint method of the Engine interface.
+func (e MockedEngine) DropConstraint(model *Model, name string) error {
+	e.calls["DropConstraint"] += 1
+	e.Args.DropConstraint.Model = model
+	e.Args.DropConstraint.Name = name
+	return e.Results.DropConstraint
+}
+
 // SelectQuery mocks the SelectQuery method of the Engine interface.
 func (e MockedEngine) SelectQuery(m *Model, opt QueryOptions) (Query, error) {
 	e.calls["SelectQuery"] += 1
@@ -288,6 +391,21 @@ func (e MockedEngine) InsertRow(model *Model, values Values) (int64, error) {
 	return e.Results.InsertRow.Id, e.Results.InsertRow.Err
 }
 
+// InsertRowReturning mocks the InsertRowReturning method of the Engine
+// interface.
+func (e MockedEngine) InsertRowReturning(
+	model *Model,
+	values Values,
+	fields ...string,
+) (Rows, error) {
+	e.calls["InsertRowReturning"] += 1
+	e.Args.InsertRowReturning.Model = model
+	e.Args.InsertRowReturning.Values = values
+	e.Args.InsertRowReturning.Fields = fields
+	results := e.Results.InsertRowReturning
+	return results.Rows, results.Err
+}
+
 // UpdateRows mocks the UpdateRows method of the Engine interface.
 func (e MockedEngine) UpdateRows(
 	model *Model,
@@ -301,6 +419,21 @@ func (e MockedEngine) UpdateRows(
 	return e.Results.UpdateRows.Number, e.Results.UpdateRows.Err
 }
 
+// UpdateRowsReturning mocks the UpdateRowsReturning method of the Engine
+// interface.
+func (e MockedEngine) UpdateRowsReturning(
+	model *Model,
+	values Values,
+	options QueryOptions,
+) (Rows, error) {
+	e.calls["UpdateRowsReturning"] += 1
+	e.Args.UpdateRowsReturning.Model = model
+	e.Args.UpdateRowsReturning.Values = values
+	e.Args.UpdateRowsReturning.Options = options
+	results := e.Results.UpdateRowsReturning
+	return results.Rows, results.Err
+}
+
 // DeleteRows mocks the DeleteRows method of the Engine interface.
 func (e MockedEngine) DeleteRows(m *Model, opt QueryOptions) (int64, error) {
 	e.calls["DeleteRows"] += 1
@@ -309,6 +442,19 @@ func (e MockedEngine) DeleteRows(m *Model, opt QueryOptions) (int64, error) {
 	return e.Results.DeleteRows.Number, e.Results.DeleteRows.Err
 }
 
+// DeleteRowsReturning mocks the DeleteRowsReturning method of the Engine
+// interface.
+func (e MockedEngine) DeleteRowsReturning(
+	m *Model,
+	opt QueryOptions,
+) (Rows, error) {
+	e.calls["DeleteRowsReturning"] += 1
+	e.Args.DeleteRowsReturning.Model = m
+	e.Args.DeleteRowsReturning.Options = opt
+	results := e.Results.DeleteRowsReturning
+	return results.Rows, results.Err
+}
+
 // CountRows mocks the CountRows method of the Engine interface.
 func (e MockedEngine) CountRows(m *Model, opt QueryOptions) (int64, error) {
 	e.calls["CountRows"] += 1
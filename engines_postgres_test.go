@@ -3,6 +3,7 @@ package gomodel
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -156,6 +157,49 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("AddIndexDefinition", func(t *testing.T) {
+		tests := []struct {
+			idx      Index
+			expected string
+		}{
+			{
+				Index{Fields: []string{"email", "-updated"}, Unique: true},
+				`CREATE UNIQUE INDEX "idx" ON "users_user" ` +
+					`("email", "updated" DESC)`,
+			},
+			{
+				Index{
+					Expressions: []Expression{Lower("email")},
+					Condition:   Q{"active": true},
+				},
+				`CREATE INDEX "idx" ON "users_user" ((LOWER("email"))) ` +
+					`WHERE "active" = TRUE`,
+			},
+			{
+				Index{Fields: []string{"updated"}, Method: "brin"},
+				`CREATE INDEX "idx" ON "users_user" USING brin ("updated")`,
+			},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		for _, test := range tests {
+			mockedDB.Reset()
+			model.meta.IndexDefinitions = IndexDefinitions{"idx": test.idx}
+			if err := engine.AddIndex(model, "idx"); err != nil {
+				t.Fatal(err)
+			}
+			if stmt := mockedDB.queries[0].Stmt; stmt != test.expected {
+				t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", test.expected, stmt)
+			}
+		}
+	})
+
+	t.Run("AddIndexNoFields", func(t *testing.T) {
+		mockedDB.Reset()
+		if err := engine.AddIndex(model, "missing_index"); err == nil {
+			t.Error("expected index with no fields error")
+		}
+	})
+
 	t.Run("DropIndex", func(t *testing.T) {
 		mockedDB.Reset()
 		if err := engine.DropIndex(model, "test_index"); err != nil {
@@ -171,6 +215,304 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateTableEnum", func(t *testing.T) {
+		mockedDB.Reset()
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id": IntegerField{Auto: true},
+				"status": EnumField{
+					Values: []Value{testStatusActive, testStatusInactive},
+				},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		enumModel.SetupEnumTypes()
+		if err := engine.CreateTable(enumModel, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 3 {
+			t.Fatalf("expected three queries, got %d", len(mockedDB.queries))
+		}
+		if args := mockedDB.queries[0].Args; args[0] != "users_account_status" {
+			t.Errorf("expected enum type lookup, got %v", args)
+		}
+		expected := `CREATE TYPE "users_account_status" AS ENUM ` +
+			`('active', 'inactive')`
+		if stmt := mockedDB.queries[1].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		expected = `"status" "users_account_status" NOT NULL`
+		if stmt := mockedDB.queries[2].Stmt; !strings.Contains(stmt, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+
+		existing := `["inactive", "active"]`
+		mockedScanRow := scanRow
+		defer func() { scanRow = mockedScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			result := dest.(*sql.NullString)
+			result.String, result.Valid = existing, true
+			return mockedScanRow(ex, dest, query)
+		}
+		mockedDB.Reset()
+		if err := engine.CreateTable(enumModel, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Errorf("expected existing type to be reused")
+		}
+		existing = `["active", "inactive", "disabled"]`
+		mockedDB.Reset()
+		if err := engine.CreateTable(enumModel, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Errorf("expected type with removed values to be reused")
+		}
+		existing = `["active", "disabled"]`
+		if err := engine.CreateTable(enumModel, false); err == nil {
+			t.Error("expected enum type clash error")
+		}
+	})
+
+	t.Run("DropTableEnum", func(t *testing.T) {
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id":     IntegerField{Auto: true},
+				"status": EnumField{Values: []Value{1, 2}, TypeName: "level"},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		used := false
+		mockedScanRow := scanRow
+		defer func() { scanRow = mockedScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*bool) = used
+			return mockedScanRow(ex, dest, query)
+		}
+		mockedDB.Reset()
+		if err := engine.DropTable(enumModel); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 3 {
+			t.Fatalf("expected three queries, got %d", len(mockedDB.queries))
+		}
+		if args := mockedDB.queries[1].Args; args[0] != "level" {
+			t.Errorf("expected enum type usage lookup, got %v", args)
+		}
+		expected := `DROP TYPE IF EXISTS "level"`
+		if stmt := mockedDB.queries[2].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		used = true
+		mockedDB.Reset()
+		if err := engine.DropTable(enumModel); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Errorf("expected type used by other columns to be kept")
+		}
+	})
+
+	t.Run("AddEnumValues", func(t *testing.T) {
+		mockedDB.Reset()
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id":    IntegerField{Auto: true},
+				"level": EnumField{Values: []Value{1, 2}, TypeName: "level"},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.AddEnumValues(enumModel, "level", 2); err != nil {
+			t.Fatal(err)
+		}
+		expected := `ALTER TYPE "level" ADD VALUE IF NOT EXISTS '2'`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		if err := engine.AddEnumValues(enumModel, "id", 3); err == nil {
+			t.Error("expected unknown enum field error")
+		}
+
+		version := 110000
+		mockedScanRow := scanRow
+		defer func() { scanRow = mockedScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*int) = version
+			return mockedScanRow(ex, dest, query)
+		}
+		mockedTx := &dbMocker{}
+		txEngine := engine
+		txEngine.tx = mockedTx
+		mockedDB.Reset()
+		if err := txEngine.AddEnumValues(enumModel, "level", 2); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedTx.queries) != 1 || len(mockedDB.queries) != 1 {
+			t.Fatal("expected value to be added outside the transaction")
+		}
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		version = 120000
+		mockedTx.Reset()
+		mockedDB.Reset()
+		if err := txEngine.AddEnumValues(enumModel, "level", 2); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedTx.queries) != 2 || len(mockedDB.queries) != 0 {
+			t.Error("expected value to be added within the transaction")
+		}
+	})
+
+	t.Run("DropEnumValues", func(t *testing.T) {
+		mockedDB.Reset()
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id": IntegerField{Auto: true},
+				"level": EnumField{
+					Values:    []Value{1, 3},
+					TypeName:  "level",
+					DBDefault: Literal("1"),
+				},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.DropEnumValues(enumModel, "level", 2); err != nil {
+			t.Fatal(err)
+		}
+		alter := `ALTER TABLE "users_account" ALTER COLUMN "level"`
+		expected := []string{
+			`ALTER TYPE "level" RENAME TO "level_old"`,
+			"",
+			`CREATE TYPE "level" AS ENUM ('1', '3')`,
+			alter + ` DROP DEFAULT`,
+			alter + ` TYPE "level" USING "level"::text::"level"`,
+			alter + ` SET DEFAULT ('1')`,
+			`DROP TYPE "level_old"`,
+		}
+		if len(mockedDB.queries) != len(expected) {
+			t.Fatalf("expected %d queries, got %d", len(expected),
+				len(mockedDB.queries))
+		}
+		for i, stmt := range expected {
+			if got := mockedDB.queries[i].Stmt; stmt != "" && got != stmt {
+				t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", stmt, got)
+			}
+		}
+		if err := engine.DropEnumValues(enumModel, "id", 3); err == nil {
+			t.Error("expected unknown enum field error")
+		}
+	})
+
+	t.Run("CreateTableConstraints", func(t *testing.T) {
+		mockedDB.Reset()
+		constraintsModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id":     IntegerField{Auto: true},
+				"email":  CharField{MaxLength: 100},
+				"active": BooleanField{},
+			},
+			meta: Options{
+				Table: "users_account",
+				Constraints: Constraints{
+					"unique_active_email": UniqueConstraint{
+						Fields:    []string{"email"},
+						Condition: Q{"active": true},
+					},
+				},
+			},
+		}
+		if err := engine.CreateTable(constraintsModel, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Fatalf("expected two queries, got %d", len(mockedDB.queries))
+		}
+		expected := `CREATE UNIQUE INDEX "unique_active_email" ON ` +
+			`"users_account" ("email") WHERE "active" = TRUE`
+		if stmt := mockedDB.queries[1].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("AddConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"id_check": CheckConstraint{Check: Q{"id >": 0}},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.AddConstraint(model, "id_check"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `ALTER TABLE "users_user" ADD CONSTRAINT "id_check" ` +
+			`CHECK ("id" > 0)`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("AddUnknownConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		if err := engine.AddConstraint(model, "id_check"); err == nil {
+			t.Error("expected unknown constraint error")
+		}
+	})
+
+	t.Run("DropConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{
+				Fields: []string{"email", "active"},
+			},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.DropConstraint(model, "unique_email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `ALTER TABLE "users_user" DROP CONSTRAINT "unique_email"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("DropPartialUniqueConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{
+				Fields:    []string{"email"},
+				Condition: Q{"active": true},
+			},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.DropConstraint(model, "unique_email"); err != nil {
+			t.Fatal(err)
+		}
+		expected := `DROP INDEX "unique_email"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("AddNotNullColumnNoDefault", func(t *testing.T) {
 		mockedDB.Reset()
 		fields := Fields{
@@ -181,6 +523,58 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateTableDBDefaults", func(t *testing.T) {
+		mockedDB.Reset()
+		dbModel := &Model{
+			name: "Order",
+			pk:   "id",
+			fields: Fields{
+				"id":       IntegerField{Auto: true, PrimaryKey: true},
+				"created":  DateTimeField{DBDefault: Now()},
+				"quantity": IntegerField{},
+				"total": GeneratedField{
+					Base:       IntegerField{},
+					Expression: `"quantity" * 2`,
+				},
+			},
+			meta: Options{Table: "orders_order"},
+		}
+		if err := engine.CreateTable(dbModel, false); err != nil {
+			t.Fatal(err)
+		}
+		stmt := mockedDB.queries[0].Stmt
+		expected := `"created" TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP)`
+		if !strings.Contains(stmt, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+		expected = `"total" INTEGER GENERATED ALWAYS AS ("quantity" * 2) STORED`
+		if !strings.Contains(stmt, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+	})
+
+	t.Run("AddColumnsDBDefault", func(t *testing.T) {
+		mockedDB.Reset()
+		fields := Fields{"status": CharField{
+			MaxLength: 10, DBDefault: Literal("new"),
+		}}
+		if err := engine.AddColumns(model, fields); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Fatalf("expected 2 queries, got %d", len(mockedDB.queries))
+		}
+		expected := `ALTER TABLE "users_user" ADD COLUMN "status" ` +
+			`VARCHAR(10)  DEFAULT ('new')`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		expected = `ALTER TABLE "users_user" ALTER COLUMN "status" SET NOT NULL`
+		if stmt := mockedDB.queries[1].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("AddColumns", func(t *testing.T) {
 		mockedDB.Reset()
 		fields := Fields{
@@ -212,6 +606,75 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateTableStrictChoices", func(t *testing.T) {
+		mockedDB.Reset()
+		choicesModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id": IntegerField{Auto: true},
+				"role": CharField{
+					MaxLength: 10,
+					Choices: []Choice{
+						{"admin", "Admin"}, {"o'neil", "O'Neil"},
+					},
+					StrictChoices: true,
+				},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.CreateTable(choicesModel, false); err != nil {
+			t.Fatal(err)
+		}
+		expected := `"role" VARCHAR(10) NOT NULL CONSTRAINT "role_choices" ` +
+			`CHECK ("role" IN ('admin', 'o''neil'))`
+		if stmt := mockedDB.queries[0].Stmt; !strings.Contains(stmt, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+	})
+
+	t.Run("AlterChoices", func(t *testing.T) {
+		mockedDB.Reset()
+		choicesModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id": IntegerField{Auto: true},
+				"level": IntegerField{
+					Choices:       []Choice{{1, "Basic"}, {2, "Pro"}},
+					StrictChoices: true,
+				},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.AlterChoices(choicesModel, "level"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Fatalf("expected 2 queries, got %d", len(mockedDB.queries))
+		}
+		expected := `ALTER TABLE "users_account" ` +
+			`DROP CONSTRAINT IF EXISTS "level_choices"`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		expected = `ALTER TABLE "users_account" ADD CONSTRAINT ` +
+			`"level_choices" CHECK ("level" IN (1, 2))`
+		if stmt := mockedDB.queries[1].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("AlterChoicesNotStrict", func(t *testing.T) {
+		mockedDB.Reset()
+		if err := engine.AlterChoices(model, "email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+	})
+
 	t.Run("DropColumns", func(t *testing.T) {
 		mockedDB.Reset()
 		if err := engine.DropColumns(model, "active", "updated"); err != nil {
@@ -326,6 +789,297 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("SelectJSONLookups", func(t *testing.T) {
+		mockedDB.Reset()
+		jsonModel := &Model{
+			name:   "Account",
+			pk:     "id",
+			fields: Fields{"id": IntegerField{Auto: true}, "meta": JSONField{}},
+			meta:   Options{Table: "users_account"},
+		}
+		tests := []struct {
+			cond     Q
+			expected string
+			args     []interface{}
+		}{
+			{
+				Q{"meta__plan__tier": "pro"},
+				`("meta" #> CAST($1 AS text[])) = CAST($2 AS jsonb)`,
+				[]interface{}{`{"plan","tier"}`, `"pro"`},
+			},
+			{
+				Q{"meta__seats >=": 10},
+				`("meta" #> CAST($1 AS text[])) >= CAST($2 AS jsonb)`,
+				[]interface{}{`{"seats"}`, `10`},
+			},
+			{
+				Q{"meta__plan": nil},
+				`("meta" #> CAST($1 AS text[])) IS NULL`,
+				[]interface{}{`{"plan"}`},
+			},
+			{
+				Q{"meta has_key": "plan"},
+				`"meta" ? $1`,
+				[]interface{}{"plan"},
+			},
+			{
+				Q{"meta contains": map[string]interface{}{"beta": true}},
+				`"meta" @> CAST($1 AS jsonb)`,
+				[]interface{}{`{"beta":true}`},
+			},
+		}
+		for _, test := range tests {
+			options := QueryOptions{Conditioner: test.cond, Fields: []string{"id"}}
+			query, err := engine.SelectQuery(jsonModel, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := `SELECT "id" FROM "users_account" WHERE ` + test.expected
+			if query.Stmt != expected {
+				t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, query.Stmt)
+			}
+			if !reflect.DeepEqual(query.Args, test.args) {
+				t.Errorf("expected args %v, got %v", test.args, query.Args)
+			}
+		}
+	})
+
+	t.Run("SelectJSONInvalidLookup", func(t *testing.T) {
+		mockedDB.Reset()
+		options := QueryOptions{
+			Conditioner: Q{"email__domain": "test.com"},
+			Fields:      []string{"id"},
+		}
+		if _, err := engine.SelectQuery(model, options); err == nil {
+			t.Error("expected unsupported lookup error")
+		}
+	})
+
+	t.Run("SelectArrayLookups", func(t *testing.T) {
+		mockedDB.Reset()
+		arrayModel := &Model{
+			name: "Post",
+			pk:   "id",
+			fields: Fields{
+				"id":   IntegerField{Auto: true},
+				"tags": ArrayField{Base: CharField{MaxLength: 20}},
+			},
+			meta: Options{Table: "blog_post"},
+		}
+		tests := []struct {
+			cond     Q
+			expected string
+			args     []interface{}
+		}{
+			{
+				Q{"tags contains": []string{"go"}},
+				`"tags" @> CAST($1 AS VARCHAR(20)[])`,
+				[]interface{}{`{"go"}`},
+			},
+			{
+				Q{"tags contained_by": []string{"go", "sql"}},
+				`"tags" <@ CAST($1 AS VARCHAR(20)[])`,
+				[]interface{}{`{"go","sql"}`},
+			},
+			{
+				Q{"tags overlap": []string{"go", "sql"}},
+				`"tags" && CAST($1 AS VARCHAR(20)[])`,
+				[]interface{}{`{"go","sql"}`},
+			},
+			{
+				Q{"tags__len >": 2},
+				`COALESCE(array_length("tags", 1), 0) > $1`,
+				[]interface{}{int64(2)},
+			},
+			{
+				Q{"tags": []string{"go"}},
+				`"tags" = $1`,
+				[]interface{}{`{"go"}`},
+			},
+		}
+		for _, test := range tests {
+			options := QueryOptions{Conditioner: test.cond, Fields: []string{"id"}}
+			query, err := engine.SelectQuery(arrayModel, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := `SELECT "id" FROM "blog_post" WHERE ` + test.expected
+			if query.Stmt != expected {
+				t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, query.Stmt)
+			}
+			if !reflect.DeepEqual(query.Args, test.args) {
+				t.Errorf("expected args %v, got %v", test.args, query.Args)
+			}
+		}
+		options := QueryOptions{
+			Conditioner: Q{"tags__first": "go"},
+			Fields:      []string{"id"},
+		}
+		if _, err := engine.SelectQuery(arrayModel, options); err == nil {
+			t.Error("expected unsupported transform error")
+		}
+	})
+
+	t.Run("SelectEncryptedLookups", func(t *testing.T) {
+		EncryptionKeys = StaticKeys{
+			Current: "k1",
+			Keys:    map[string][]byte{"k1": []byte("0123456789abcdef")},
+		}
+		defer func() { EncryptionKeys = nil }()
+		secretModel := &Model{
+			name: "Patient",
+			pk:   "id",
+			fields: Fields{
+				"id":   IntegerField{Auto: true},
+				"name": EncryptedField{Base: CharField{MaxLength: 50}},
+				"ssn": EncryptedField{
+					Base: CharField{MaxLength: 11}, Deterministic: true,
+				},
+			},
+			meta: Options{Table: "health_patient"},
+		}
+		options := QueryOptions{
+			Conditioner: Q{"ssn": "123-45-6789"}.AndNot(Q{"name": nil}),
+			Fields:      []string{"id"},
+		}
+		query, err := engine.SelectQuery(secretModel, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `SELECT "id" FROM "health_patient" WHERE ` +
+			`("ssn" = $1) AND NOT ("name" IS NULL)`
+		if query.Stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, query.Stmt)
+		}
+		for _, cond := range []Q{
+			{"name": "Alice"},
+			{"ssn >": "123"},
+			{"ssn__len": 11},
+		} {
+			options := QueryOptions{Conditioner: cond, Fields: []string{"id"}}
+			if _, err := engine.SelectQuery(secretModel, options); err == nil {
+				t.Errorf("expected unsupported lookup error for %v", cond)
+			}
+		}
+	})
+
+	t.Run("ChildModel", func(t *testing.T) {
+		child := &Model{
+			name:   "Customer",
+			fields: Fields{"address": CharField{MaxLength: 100}},
+			meta:   Options{Table: "users_customer"},
+		}
+		if err := child.SetupParent(model); err != nil {
+			t.Fatal(err)
+		}
+		from := `"users_customer" JOIN "users_user" ` +
+			`ON "users_user"."id" = "users_customer"."user_ptr"`
+		txs := 0
+		origBeginTx := beginTx
+		defer func() { beginTx = origBeginTx }()
+		beginTx = func(db sqlDB) (sqlTx, error) {
+			txs++
+			return mockedDB, nil
+		}
+
+		mockedDB.Reset()
+		if err := engine.CreateTable(child, false); err != nil {
+			t.Fatal(err)
+		}
+		stmt := mockedDB.queries[0].Stmt
+		if strings.Contains(stmt, `"email"`) {
+			t.Errorf("expected parent columns not to be created: %s", stmt)
+		}
+		link := `"user_ptr" INTEGER NOT NULL PRIMARY KEY ` +
+			`REFERENCES "users_user" ("id")`
+		if !strings.Contains(stmt, link) {
+			t.Errorf("expected parent link column: %s", stmt)
+		}
+
+		options := QueryOptions{
+			Conditioner: Q{"email": "user@test.com"},
+			Fields:      []string{"id", "address"},
+		}
+		query, err := engine.SelectQuery(child, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `SELECT "id", "address" FROM ` + from +
+			` WHERE "email" = $1`
+		if query.Stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, query.Stmt)
+		}
+
+		mockedDB.Reset()
+		values := Values{"email": "user@test.com", "address": "Street"}
+		if _, err := engine.InsertRow(child, values); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 2 {
+			t.Fatalf("expected two queries, got %d", len(mockedDB.queries))
+		}
+		expected = `INSERT INTO "users_user" ("email") VALUES ($1) ` +
+			`RETURNING "id"`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		stmt = mockedDB.queries[1].Stmt
+		if !strings.HasPrefix(stmt, `INSERT INTO "users_customer"`) {
+			t.Errorf("expected child insert, got %s", stmt)
+		}
+		if args := mockedDB.queries[1].Args; len(args) != 2 {
+			t.Errorf("expected 2 query args, got %d", len(args))
+		}
+		if txs != 1 {
+			t.Errorf("expected insert within one transaction, got %d", txs)
+		}
+
+		mockedDB.Reset()
+		txEngine := engine
+		txEngine.baseSQLEngine.tx = mockedDB
+		if _, err := txEngine.InsertRow(child, values); err != nil {
+			t.Fatal(err)
+		}
+		if txs != 1 {
+			t.Error("expected the engine transaction to be used")
+		}
+
+		mockedDB.Reset()
+		mockedDB.err = fmt.Errorf("db error")
+		_, err = engine.InsertRowReturning(child, values, "id", "address")
+		if err == nil {
+			t.Error("expected db error")
+		}
+		expected = `INSERT INTO "users_user" ("email") VALUES ($1) ` +
+			`RETURNING "id"`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+
+		mockedDB.Reset()
+		mockedDB.err = fmt.Errorf("db error")
+		cond := QueryOptions{Conditioner: Q{"active": true}}
+		_, err = engine.UpdateRows(child, Values{"address": "x"}, cond)
+		if err == nil {
+			t.Error("expected db error")
+		}
+		expected = `SELECT "users_customer"."user_ptr" FROM ` + from +
+			` WHERE "active" = $1`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		if _, err := engine.DeleteRows(child, cond); err == nil {
+			t.Error("expected db error")
+		}
+		if _, err := engine.UpdateRows(child, Values{"x": 1}, cond); err == nil {
+			t.Error("expected unknown field error")
+		}
+		options = QueryOptions{Fields: []string{"id"}}
+		if _, err := engine.DeleteRowsReturning(child, options); err == nil {
+			t.Error("expected returning not supported error")
+		}
+	})
+
 	t.Run("GetRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{
@@ -406,6 +1160,33 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("InsertRowNoAutoPK", func(t *testing.T) {
+		mockedDB.Reset()
+		uuidModel := &Model{
+			name: "Token",
+			pk:   "id",
+			fields: Fields{
+				"id": UUIDField{PrimaryKey: true, AutoGenerate: true},
+			},
+			meta: Options{Table: "users_token"},
+		}
+		id := "2b1c9b3e-5d3a-4f5e-9a43-0d2a4d6f8c11"
+		pk, err := engine.InsertRow(uuidModel, Values{"id": id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pk != 0 {
+			t.Errorf("expected pk to be 0, got %d", pk)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `INSERT INTO "users_token" ("id") VALUES ($1)`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("InsertUnknownField", func(t *testing.T) {
 		mockedDB.Reset()
 		values := Values{"username": "test", "active": true}
@@ -424,6 +1205,31 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("InsertRowReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"email": "user@test.com"}
+		_, err := engine.InsertRowReturning(model, values, "id", "active")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `INSERT INTO "users_user" ("email") VALUES ($1) ` +
+			`RETURNING "id", "active"`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("InsertRowReturningNoFields", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"email": "user@test.com"}
+		if _, err := engine.InsertRowReturning(model, values); err == nil {
+			t.Fatal("expected no returning fields error")
+		}
+	})
+
 	t.Run("InsertRowDBError", func(t *testing.T) {
 		mockedDB.Reset()
 		origScanRow := scanRow
@@ -504,6 +1310,48 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateRowsReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"active": false}
+		options := QueryOptions{
+			Conditioner: Q{"active": true},
+			Fields:      []string{"id", "email"},
+		}
+		_, err := engine.UpdateRowsReturning(model, values, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `UPDATE "users_user" SET "active" = $1 ` +
+			`WHERE "active" = $2 RETURNING "id", "email"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("UpdateRowsReturningNoFields", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"active": false}
+		options := QueryOptions{Conditioner: Q{"active": true}}
+		_, err := engine.UpdateRowsReturning(model, values, options)
+		if err == nil {
+			t.Fatal("expected no returning fields error")
+		}
+	})
+
+	t.Run("UpdateRowsReturningUnknownField", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"active": false}
+		options := QueryOptions{Fields: []string{"username"}}
+		_, err := engine.UpdateRowsReturning(model, values, options)
+		if err == nil {
+			t.Fatal("expected unknown field error")
+		}
+	})
+
 	t.Run("DeleteRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{Conditioner: Q{"id >=": 100}}
@@ -547,6 +1395,26 @@ func TestPostgresEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("DeleteRowsReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		options := QueryOptions{
+			Conditioner: Q{"id >=": 100},
+			Fields:      []string{"pk"},
+		}
+		_, err := engine.DeleteRowsReturning(model, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `DELETE FROM "users_user" WHERE "id" >= $1 RETURNING "id"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("CountRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{Conditioner: Q{"email": "user@test.com"}}
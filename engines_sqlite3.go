@@ -2,6 +2,7 @@ package gomodel
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -48,21 +49,30 @@ func (e SqliteEngine) BeginTx() (Engine, error) {
 // copyTable copies the model table to a new one with the given name and
 // columns.
 func (e SqliteEngine) copyTable(m *Model, name string, fields ...string) error {
-	modelCopy := &Model{fields: Fields{}, meta: Options{Table: name}}
+	modelCopy := &Model{
+		fields: Fields{},
+		meta:   Options{Table: name, Constraints: Constraints{}},
+	}
+	for cName, constraint := range m.meta.Constraints {
+		if _, ok := partialUnique(constraint); !ok {
+			modelCopy.meta.Constraints[cName] = constraint
+		}
+	}
 	if len(fields) > 0 {
 		for _, name := range fields {
 			modelCopy.fields[name] = m.fields[name]
 		}
 	} else {
-		for name, field := range m.fields {
-			modelCopy.fields[name] = field
-		}
+		modelCopy.fields = m.LocalFields()
 	}
 	if err := e.CreateTable(modelCopy, true); err != nil {
 		return err
 	}
 	columns := make([]string, 0, len(fields))
 	for name, field := range modelCopy.fields {
+		if isGenerated(field) {
+			continue
+		}
 		columns = append(columns, e.escape(field.DBColumn(name)))
 	}
 	stmt := fmt.Sprintf(
@@ -74,51 +84,98 @@ func (e SqliteEngine) copyTable(m *Model, name string, fields ...string) error {
 }
 
 // AddColumns implements the AddColumns method of the Engine interface.
+//
+// Since sqlite3 doesn't support adding generated columns or columns with
+// expression defaults, the table is rebuilt to include them.
 func (e SqliteEngine) AddColumns(model *Model, fields Fields) error {
 	notNullFields := make([]string, 0, len(fields))
+	rebuild := false
 	for name, field := range fields {
+		if isGenerated(field) {
+			rebuild = true
+			continue
+		}
 		if !field.IsNull() {
 			notNullFields = append(notNullFields, name)
 		}
+		dataType, err := e.dataType(name, field)
+		if err != nil {
+			return err
+		}
+		check, err := e.choicesCheck(name, field)
+		if err != nil {
+			return err
+		}
 		stmt := fmt.Sprintf(
-			"ALTER TABLE %s ADD COLUMN %s %s %s",
+			"ALTER TABLE %s ADD COLUMN %s %s %s%s",
 			e.escape(model.Table()),
 			e.escape(field.DBColumn(name)),
-			field.DataType("sqlite3"),
+			dataType,
 			e.sqlColumnOptions(field, true),
+			check,
 		)
 		if _, err := e.executor().Exec(stmt); err != nil {
 			return err
 		}
+		if expr := dbDefault(field); expr != "" {
+			rebuild = true
+			stmt := fmt.Sprintf(
+				"UPDATE %s SET %s = (%s)",
+				e.escape(model.Table()), e.escape(field.DBColumn(name)), expr,
+			)
+			if _, err := e.executor().Exec(stmt); err != nil {
+				return err
+			}
+		}
 	}
 	if len(notNullFields) > 0 {
 		values := Values{}
 		for _, name := range notNullFields {
 			field := fields[name]
-			val, ok := field.DefaultValue()
-			if !ok {
+			if val, ok := field.DefaultValue(); ok {
+				values[name] = val
+			} else if dbDefault(field) == "" {
 				return fmt.Errorf(
 					"%s: cannot add not null column without default", name,
 				)
 			}
-			values[name] = val
 		}
-		if _, err := e.UpdateRows(model, values, QueryOptions{}); err != nil {
-			return err
-		}
-		copyName := fmt.Sprintf("%s__new", model.Table())
-		if err := e.copyTable(model, copyName); err != nil {
-			return err
-		}
-		if err := e.DropTable(model); err != nil {
-			return err
+		if len(values) > 0 {
+			_, err := e.UpdateRows(model, values, QueryOptions{})
+			if err != nil {
+				return err
+			}
 		}
-		copyModel := &Model{meta: Options{Table: copyName}}
-		if err := e.RenameTable(copyModel, model); err != nil {
+		rebuild = true
+	}
+	if rebuild {
+		return e.rebuildTable(model)
+	}
+	return nil
+}
+
+// rebuildTable recreates the model table from the current model definition,
+// keeping the rows and indexes.
+func (e SqliteEngine) rebuildTable(model *Model, fields ...string) error {
+	copyName := fmt.Sprintf("%s__new", model.Table())
+	if err := e.copyTable(model, copyName, fields...); err != nil {
+		return err
+	}
+	if err := e.DropTable(model); err != nil {
+		return err
+	}
+	copyModel := &Model{meta: Options{Table: copyName}}
+	if err := e.RenameTable(copyModel, model); err != nil {
+		return err
+	}
+	for idxName := range model.indexes() {
+		if err := e.AddIndex(model, idxName); err != nil {
 			return err
 		}
-		for idxName, fields := range model.Indexes() {
-			if err := e.AddIndex(model, idxName, fields...); err != nil {
+	}
+	for name, constraint := range model.meta.Constraints {
+		if _, ok := partialUnique(constraint); ok {
+			if err := e.baseSQLEngine.AddConstraint(model, name); err != nil {
 				return err
 			}
 		}
@@ -126,6 +183,80 @@ func (e SqliteEngine) AddColumns(model *Model, fields Fields) error {
 	return nil
 }
 
+// AddConstraint implements the AddConstraint method of the Engine interface.
+//
+// Since sqlite3 doesn't support adding table constraints, it will perform the
+// operation by creating a new table.
+func (e SqliteEngine) AddConstraint(model *Model, name string) error {
+	constraint, ok := model.meta.Constraints[name]
+	if !ok {
+		return fmt.Errorf("unknown constraint %s", name)
+	}
+	if _, ok := partialUnique(constraint); ok {
+		return e.baseSQLEngine.AddConstraint(model, name)
+	}
+	return e.rebuildTable(model)
+}
+
+// DropConstraint implements the DropConstraint method of the Engine interface.
+//
+// Since sqlite3 doesn't support dropping table constraints, it will perform
+// the operation by creating a new table.
+func (e SqliteEngine) DropConstraint(model *Model, name string) error {
+	constraint, ok := model.meta.Constraints[name]
+	if !ok {
+		return fmt.Errorf("unknown constraint %s", name)
+	}
+	if _, ok := partialUnique(constraint); ok {
+		return e.baseSQLEngine.DropConstraint(model, name)
+	}
+	modelCopy := *model
+	modelCopy.meta.Constraints = model.Constraints()
+	delete(modelCopy.meta.Constraints, name)
+	return e.rebuildTable(&modelCopy)
+}
+
+// AlterChoices implements the AlterChoices method of the Engine interface.
+//
+// Since sqlite3 doesn't support altering constraints, it will perform the
+// operation by creating a new table.
+func (e SqliteEngine) AlterChoices(model *Model, name string) error {
+	if _, ok := model.Fields()[name]; !ok {
+		return fmt.Errorf("unknown field %s", name)
+	}
+	return e.rebuildTable(model)
+}
+
+// AddEnumValues implements the AddEnumValues method of the Engine interface.
+//
+// Since sqlite3 doesn't support altering constraints, it will perform the
+// operation by creating a new table.
+func (e SqliteEngine) AddEnumValues(
+	model *Model,
+	name string,
+	values ...Value,
+) error {
+	if _, ok := enumField(model.fields[name]); !ok {
+		return fmt.Errorf("unknown enum field %s", name)
+	}
+	return e.rebuildTable(model)
+}
+
+// DropEnumValues implements the DropEnumValues method of the Engine interface.
+//
+// Since sqlite3 doesn't support altering constraints, it will perform the
+// operation by creating a new table.
+func (e SqliteEngine) DropEnumValues(
+	model *Model,
+	name string,
+	values ...Value,
+) error {
+	if _, ok := enumField(model.fields[name]); !ok {
+		return fmt.Errorf("unknown enum field %s", name)
+	}
+	return e.rebuildTable(model)
+}
+
 // DropColumns implements the DropColumns method of the Engine interface.
 //
 // Since sqlite3 doesn't support dropping columns, it will perform the operation
@@ -139,23 +270,7 @@ func (e SqliteEngine) DropColumns(model *Model, fields ...string) error {
 	for name, field := range oldFields {
 		keepCols = append(keepCols, field.DBColumn(name))
 	}
-	copyName := fmt.Sprintf("%s__new", model.Table())
-	if err := e.copyTable(model, copyName, keepCols...); err != nil {
-		return err
-	}
-	if err := e.DropTable(model); err != nil {
-		return err
-	}
-	copyModel := &Model{meta: Options{Table: copyName}}
-	if err := e.RenameTable(copyModel, model); err != nil {
-		return err
-	}
-	for idxName, fields := range model.Indexes() {
-		if err := e.AddIndex(model, idxName, fields...); err != nil {
-			return err
-		}
-	}
-	return nil
+	return e.rebuildTable(model, keepCols...)
 }
 
 // GetRows implements the GetRows method of the Engine interface.
@@ -174,3 +289,93 @@ func (e SqliteEngine) GetRows(model *Model, opt QueryOptions) (Rows, error) {
 	}
 	return e.executor().Query(query.Stmt, query.Args...)
 }
+
+// checkReturning returns an error if the sqlite3 library version doesn't
+// support the RETURNING clause, added on 3.35.0.
+func (e SqliteEngine) checkReturning() error {
+	var version string
+	query := Query{Stmt: "SELECT sqlite_version()"}
+	if err := scanRow(e.executor(), &version, query); err != nil {
+		return err
+	}
+	numbers := strings.Split(version, ".")
+	if len(numbers) >= 2 {
+		major, _ := strconv.Atoi(numbers[0])
+		minor, _ := strconv.Atoi(numbers[1])
+		if major > 3 || major == 3 && minor >= 35 {
+			return nil
+		}
+	}
+	return fmt.Errorf("sqlite3 %s doesn't support RETURNING clause", version)
+}
+
+// InsertRowReturning implements the InsertRowReturning method of the Engine
+// interface. For sqlite3 versions prior to 3.35.0, the inserted row is
+// selected by its rowid after the insert, and child models are not supported.
+func (e SqliteEngine) InsertRowReturning(
+	model *Model,
+	values Values,
+	fields ...string,
+) (Rows, error) {
+	if err := e.checkReturning(); err == nil {
+		return e.baseSQLEngine.InsertRowReturning(model, values, fields...)
+	} else if model.parent != nil {
+		return nil, err
+	}
+	query, err := e.insertQuery(model, values)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(fields))
+	for _, name := range fields {
+		if name == "pk" {
+			name = model.pk
+		}
+		field, ok := model.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+		columns = append(columns, e.escape(field.DBColumn(name)))
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no returning fields")
+	}
+	result, err := e.executor().Exec(query.Stmt, query.Args...)
+	if err != nil {
+		return nil, err
+	}
+	rowID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	stmt := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE rowid = %s",
+		strings.Join(columns, ", "), e.escape(model.Table()), e.placeholder(1),
+	)
+	return e.executor().Query(stmt, rowID)
+}
+
+// UpdateRowsReturning implements the UpdateRowsReturning method of the Engine
+// interface. It returns an error for sqlite3 versions prior to 3.35.0.
+func (e SqliteEngine) UpdateRowsReturning(
+	model *Model,
+	values Values,
+	options QueryOptions,
+) (Rows, error) {
+	if err := e.checkReturning(); err != nil {
+		return nil, err
+	}
+	return e.baseSQLEngine.UpdateRowsReturning(model, values, options)
+}
+
+// DeleteRowsReturning implements the DeleteRowsReturning method of the Engine
+// interface. It returns an error for sqlite3 versions prior to 3.35.0.
+func (e SqliteEngine) DeleteRowsReturning(
+	model *Model,
+	options QueryOptions,
+) (Rows, error) {
+	if err := e.checkReturning(); err != nil {
+		return nil, err
+	}
+	return e.baseSQLEngine.DeleteRowsReturning(model, options)
+}
@@ -3,6 +3,7 @@ package gomodel
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -237,6 +238,25 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateTableUnsupportedField", func(t *testing.T) {
+		mockedDB.Reset()
+		arrayModel := &Model{
+			name: "Post",
+			pk:   "id",
+			fields: Fields{
+				"id":   IntegerField{Auto: true},
+				"tags": ArrayField{Base: CharField{MaxLength: 20}},
+			},
+			meta: Options{Table: "blog_post"},
+		}
+		if err := engine.CreateTable(arrayModel, false); err == nil {
+			t.Fatal("expected field type not supported error")
+		}
+		if len(mockedDB.queries) != 0 {
+			t.Errorf("expected no queries, got %d", len(mockedDB.queries))
+		}
+	})
+
 	t.Run("RenameTable", func(t *testing.T) {
 		mockedDB.Reset()
 		newModel := &Model{meta: Options{Table: "new_table"}}
@@ -283,6 +303,21 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("AddIndexMethod", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.IndexDefinitions = IndexDefinitions{
+			"idx": {Fields: []string{"-email"}, Method: "gin"},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		if err := engine.AddIndex(model, "idx"); err != nil {
+			t.Fatal(err)
+		}
+		expected := `CREATE INDEX "idx" ON "users_user" ("email" DESC)`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("DropIndex", func(t *testing.T) {
 		mockedDB.Reset()
 		if err := engine.DropIndex(model, "test_index"); err != nil {
@@ -418,6 +453,142 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("AlterChoices", func(t *testing.T) {
+		mockedDB.Reset()
+		if err := engine.AlterChoices(model, "email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 5 {
+			t.Fatalf("expected 5 queries, got %d", len(mockedDB.queries))
+		}
+		st := mockedDB.queries[0].Stmt
+		if !strings.HasPrefix(st, `CREATE TABLE "users_user__new"`) {
+			t.Fatalf(
+				"expected query start: %s",
+				`CREATE TABLE "users_user__new"`,
+			)
+		}
+	})
+
+	t.Run("AlterChoicesUnknownField", func(t *testing.T) {
+		mockedDB.Reset()
+		if err := engine.AlterChoices(model, "foo"); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
+	t.Run("CreateTableEnum", func(t *testing.T) {
+		mockedDB.Reset()
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id":    IntegerField{Auto: true},
+				"level": EnumField{Values: []Value{1, 2}},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.CreateTable(enumModel, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `"level" INTEGER NOT NULL CONSTRAINT "level_choices" ` +
+			`CHECK ("level" IN (1, 2))`
+		st := mockedDB.queries[0].Stmt
+		if !strings.Contains(st, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+	})
+
+	t.Run("AddEnumValues", func(t *testing.T) {
+		mockedDB.Reset()
+		enumModel := &Model{
+			name: "Account",
+			pk:   "id",
+			fields: Fields{
+				"id":    IntegerField{Auto: true},
+				"level": EnumField{Values: []Value{1, 2, 3}},
+			},
+			meta: Options{Table: "users_account"},
+		}
+		if err := engine.AddEnumValues(enumModel, "level", 3); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 4 {
+			t.Fatalf("expected 4 queries, got %d", len(mockedDB.queries))
+		}
+		expected := `CHECK ("level" IN (1, 2, 3))`
+		st := mockedDB.queries[0].Stmt
+		if !strings.Contains(st, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+	})
+
+	t.Run("AddConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"id_check": CheckConstraint{Check: Q{"id >": 0}},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.AddConstraint(model, "id_check"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 5 {
+			t.Fatalf("expected 5 queries, got %d", len(mockedDB.queries))
+		}
+		st := mockedDB.queries[0].Stmt
+		expected := `CONSTRAINT "id_check" CHECK ("id" > 0)`
+		if !strings.Contains(st, expected) {
+			t.Errorf("expected query to contain: %s", expected)
+		}
+	})
+
+	t.Run("AddPartialUniqueConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{
+				Fields:    []string{"email"},
+				Condition: Q{"active": true},
+			},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.AddConstraint(model, "unique_email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `CREATE UNIQUE INDEX "unique_email" ON "users_user" ` +
+			`("email") WHERE "active" = TRUE`
+		st := mockedDB.queries[0].Stmt
+		if st != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, st)
+		}
+	})
+
+	t.Run("DropConstraint", func(t *testing.T) {
+		mockedDB.Reset()
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{Fields: []string{"email"}},
+		}
+		defer func() { model.meta.Constraints = nil }()
+		if err := engine.DropConstraint(model, "unique_email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 5 {
+			t.Fatalf("expected 5 queries, got %d", len(mockedDB.queries))
+		}
+		st := mockedDB.queries[0].Stmt
+		if strings.Contains(st, "unique_email") {
+			t.Errorf("expected constraint to be dropped, got: %s", st)
+		}
+		if _, ok := model.meta.Constraints["unique_email"]; !ok {
+			t.Errorf("expected model constraints to be unchanged")
+		}
+	})
+
 	t.Run("DropColumnsDBError", func(t *testing.T) {
 		mockedDB.Reset()
 		mockedDB.err = fmt.Errorf("db error")
@@ -476,6 +647,89 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("SelectJSONLookups", func(t *testing.T) {
+		mockedDB.Reset()
+		jsonModel := &Model{
+			name:   "Account",
+			pk:     "id",
+			fields: Fields{"id": IntegerField{Auto: true}, "meta": JSONField{}},
+			meta:   Options{Table: "users_account"},
+		}
+		tests := []struct {
+			cond     Q
+			expected string
+			args     []interface{}
+		}{
+			{
+				Q{"meta__plan__tier": "pro"},
+				`json_extract("meta", ?) = ?`,
+				[]interface{}{`$."plan"."tier"`, "pro"},
+			},
+			{
+				Q{"meta__tags__0 <": 10},
+				`json_extract("meta", ?) < ?`,
+				[]interface{}{`$."tags"[0]`, float64(10)},
+			},
+			{
+				Q{"meta__plan": map[string]interface{}{"tier": "pro"}},
+				`json_extract("meta", ?) = json(?)`,
+				[]interface{}{`$."plan"`, `{"tier":"pro"}`},
+			},
+			{
+				Q{"meta__plan": nil},
+				`json_extract("meta", ?) IS NULL`,
+				[]interface{}{`$."plan"`},
+			},
+			{
+				Q{"meta__plan has_key": "tier"},
+				`json_type("meta", ?) IS NOT NULL`,
+				[]interface{}{`$."plan"."tier"`},
+			},
+			{
+				Q{"meta contains": map[string]interface{}{
+					"beta": true, "tags": []string{"a"},
+				}},
+				`json_extract("meta", ?) = ? AND ` +
+					`EXISTS (SELECT 1 FROM json_each("meta", ?) WHERE value = ?)`,
+				[]interface{}{`$."beta"`, true, `$."tags"`, "a"},
+			},
+			{
+				Q{"meta contains": map[string]interface{}{}},
+				`json_type("meta", ?) = 'object'`,
+				[]interface{}{`$`},
+			},
+		}
+		for _, test := range tests {
+			options := QueryOptions{Conditioner: test.cond, Fields: []string{"id"}}
+			query, err := engine.SelectQuery(jsonModel, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := `SELECT "id" FROM "users_account" WHERE ` + test.expected
+			if query.Stmt != expected {
+				t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, query.Stmt)
+			}
+			if !reflect.DeepEqual(query.Args, test.args) {
+				t.Errorf("expected args %v, got %v", test.args, query.Args)
+			}
+		}
+	})
+
+	t.Run("SelectJSONNestedContains", func(t *testing.T) {
+		mockedDB.Reset()
+		jsonModel := &Model{
+			name:   "Account",
+			pk:     "id",
+			fields: Fields{"id": IntegerField{Auto: true}, "meta": JSONField{}},
+			meta:   Options{Table: "users_account"},
+		}
+		cond := Q{"meta contains": []interface{}{[]int{1}}}
+		options := QueryOptions{Conditioner: cond, Fields: []string{"id"}}
+		if _, err := engine.SelectQuery(jsonModel, options); err == nil {
+			t.Error("expected unsupported nested documents error")
+		}
+	})
+
 	t.Run("GetRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{
@@ -566,6 +820,74 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("InsertRowReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		origScanRow := scanRow
+		defer func() { scanRow = origScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*string) = "3.35.5"
+			return nil
+		}
+		values := Values{"email": "user@test.com"}
+		_, err := engine.InsertRowReturning(model, values, "id", "active")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `INSERT INTO "users_user" ("email") VALUES (?) ` +
+			`RETURNING "id", "active"`
+		if stmt := mockedDB.queries[0].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
+	t.Run("InsertRowReturningSelect", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"email": "user@test.com"}
+		_, err := engine.InsertRowReturning(model, values, "id", "active")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 3 {
+			t.Fatalf("expected 3 queries, got %d", len(mockedDB.queries))
+		}
+		expected := `SELECT "id", "active" FROM "users_user" WHERE rowid = ?`
+		if stmt := mockedDB.queries[2].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+		if args := mockedDB.queries[2].Args; args[0] != int64(42) {
+			t.Errorf("expected rowid 42, got %v", args[0])
+		}
+	})
+
+	t.Run("AddColumnsGenerated", func(t *testing.T) {
+		mockedDB.Reset()
+		fields := Fields{"domain": GeneratedField{
+			Base:       CharField{MaxLength: 100},
+			Expression: `substr("email", instr("email", '@') + 1)`,
+		}}
+		genModel := &Model{
+			name:   "User",
+			pk:     "id",
+			fields: Fields{"id": IntegerField{Auto: true, PrimaryKey: true}},
+			meta:   Options{Table: "users_user"},
+		}
+		genModel.fields["domain"] = fields["domain"]
+		if err := engine.AddColumns(genModel, fields); err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 4 {
+			t.Fatalf("expected 4 queries, got %d", len(mockedDB.queries))
+		}
+		expected := `INSERT INTO "users_user__new" ` +
+			`SELECT "id" FROM "users_user"`
+		if stmt := mockedDB.queries[1].Stmt; stmt != expected {
+			t.Errorf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("InsertRowResultError", func(t *testing.T) {
 		mockedDB.Reset()
 		mockedDB.resultErr = fmt.Errorf("result error")
@@ -612,6 +934,50 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateRowsReturningUnsupported", func(t *testing.T) {
+		mockedDB.Reset()
+		values := Values{"active": false}
+		options := QueryOptions{Fields: []string{"id"}}
+		_, err := engine.UpdateRowsReturning(model, values, options)
+		if err == nil {
+			t.Fatal("expected unsupported returning error")
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		if mockedDB.queries[0].Stmt != "SELECT sqlite_version()" {
+			t.Errorf("expected version query, got %s", mockedDB.queries[0].Stmt)
+		}
+	})
+
+	t.Run("UpdateRowsReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		origScanRow := scanRow
+		defer func() { scanRow = origScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*string) = "3.35.5"
+			return nil
+		}
+		values := Values{"active": false}
+		options := QueryOptions{
+			Conditioner: Q{"active": true},
+			Fields:      []string{"id", "email"},
+		}
+		_, err := engine.UpdateRowsReturning(model, values, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `UPDATE "users_user" SET "active" = ? ` +
+			`WHERE "active" = ? RETURNING "id", "email"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("DeleteRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{Conditioner: Q{"id >=": 100}}
@@ -646,6 +1012,47 @@ func TestSqliteEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("DeleteRowsReturningUnsupported", func(t *testing.T) {
+		mockedDB.Reset()
+		origScanRow := scanRow
+		defer func() { scanRow = origScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*string) = "3.34.1"
+			return nil
+		}
+		options := QueryOptions{Fields: []string{"id"}}
+		_, err := engine.DeleteRowsReturning(model, options)
+		if err == nil {
+			t.Fatal("expected unsupported returning error")
+		}
+	})
+
+	t.Run("DeleteRowsReturning", func(t *testing.T) {
+		mockedDB.Reset()
+		origScanRow := scanRow
+		defer func() { scanRow = origScanRow }()
+		scanRow = func(ex sqlExecutor, dest interface{}, query Query) error {
+			*dest.(*string) = "3.40.0"
+			return nil
+		}
+		options := QueryOptions{
+			Conditioner: Q{"id >=": 100},
+			Fields:      []string{"id"},
+		}
+		_, err := engine.DeleteRowsReturning(model, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mockedDB.queries) != 1 {
+			t.Fatalf("expected one query, got %d", len(mockedDB.queries))
+		}
+		expected := `DELETE FROM "users_user" WHERE "id" >= ? RETURNING "id"`
+		stmt := mockedDB.queries[0].Stmt
+		if stmt != expected {
+			t.Fatalf("expected:\n\n%s\n\ngot:\n\n%s", expected, stmt)
+		}
+	})
+
 	t.Run("CountRows", func(t *testing.T) {
 		mockedDB.Reset()
 		options := QueryOptions{Conditioner: Q{"email": "user@test.com"}}
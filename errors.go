@@ -78,3 +78,26 @@ type MultipleObjectsError struct {
 func (e *MultipleObjectsError) Error() string {
 	return fmt.Sprintf("gomodel: %s", e.Trace)
 }
+
+// StaleObjectError is raised when an instance with a VersionField is saved but
+// the row has been modified or deleted since the instance was loaded.
+type StaleObjectError struct {
+	Trace ErrorTrace
+}
+
+// Error implements the error interface.
+func (e *StaleObjectError) Error() string {
+	return fmt.Sprintf("gomodel: %s", e.Trace)
+}
+
+// ValidationError is raised when the values of an instance are not valid.
+type ValidationError struct {
+	Trace ErrorTrace
+	// Errors maps field names to their validation messages.
+	Errors map[string][]string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("gomodel: %s", e.Trace)
+}
@@ -68,4 +68,28 @@ func TestErrors(t *testing.T) {
 			t.Errorf("expected '%s', got '%s'", expected, err.Error())
 		}
 	})
+	t.Run("StaleObjectError", func(t *testing.T) {
+		trace := ErrorTrace{
+			App:   app,
+			Model: model,
+			Err:   fmt.Errorf("stale object"),
+		}
+		err := &StaleObjectError{trace}
+		expected := "gomodel: users: User: stale object"
+		if err.Error() != expected {
+			t.Errorf("expected '%s', got '%s'", expected, err.Error())
+		}
+	})
+	t.Run("ValidationError", func(t *testing.T) {
+		trace := ErrorTrace{
+			App:   app,
+			Model: model,
+			Err:   fmt.Errorf("invalid values"),
+		}
+		err := &ValidationError{trace, map[string][]string{}}
+		expected := "gomodel: users: User: invalid values"
+		if err.Error() != expected {
+			t.Errorf("expected '%s', got '%s'", expected, err.Error())
+		}
+	})
 }
@@ -0,0 +1,73 @@
+package gomodel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Expression represents a raw SQL expression evaluated by the database, used
+// for column defaults and generated columns.
+type Expression string
+
+// Now returns the expression for the current timestamp.
+func Now() Expression {
+	return Expression("CURRENT_TIMESTAMP")
+}
+
+// Lower returns the expression for the lowercase values of the given column.
+func Lower(column string) Expression {
+	return Expression(fmt.Sprintf("LOWER(\"%s\")", column))
+}
+
+// Literal returns the expression representing the given literal value.
+func Literal(val Value) Expression {
+	if literal, ok := sqlLiteral(val); ok {
+		return Expression(literal)
+	}
+	return Expression(quoteLiteral(fmt.Sprintf("%v", val)))
+}
+
+// quoteLiteral returns the given string as a quoted SQL literal.
+func quoteLiteral(s string) string {
+	return fmt.Sprintf("'%s'", strings.Replace(s, "'", "''", -1))
+}
+
+// sqlLiteral returns the SQL literal representing the given value, and false
+// if the value type is not supported. Times are formatted as DateTimeField
+// values are stored, depending on the UseTZ setting.
+func sqlLiteral(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case nil:
+		return "NULL", true
+	case string:
+		return quoteLiteral(v), true
+	case []byte:
+		return quoteLiteral(string(v)), true
+	case bool:
+		if v {
+			return "TRUE", true
+		}
+		return "FALSE", true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32,
+		uint64:
+		return fmt.Sprintf("%d", v), true
+	case float32, float64:
+		return fmt.Sprintf("%v", v), true
+	case time.Time:
+		if UseTZ {
+			return quoteLiteral(v.UTC().Format(tzLayout)), true
+		}
+		return quoteLiteral(v.Format("2006-01-02 15:04:05")), true
+	}
+	return "", false
+}
+
+// dbDefault returns the DBDefault expression of the given field, or a blank
+// expression if the field doesn't have one.
+func dbDefault(field Field) Expression {
+	if d, ok := field.(DBDefaulter); ok {
+		return d.DBDefaultExpression()
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package gomodel
+
+import (
+	"testing"
+	"time"
+)
+
+// TestExpressions tests the Expression helpers
+func TestExpressions(t *testing.T) {
+	t.Run("Now", func(t *testing.T) {
+		if Now() != "CURRENT_TIMESTAMP" {
+			t.Errorf("expected CURRENT_TIMESTAMP, got %s", Now())
+		}
+	})
+
+	t.Run("Lower", func(t *testing.T) {
+		if expr := Lower("email"); expr != `LOWER("email")` {
+			t.Errorf("expected LOWER(\"email\"), got %s", expr)
+		}
+	})
+
+	t.Run("Literal", func(t *testing.T) {
+		date := time.Date(2019, 8, 24, 14, 18, 3, 0, time.UTC)
+		tests := []struct {
+			val      Value
+			expected Expression
+		}{
+			{"o'neil", "'o''neil'"},
+			{42, "42"},
+			{1.5, "1.5"},
+			{true, "TRUE"},
+			{nil, "NULL"},
+			{date, "'2019-08-24 14:18:03'"},
+			{[]int{1}, "'[1]'"},
+		}
+		for _, test := range tests {
+			if expr := Literal(test.val); expr != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, expr)
+			}
+		}
+		UseTZ = true
+		defer func() { UseTZ = false }()
+		local := date.In(time.FixedZone("CEST", 2*60*60))
+		expected := Expression("'2019-08-24T14:18:03.000000+00:00'")
+		if expr := Literal(local); expr != expected {
+			t.Errorf("expected %s, got %s", expected, expr)
+		}
+	})
+
+	t.Run("DBDefault", func(t *testing.T) {
+		if expr := dbDefault(CharField{DBDefault: "'a'"}); expr != "'a'" {
+			t.Errorf("expected 'a', got %s", expr)
+		}
+		if expr := dbDefault(&IntegerField{}); expr != "" {
+			t.Errorf("expected blank expression, got %s", expr)
+		}
+		if expr := dbDefault(VersionField{}); expr != "" {
+			t.Errorf("expected blank expression, got %s", expr)
+		}
+	})
+}
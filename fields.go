@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"strings"
 )
 
 // Field is the interface that represents a model field.
+//
+// Custom fields can be registered using the RegisterField function.
 type Field interface {
 	// IsPK returns true if the field is the model primary key.
 	IsPK() bool
@@ -47,16 +48,133 @@ type Field interface {
 	DisplayValue(val Value) string
 }
 
+// AutoGenerator is the interface implemented by fields whose values can be
+// generated before a new row is inserted.
+type AutoGenerator interface {
+	// IsAutoGenerated returns true if a value should be generated for the
+	// field when a new row is inserted without a value.
+	IsAutoGenerated() bool
+	// AutoValue returns a newly generated value for the field.
+	AutoValue() (Value, error)
+}
+
+// generateValue returns a generated value for the given field if it's an
+// AutoGenerator with generation enabled and the current value is nil or zero,
+// and a boolean indicating whether the value was generated.
+func generateValue(field Field, current Value) (Value, bool, error) {
+	gen, ok := field.(AutoGenerator)
+	if !ok || !gen.IsAutoGenerated() {
+		return nil, false, nil
+	}
+	if val := field.Value(current); val != nil {
+		zero := reflect.Zero(reflect.TypeOf(val)).Interface()
+		if !reflect.DeepEqual(val, zero) {
+			return nil, false, nil
+		}
+	}
+	val, err := gen.AutoValue()
+	if err != nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+// isAutoGenerated returns true if the given field is an AutoGenerator with
+// generation enabled.
+func isAutoGenerated(field Field) bool {
+	gen, ok := field.(AutoGenerator)
+	return ok && gen.IsAutoGenerated()
+}
+
+// GeneratedColumn is the interface implemented by fields whose values are
+// computed by the database, and never inserted or updated.
+type GeneratedColumn interface {
+	// IsGenerated returns true if the column values are computed by the
+	// database.
+	IsGenerated() bool
+}
+
+// isGenerated returns true if the given field is a GeneratedColumn with
+// database generated values.
+func isGenerated(field Field) bool {
+	gen, ok := field.(GeneratedColumn)
+	return ok && gen.IsGenerated()
+}
+
+// ChoicesLister is the interface implemented by fields with a list of choices
+// used to validate their values.
+type ChoicesLister interface {
+	// FieldChoices returns the field choices and whether they are strict
+	// (enforced by a database constraint) or not.
+	FieldChoices() (choices []Choice, strict bool)
+}
+
+// ChoicesField is the interface implemented by fields with a list of choices,
+// that can be enforced by a database constraint.
+type ChoicesField interface {
+	Field
+	ChoicesLister
+	// WithChoices returns a copy of the field with the given choices.
+	WithChoices(choices []Choice, strict bool) Field
+}
+
+// DBDefaulter is the interface implemented by fields with a default value
+// expression evaluated by the database.
+type DBDefaulter interface {
+	// DBDefaultExpression returns the column default expression, or a blank
+	// expression if the field doesn't have one.
+	DBDefaultExpression() Expression
+}
+
 // Fields represents the fields map of a model.
 type Fields map[string]Field
 
+// marshalField returns the JSON encoding of the given field, in the form:
+// {"FieldType": {...}}, where FieldType is the registered name of the field
+// type. An error is returned if the field type is not registered.
+func marshalField(field Field) ([]byte, error) {
+	name, ok := fieldName(field)
+	if !ok {
+		return nil, fmt.Errorf("unregistered field type: %T", field)
+	}
+	return json.Marshal(map[string]Field{name: field})
+}
+
+// unmarshalField returns the field represented by the given JSON data, in the
+// form: {"FieldType": {...}}. An error is returned if the field type is not
+// registered.
+func unmarshalField(data []byte) (Field, error) {
+	fMap := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fMap); err != nil {
+		return nil, err
+	}
+	if len(fMap) != 1 {
+		return nil, fmt.Errorf("invalid field definition: %s", data)
+	}
+	for fType, raw := range fMap {
+		field, ok := fieldsRegistry[fType]
+		if !ok {
+			return nil, fmt.Errorf("invalid field type: %s", fType)
+		}
+		ft := reflect.Indirect(reflect.ValueOf(field)).Type()
+		fp := reflect.New(ft).Interface()
+		if err := json.Unmarshal(raw, fp); err != nil {
+			return nil, err
+		}
+		return fp.(Field), nil
+	}
+	return nil, nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (fields Fields) MarshalJSON() ([]byte, error) {
-	result := map[string]map[string]Field{}
+	result := map[string]json.RawMessage{}
 	for name, f := range fields {
-		m := map[string]Field{}
-		m[strings.Split(reflect.ValueOf(f).Type().String(), ".")[1]] = f
-		result[name] = m
+		data, err := marshalField(f)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = data
 	}
 	return json.Marshal(result)
 }
@@ -65,24 +183,17 @@ func (fields Fields) MarshalJSON() ([]byte, error) {
 // returned if the field type is not registered.
 func (fields *Fields) UnmarshalJSON(data []byte) error {
 	result := map[string]Field{}
-	rawMap := map[string]map[string]json.RawMessage{}
+	rawMap := map[string]json.RawMessage{}
 	err := json.Unmarshal(data, &rawMap)
 	if err != nil {
 		return err
 	}
-	for name, fMap := range rawMap {
-		for fType, raw := range fMap {
-			field, ok := fieldsRegistry[fType]
-			if !ok {
-				return fmt.Errorf("invalid field type: %s", fType)
-			}
-			ft := reflect.Indirect(reflect.ValueOf(field)).Type()
-			fp := reflect.New(ft).Interface()
-			if err := json.Unmarshal(raw, fp); err != nil {
-				return err
-			}
-			result[name] = fp.(Field)
+	for name, raw := range rawMap {
+		field, err := unmarshalField(raw)
+		if err != nil {
+			return err
 		}
+		result[name] = field
 	}
 	*fields = result
 	return nil
@@ -90,10 +201,62 @@ func (fields *Fields) UnmarshalJSON(data []byte) error {
 
 // fieldsRegistry holds a global registry with the available fields.
 var fieldsRegistry = Fields{
-	"IntegerField":  IntegerField{},
-	"BooleanField":  BooleanField{},
-	"CharField":     CharField{},
-	"DateField":     DateField{},
-	"TimeField":     TimeField{},
-	"DateTimeField": DateTimeField{},
+	"IntegerField":          IntegerField{},
+	"SmallIntegerField":     SmallIntegerField{},
+	"BigIntegerField":       BigIntegerField{},
+	"PositiveIntegerField":  PositiveIntegerField{},
+	"FloatField":            FloatField{},
+	"DecimalField":          DecimalField{},
+	"BooleanField":          BooleanField{},
+	"CharField":             CharField{},
+	"TextField":             TextField{},
+	"EmailField":            EmailField{},
+	"URLField":              URLField{},
+	"GenericIPAddressField": GenericIPAddressField{},
+	"BinaryField":           BinaryField{},
+	"UUIDField":             UUIDField{},
+	"JSONField":             JSONField{},
+	"ArrayField":            ArrayField{},
+	"DateField":             DateField{},
+	"TimeField":             TimeField{},
+	"DateTimeField":         DateTimeField{},
+	"DurationField":         DurationField{},
+	"GeneratedField":        GeneratedField{},
+	"EnumField":             EnumField{},
+	"EncryptedField":        EncryptedField{},
+	"VersionField":          VersionField{},
+}
+
+// fieldName returns the name the given field type was registered with, and
+// false if the type is not registered.
+func fieldName(field Field) (string, bool) {
+	if field == nil {
+		return "", false
+	}
+	ft := reflect.Indirect(reflect.ValueOf(field)).Type()
+	for name, f := range fieldsRegistry {
+		if reflect.Indirect(reflect.ValueOf(f)).Type() == ft {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// RegisterField registers a custom field type with the given name, which is
+// used to identify the type on migration files. Returns an error if the name
+// or the field type are already registered.
+func RegisterField(name string, field Field) error {
+	if name == "" || field == nil {
+		return fmt.Errorf("gomodel: invalid field registration: %q", name)
+	}
+	if _, found := fieldsRegistry[name]; found {
+		return fmt.Errorf("gomodel: duplicate field: %s", name)
+	}
+	if regName, found := fieldName(field); found {
+		return fmt.Errorf(
+			"gomodel: field type %T already registered as %s", field, regName,
+		)
+	}
+	fieldsRegistry[name] = field
+	return nil
 }
@@ -0,0 +1,373 @@
+package gomodel
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// parsePostgresArray returns the elements of the given one-dimensional postgres
+// array literal, as strings or nil for NULL elements.
+func parsePostgresArray(s string) ([]interface{}, error) {
+	if idx := strings.Index(s, "={"); idx > 0 && strings.HasPrefix(s, "[") {
+		s = s[idx+1:]
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid array literal: %s", s)
+	}
+	body := s[1 : len(s)-1]
+	elems := []interface{}{}
+	if strings.TrimSpace(body) == "" {
+		return elems, nil
+	}
+	for i := 0; i <= len(body); i++ {
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i < len(body) && body[i] == '{' {
+			return nil, fmt.Errorf("multidimensional arrays not supported")
+		} else if i < len(body) && body[i] == '"' {
+			var b strings.Builder
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' {
+					i++
+				}
+				if i < len(body) {
+					b.WriteByte(body[i])
+				}
+			}
+			if i >= len(body) {
+				return nil, fmt.Errorf("invalid array literal: %s", s)
+			}
+			elems = append(elems, b.String())
+			i++
+		} else {
+			end := strings.IndexByte(body[i:], ',')
+			if end == -1 {
+				end = len(body)
+			} else {
+				end += i
+			}
+			token := strings.TrimSpace(body[i:end])
+			if strings.ToUpper(token) == "NULL" {
+				elems = append(elems, nil)
+			} else {
+				elems = append(elems, token)
+			}
+			i = end
+		}
+		if i < len(body) && body[i] != ',' {
+			return nil, fmt.Errorf("invalid array literal: %s", s)
+		}
+	}
+	return elems, nil
+}
+
+// formatPostgresArray returns the postgres array literal for the given
+// elements.
+func formatPostgresArray(elems []interface{}) string {
+	literals := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		var s string
+		switch val := elem.(type) {
+		case nil:
+			literals = append(literals, "NULL")
+			continue
+		case time.Time:
+			s = val.Format(time.RFC3339Nano)
+		default:
+			s = asString(elem)
+		}
+		s = strings.Replace(s, `\`, `\\`, -1)
+		s = strings.Replace(s, `"`, `\"`, -1)
+		literals = append(literals, fmt.Sprintf(`"%s"`, s))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(literals, ","))
+}
+
+// NullArray represents an array that may be null. Elements coming from the
+// database are strings, or nil for NULL elements.
+type NullArray struct {
+	Array []interface{}
+	Valid bool // Valid is true if Array is not NULL
+}
+
+// Scan implements the Scanner interface. It accepts postgres array literals
+// and Go slices or arrays.
+func (n *NullArray) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		n.Array, n.Valid = nil, false
+		return nil
+	case []byte:
+		return n.Scan(string(v))
+	case string:
+		elems, err := parsePostgresArray(v)
+		if err != nil {
+			return err
+		}
+		n.Array, n.Valid = elems, true
+		return nil
+	case NullArray:
+		n.Array, n.Valid = v.Array, v.Valid
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("converting type %T to an array", value)
+	}
+	elems := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elems = append(elems, rv.Index(i).Interface())
+	}
+	n.Array, n.Valid = elems, true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (n NullArray) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return formatPostgresArray(n.Array), nil
+}
+
+// arrayLookuper is the interface implemented by fields holding arrays, enabling
+// the len transform and the contains, contained_by and overlap operators on
+// conditioners.
+type arrayLookuper interface {
+	arrayLookups() bool
+}
+
+// arrayFieldMarshaler is used to serialize the base field of an ArrayField.
+type arrayFieldMarshaler struct {
+	Base         json.RawMessage `json:",omitempty"`
+	Null         bool            `json:",omitempty"`
+	Blank        bool            `json:",omitempty"`
+	Column       string          `json:",omitempty"`
+	DBDefault    Expression      `json:",omitempty"`
+	DefaultEmpty bool            `json:",omitempty"`
+}
+
+// ArrayField implements the Field interface for arrays of the Base field type.
+// It's only supported by the postgres driver.
+type ArrayField struct {
+	// Base is the field definition of the array elements.
+	Base Field
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// DefaultEmpty is true if the empty array is the field default value.
+	DefaultEmpty bool `json:",omitempty"`
+}
+
+// arrayLookups implements the arrayLookuper interface.
+func (f ArrayField) arrayLookups() bool {
+	return true
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f ArrayField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f ArrayField) IsUnique() bool {
+	return false
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f ArrayField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f ArrayField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f ArrayField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f ArrayField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f ArrayField) HasIndex() bool {
+	return false
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f ArrayField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface. It returns
+// a blank string for drivers other than postgres.
+func (f ArrayField) DataType(dvr string) string {
+	if dvr != "postgres" || f.Base == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s[]", f.Base.DataType(dvr))
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f ArrayField) DefaultValue() (Value, bool) {
+	if f.DefaultEmpty {
+		return []interface{}{}, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f ArrayField) Recipient() interface{} {
+	var val NullArray
+	return &val
+}
+
+// Value implements the Value method of the Field interface. Elements are
+// converted using the Base field, and returned in a slice of the Base value
+// type if possible ([]interface{} otherwise).
+func (f ArrayField) Value(rec interface{}) Value {
+	array, ok := rec.(NullArray)
+	if !ok {
+		return rec
+	}
+	if !array.Valid {
+		return nil
+	}
+	if f.Base == nil {
+		return array.Array
+	}
+	values := make([]interface{}, 0, len(array.Array))
+	for _, elem := range array.Array {
+		if elem == nil {
+			values = append(values, nil)
+			continue
+		}
+		recipient := f.Base.Recipient()
+		if err := setRecipient(recipient, elem); err != nil {
+			return rec
+		}
+		val := reflect.Indirect(reflect.ValueOf(recipient)).Interface()
+		values = append(values, f.Base.Value(val))
+	}
+	zeroRec := reflect.Indirect(reflect.ValueOf(f.Base.Recipient())).Interface()
+	zero := f.Base.Value(zeroRec)
+	if zero == nil {
+		return values
+	}
+	elemType := reflect.TypeOf(zero)
+	result := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(values))
+	for _, val := range values {
+		if val == nil || !reflect.TypeOf(val).AssignableTo(elemType) {
+			return values
+		}
+		result = reflect.Append(result, reflect.ValueOf(val))
+	}
+	return result.Interface()
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value can be a slice, an array or a postgres array literal, and the elements
+// are converted using the Base field.
+func (f ArrayField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if f.Base == nil {
+		return nil, fmt.Errorf("array field without base field")
+	}
+	array := NullArray{}
+	if vlr, ok := v.(driver.Valuer); ok {
+		if n, ok := v.(NullArray); ok {
+			array = n
+		} else if val, err := vlr.Value(); err != nil {
+			return nil, err
+		} else if err := array.Scan(val); err != nil {
+			return nil, err
+		}
+	} else if err := array.Scan(v); err != nil {
+		return nil, err
+	}
+	if !array.Valid {
+		return nil, nil
+	}
+	elems := make([]interface{}, 0, len(array.Array))
+	for _, elem := range array.Array {
+		val, err := f.Base.DriverValue(elem, dvr)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, val)
+	}
+	return formatPostgresArray(elems), nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f ArrayField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f ArrayField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f ArrayField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f ArrayField) MarshalJSON() ([]byte, error) {
+	result := arrayFieldMarshaler{
+		Null:         f.Null,
+		Blank:        f.Blank,
+		Column:       f.Column,
+		DBDefault:    f.DBDefault,
+		DefaultEmpty: f.DefaultEmpty,
+	}
+	if f.Base != nil {
+		base, err := marshalField(f.Base)
+		if err != nil {
+			return nil, err
+		}
+		result.Base = base
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *ArrayField) UnmarshalJSON(data []byte) error {
+	r := arrayFieldMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	if len(r.Base) > 0 {
+		base, err := unmarshalField(r.Base)
+		if err != nil {
+			return err
+		}
+		f.Base = base
+	}
+	f.Null = r.Null
+	f.Blank = r.Blank
+	f.Column = r.Column
+	f.DBDefault = r.DBDefault
+	f.DefaultEmpty = r.DefaultEmpty
+	return nil
+}
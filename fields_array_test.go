@@ -0,0 +1,147 @@
+package gomodel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestArrayField tests the ArrayField struct methods
+func TestArrayField(t *testing.T) {
+	field := ArrayField{Base: IntegerField{}}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "INTEGER[]" {
+			t.Errorf("expected INTEGER[], got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "" {
+			t.Errorf("expected blank type, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		field := ArrayField{Base: IntegerField{}, DefaultEmpty: true}
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if !reflect.DeepEqual(val, []interface{}{}) {
+			t.Errorf("expected empty array, got %v", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullArray); !ok {
+			t.Errorf("expected *gomodel.NullArray, got %T", recipient)
+		}
+	})
+
+	t.Run("ScanLiteral", func(t *testing.T) {
+		array := NullArray{}
+		if err := array.Scan([]byte(`{1,NULL,"a \"b\"","c,d"}`)); err != nil {
+			t.Fatal(err)
+		}
+		expected := []interface{}{"1", nil, `a "b"`, "c,d"}
+		if !array.Valid || !reflect.DeepEqual(array.Array, expected) {
+			t.Errorf("expected %v, got %v", expected, array.Array)
+		}
+	})
+
+	t.Run("ScanEmpty", func(t *testing.T) {
+		array := NullArray{}
+		if err := array.Scan("{}"); err != nil {
+			t.Fatal(err)
+		}
+		if !array.Valid || len(array.Array) != 0 {
+			t.Errorf("expected empty array, got %v", array.Array)
+		}
+	})
+
+	t.Run("ScanNull", func(t *testing.T) {
+		array := NullArray{Array: []interface{}{"1"}, Valid: true}
+		if err := array.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		if array.Valid || array.Array != nil {
+			t.Errorf("expected null array, got %v", array.Array)
+		}
+	})
+
+	t.Run("ScanInvalid", func(t *testing.T) {
+		array := NullArray{}
+		if err := array.Scan(`{"a`); err == nil {
+			t.Error("expected invalid literal error")
+		}
+		if err := array.Scan("{{1,2},{3,4}}"); err == nil {
+			t.Error("expected multidimensional array error")
+		}
+		if err := array.Scan(42); err == nil {
+			t.Error("expected invalid type error")
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		array := NullArray{Array: []interface{}{"1", "2"}, Valid: true}
+		val := field.Value(array)
+		if !reflect.DeepEqual(val, []int32{1, 2}) {
+			t.Errorf("expected []int32{1, 2}, got %#v", val)
+		}
+	})
+
+	t.Run("NullElementsValue", func(t *testing.T) {
+		array := NullArray{Array: []interface{}{"1", nil}, Valid: true}
+		val := field.Value(array)
+		if !reflect.DeepEqual(val, []interface{}{int32(1), nil}) {
+			t.Errorf("expected []interface{}{1, nil}, got %#v", val)
+		}
+	})
+
+	t.Run("NullValue", func(t *testing.T) {
+		if val := field.Value(NullArray{}); val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		val, err := field.DriverValue([]int{1, 2}, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != `{"1","2"}` {
+			t.Errorf(`expected {"1","2"}, got %v`, val)
+		}
+	})
+
+	t.Run("DriverValueLiteral", func(t *testing.T) {
+		val, err := field.DriverValue("{1,NULL}", "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != `{"1",NULL}` {
+			t.Errorf(`expected {"1",NULL}, got %v`, val)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		val, err := field.DriverValue(nil, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueInvalidElement", func(t *testing.T) {
+		field := ArrayField{Base: SmallIntegerField{}}
+		if _, err := field.DriverValue([]int{70000}, "postgres"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
-	"reflect"
 )
 
 // CharField implements the Field interface for small to medium-sized strings.
@@ -23,8 +22,13 @@ type CharField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Choices is a list of possible choices for the field.
 	Choices []Choice `json:",omitempty"`
+	// StrictChoices is true if the choices must be enforced by a database
+	// constraint.
+	StrictChoices bool `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default.
 	Default string `json:",omitempty"`
 	// DefaultEmpty is true if the empty string is the field default value.
@@ -119,13 +123,327 @@ func (f CharField) DriverValue(val Value, dvr string) (interface{}, error) {
 // DisplayValue implements the DisplayValue method of the Field interface.
 func (f CharField) DisplayValue(val Value) string {
 	for _, choice := range f.Choices {
-		if reflect.DeepEqual(f.Value(val), choice.Value) {
+		if sameChoice(f.Value(val), choice.Value) {
 			return choice.Label
 		}
 	}
 	return fmt.Sprintf("%v", f.Value(val))
 }
 
+// FieldMaxLength implements the FieldMaxLength method of the MaxLengthField
+// interface.
+func (f CharField) FieldMaxLength() int {
+	return f.MaxLength
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f CharField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f CharField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesField
+// interface.
+func (f CharField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, f.StrictChoices
+}
+
+// WithChoices implements the WithChoices method of the ChoicesField interface.
+func (f CharField) WithChoices(choices []Choice, strict bool) Field {
+	f.Choices = choices
+	f.StrictChoices = strict
+	return f
+}
+
+// TextField implements the Field interface for large strings.
+type TextField struct {
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default string `json:",omitempty"`
+	// DefaultEmpty is true if the empty string is the field default value.
+	DefaultEmpty bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f TextField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f TextField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f TextField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f TextField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f TextField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f TextField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f TextField) HasIndex() bool {
+	return f.Index && !f.Unique
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f TextField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f TextField) DataType(dvr string) string {
+	return "TEXT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f TextField) DefaultValue() (Value, bool) {
+	if f.Default != "" || f.DefaultEmpty {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f TextField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullString
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f TextField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullString); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.String
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface.
+func (f TextField) DriverValue(val Value, dvr string) (interface{}, error) {
+	if vlr, ok := val.(driver.Valuer); ok {
+		return vlr.Value()
+	}
+	return val, nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f TextField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f TextField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f TextField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// NullBytes represents a []byte that may be null.
+type NullBytes struct {
+	Bytes []byte
+	Valid bool // Valid is true if Bytes is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *NullBytes) Scan(value interface{}) error {
+	if value == nil {
+		n.Bytes, n.Valid = nil, false
+		return nil
+	}
+	n.Valid = true
+	return setRecipient(&n.Bytes, value)
+}
+
+// Value implements the driver Valuer interface.
+func (n NullBytes) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Bytes, nil
+}
+
+// BinaryField implements the Field interface for raw binary data.
+type BinaryField struct {
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Nil for no default.
+	Default []byte `json:",omitempty"`
+	// DefaultEmpty is true if an empty slice is the field default value.
+	DefaultEmpty bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f BinaryField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f BinaryField) IsUnique() bool {
+	return false
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f BinaryField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f BinaryField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f BinaryField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f BinaryField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f BinaryField) HasIndex() bool {
+	return false
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f BinaryField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f BinaryField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "BYTEA"
+	}
+	return "BLOB"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f BinaryField) DefaultValue() (Value, bool) {
+	if f.Default != nil {
+		return cloneBytes(f.Default), true
+	} else if f.DefaultEmpty {
+		return []byte{}, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f BinaryField) Recipient() interface{} {
+	if f.Null {
+		var val NullBytes
+		return &val
+	}
+	var val []byte
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f BinaryField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullBytes); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Bytes
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface.
+func (f BinaryField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return val, nil
+	case string:
+		return []byte(val), nil
+	}
+	return nil, fmt.Errorf("invalid binary value: %T", v)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface. It
+// returns the hexadecimal representation of the value.
+func (f BinaryField) DisplayValue(val Value) string {
+	val = f.Value(val)
+	if b, ok := val.([]byte); ok {
+		return fmt.Sprintf("%x", b)
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f BinaryField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f BinaryField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
 // BooleanField implements the Field interface for true/false fields.
 type BooleanField struct {
 	// Null is true if the field can have null values.
@@ -136,6 +454,8 @@ type BooleanField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default.
 	Default bool `json:",omitempty"`
 	// DefaultFalse is true if false is the field default value.
@@ -235,6 +555,17 @@ func (f BooleanField) DisplayValue(val Value) string {
 	return fmt.Sprintf("%v", f.Value(val))
 }
 
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f BooleanField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f BooleanField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
 // NullInt32 represents an int32 that may be null. TODO: remove for Golang 1.13
 type NullInt32 struct {
 	Int32 int32
@@ -275,8 +606,13 @@ type IntegerField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Choices is a list of possible choices for the field.
 	Choices []Choice `json:",omitempty"`
+	// StrictChoices is true if the choices must be enforced by a database
+	// constraint.
+	StrictChoices bool `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default.
 	Default int32 `json:",omitempty"`
 	// DefaultZero is true if zero is the field default value.
@@ -374,9 +710,33 @@ func (f IntegerField) DriverValue(v Value, dvr string) (interface{}, error) {
 // DisplayValue implements the DisplayValue method of the Field interface.
 func (f IntegerField) DisplayValue(val Value) string {
 	for _, choice := range f.Choices {
-		if reflect.DeepEqual(f.Value(val), choice.Value) {
+		if sameChoice(f.Value(val), choice.Value) {
 			return choice.Label
 		}
 	}
 	return fmt.Sprintf("%v", f.Value(val))
 }
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f IntegerField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f IntegerField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesField
+// interface.
+func (f IntegerField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, f.StrictChoices
+}
+
+// WithChoices implements the WithChoices method of the ChoicesField interface.
+func (f IntegerField) WithChoices(choices []Choice, strict bool) Field {
+	f.Choices = choices
+	f.StrictChoices = strict
+	return f
+}
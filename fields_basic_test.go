@@ -178,6 +178,186 @@ func TestCharField(t *testing.T) {
 
 }
 
+// TestTextField tests the TextField struct methods
+func TestTextField(t *testing.T) {
+	field := TextField{}
+
+	t.Run("IsPK", func(t *testing.T) {
+		if field.IsPK() {
+			t.Error("expected false, got true")
+		}
+	})
+
+	t.Run("HasIndex", func(t *testing.T) {
+		field.Index = true
+		field.Unique = true
+		if field.HasIndex() {
+			t.Error("expected false, got true")
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "TEXT" {
+			t.Errorf("expected TEXT, got %s", field.DataType("postgres"))
+		}
+	})
+
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		field.DefaultEmpty = true
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.(string); !ok || val != "" {
+			t.Errorf("expected empty string, got %s", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*string); !ok {
+			t.Errorf("expected *string, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*sql.NullString); !ok {
+			t.Errorf("expected *sql.NullString, got %T", recipient)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(sql.NullString{}); value != nil {
+			t.Errorf("expected nil, got %s", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue("foo", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(string); !ok || v != "foo" {
+			t.Errorf("expected foo, got %s", v)
+		}
+	})
+}
+
+// TestBinaryField tests the BinaryField struct methods
+func TestBinaryField(t *testing.T) {
+	field := BinaryField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "BYTEA" {
+			t.Errorf("expected BYTEA, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "BLOB" {
+			t.Errorf("expected BLOB, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		field.Default = []byte("foo")
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.([]byte); !ok || string(val) != "foo" {
+			t.Errorf("expected foo, got %s", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*[]byte); !ok {
+			t.Errorf("expected *[]byte, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullBytes); !ok {
+			t.Errorf("expected *gomodel.NullBytes, got %T", recipient)
+		}
+	})
+
+	t.Run("ScanNull", func(t *testing.T) {
+		recipient := NullBytes{Bytes: []byte("foo"), Valid: true}
+		if err := recipient.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		if recipient.Valid || recipient.Bytes != nil {
+			t.Errorf("expected null bytes, got %v", recipient)
+		}
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		src := []byte("foo")
+		recipient := NullBytes{}
+		if err := recipient.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		src[0] = 'b'
+		if !recipient.Valid || string(recipient.Bytes) != "foo" {
+			t.Errorf("expected foo, got %s", recipient.Bytes)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(NullBytes{}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("ValueNotNull", func(t *testing.T) {
+		value := field.Value(NullBytes{Bytes: []byte("foo"), Valid: true})
+		if v, ok := value.([]byte); !ok || string(v) != "foo" {
+			t.Errorf("expected foo, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue("foo", "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.([]byte); !ok || string(v) != "foo" {
+			t.Errorf("expected foo, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		value, err := field.DriverValue(NullBytes{}, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue(42, "postgres"); err == nil {
+			t.Error("expected invalid value error")
+		}
+	})
+
+	t.Run("DisplayValue", func(t *testing.T) {
+		if value := field.DisplayValue([]byte{0xca, 0xfe}); value != "cafe" {
+			t.Errorf("expected cafe, got %s", value)
+		}
+	})
+}
+
 // TestBooleanField tests the BooleanField struct methods
 func TestBooleanField(t *testing.T) {
 	field := BooleanField{}
@@ -0,0 +1,392 @@
+package gomodel
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// KeyProvider is the interface that provides the keys used by encrypted
+// fields. Keys are identified so they can be rotated: new values are always
+// encrypted with the current key, and stored values are decrypted with the
+// key they were encrypted with.
+type KeyProvider interface {
+	// CurrentKey returns the id and the key used to encrypt new values.
+	CurrentKey() (id string, key []byte, err error)
+	// Key returns the key identified by the given id.
+	Key(id string) ([]byte, error)
+}
+
+// EncryptionKeys is the KeyProvider used by encrypted fields.
+var EncryptionKeys KeyProvider
+
+// StaticKeys implements the KeyProvider interface for a fixed set of AES keys,
+// which must be 16, 24 or 32 bytes long.
+type StaticKeys struct {
+	// Current is the id of the key used to encrypt new values.
+	Current string
+	// Keys maps the key ids to the keys, including the retired ones still
+	// needed to decrypt stored values.
+	Keys map[string][]byte
+}
+
+// CurrentKey implements the CurrentKey method of the KeyProvider interface.
+func (k StaticKeys) CurrentKey() (string, []byte, error) {
+	key, err := k.Key(k.Current)
+	return k.Current, key, err
+}
+
+// Key implements the Key method of the KeyProvider interface.
+func (k StaticKeys) Key(id string) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key: %s", id)
+	}
+	return key, nil
+}
+
+// encryptedFieldMarshaler is used to serialize the base field of an
+// EncryptedField.
+type encryptedFieldMarshaler struct {
+	Base          json.RawMessage `json:",omitempty"`
+	Deterministic bool            `json:",omitempty"`
+}
+
+// EncryptedField implements the Field interface for values encrypted at rest
+// with AES-GCM. The values are handled by the Base field, which must be a
+// string or binary one, and stored as text in the form "keyID$ciphertext".
+//
+// Encrypted fields can't be used in lookups, unless they are compared to nil
+// or the field is Deterministic. Deterministic values are compared with the
+// current key, so rows encrypted with previous keys won't match until they're
+// saved again.
+//
+// The table and column names are authenticated along with the values, so a
+// value copied to another column can't be decrypted. Renaming the table or the
+// column requires the values to be encrypted again.
+type EncryptedField struct {
+	// Base is the field definition of the decrypted values.
+	Base Field
+	// Deterministic is true if the same value must always produce the same
+	// ciphertext, allowing equality lookups at the cost of revealing which
+	// rows hold equal values. It's required if the Base field is unique or
+	// indexed.
+	Deterministic bool `json:",omitempty"`
+	// aad is the additional authenticated data, set by the model the field
+	// belongs to (see SetupEncryptedFields).
+	aad string
+}
+
+// hkdf derives a 32 bytes key for the given purpose from the given key, using
+// HKDF-SHA256 (RFC 5869) without salt.
+func hkdf(key []byte, info string) []byte {
+	extract := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extract.Write(key)
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write([]byte(info))
+	expand.Write([]byte{1})
+	return expand.Sum(nil)
+}
+
+// encryptedField returns the given field as an EncryptedField, and false if
+// it's not an encrypted one.
+func encryptedField(field Field) (EncryptedField, bool) {
+	switch f := field.(type) {
+	case EncryptedField:
+		return f, true
+	case *EncryptedField:
+		if f != nil {
+			return *f, true
+		}
+	}
+	return EncryptedField{}, false
+}
+
+// checkEncryptedFields returns an error if an encrypted field of the model is
+// unique or indexed without being Deterministic, since its ciphertexts never
+// repeat.
+func (m Model) checkEncryptedFields() error {
+	for name, field := range m.fields {
+		f, ok := encryptedField(field)
+		if !ok || f.Deterministic {
+			continue
+		}
+		if f.IsUnique() || f.HasIndex() {
+			return fmt.Errorf("%s: non deterministic encrypted index", name)
+		}
+	}
+	return nil
+}
+
+// gcm returns the AEAD cipher for the given key.
+func (f EncryptedField) gcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the stored representation of the given plaintext.
+func (f EncryptedField) encrypt(plaintext []byte) (string, error) {
+	if EncryptionKeys == nil {
+		return "", fmt.Errorf("encryption key provider not set")
+	}
+	id, key, err := EncryptionKeys.CurrentKey()
+	if err != nil {
+		return "", err
+	}
+	if id == "" || strings.Contains(id, "$") {
+		return "", fmt.Errorf("invalid encryption key id: %q", id)
+	}
+	aead, err := f.gcm(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if f.Deterministic {
+		// The nonce is derived from the plaintext with a separate key, so
+		// equal values produce equal ciphertexts.
+		mac := hmac.New(sha256.New, hkdf(key, "gomodel nonce"))
+		mac.Write([]byte(f.aad))
+		mac.Write([]byte{0})
+		mac.Write(plaintext)
+		copy(nonce, mac.Sum(nil))
+	} else if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(f.aad))
+	return id + "$" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext of the given stored value.
+func (f EncryptedField) decrypt(stored string) ([]byte, error) {
+	if EncryptionKeys == nil {
+		return nil, fmt.Errorf("encryption key provider not set")
+	}
+	parts := strings.SplitN(stored, "$", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid encrypted value")
+	}
+	key, err := EncryptionKeys.Key(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := f.gcm(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted value")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, []byte(f.aad))
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f EncryptedField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f EncryptedField) IsUnique() bool {
+	return f.Base != nil && f.Base.IsUnique()
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f EncryptedField) IsNull() bool {
+	return f.Base != nil && f.Base.IsNull()
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f EncryptedField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f EncryptedField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f EncryptedField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f EncryptedField) HasIndex() bool {
+	return f.Base != nil && f.Base.HasIndex()
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f EncryptedField) DBColumn(name string) string {
+	if f.Base == nil {
+		return name
+	}
+	return f.Base.DBColumn(name)
+}
+
+// DataType implements the DataType method of the Field interface. It returns
+// a blank string if there's no base field.
+func (f EncryptedField) DataType(dvr string) string {
+	if f.Base == nil {
+		return ""
+	}
+	return "TEXT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f EncryptedField) DefaultValue() (Value, bool) {
+	if f.Base == nil {
+		return nil, false
+	}
+	return f.Base.DefaultValue()
+}
+
+// encryptedValue is the recipient of encrypted fields. The stored value is
+// decrypted on Scan, so rows that can't be decrypted fail to load.
+type encryptedValue struct {
+	field EncryptedField
+	value Value
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *encryptedValue) Scan(src interface{}) error {
+	switch stored := src.(type) {
+	case nil:
+		v.value = nil
+		return nil
+	case string:
+		val, err := v.field.decryptValue(stored)
+		v.value = val
+		return err
+	case []byte:
+		val, err := v.field.decryptValue(string(stored))
+		v.value = val
+		return err
+	}
+	return fmt.Errorf("invalid encrypted value type: %T", src)
+}
+
+// decryptValue decrypts the given stored value and returns it converted by
+// the base field.
+func (f EncryptedField) decryptValue(stored string) (Value, error) {
+	if f.Base == nil {
+		return nil, fmt.Errorf("encrypted field without base field")
+	}
+	plaintext, err := f.decrypt(stored)
+	if err != nil {
+		return nil, err
+	}
+	baseRec := f.Base.Recipient()
+	if scanner, ok := baseRec.(sql.Scanner); ok {
+		if err := scanner.Scan(plaintext); err != nil {
+			return nil, err
+		}
+	} else {
+		rv := reflect.ValueOf(baseRec).Elem()
+		switch {
+		case rv.Kind() == reflect.String:
+			rv.SetString(string(plaintext))
+		case rv.Type() == reflect.TypeOf([]byte{}):
+			rv.SetBytes(plaintext)
+		default:
+			err := fmt.Errorf("encrypted field base must be string or binary")
+			return nil, err
+		}
+	}
+	baseVal := reflect.Indirect(reflect.ValueOf(baseRec)).Interface()
+	return f.Base.Value(baseVal), nil
+}
+
+// Recipient implements the Recipient method of the Field interface. The
+// stored value is decrypted when scanned, and a decryption error is returned
+// by the Scan method of the recipient.
+func (f EncryptedField) Recipient() interface{} {
+	return &encryptedValue{field: f}
+}
+
+// Value implements the Value method of the Field interface. Scanned values
+// are returned decrypted, and the values set by the application (held by the
+// base field recipient) are passed to the base field.
+func (f EncryptedField) Value(rec interface{}) Value {
+	if val, ok := rec.(encryptedValue); ok {
+		return val.value
+	}
+	if f.Base == nil {
+		return rec
+	}
+	return f.Base.Value(rec)
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value is converted by the base field and then encrypted.
+func (f EncryptedField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if f.Base == nil {
+		return nil, fmt.Errorf("encrypted field without base field")
+	}
+	val, err := f.Base.DriverValue(v, dvr)
+	if err != nil {
+		return nil, err
+	}
+	switch plaintext := val.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return f.encrypt([]byte(plaintext))
+	case []byte:
+		return f.encrypt(plaintext)
+	}
+	return nil, fmt.Errorf("encrypted field base must be string or binary")
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f EncryptedField) DisplayValue(val Value) string {
+	if f.Base == nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return f.Base.DisplayValue(val)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f EncryptedField) MarshalJSON() ([]byte, error) {
+	result := encryptedFieldMarshaler{Deterministic: f.Deterministic}
+	if f.Base != nil {
+		base, err := marshalField(f.Base)
+		if err != nil {
+			return nil, err
+		}
+		result.Base = base
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *EncryptedField) UnmarshalJSON(data []byte) error {
+	r := encryptedFieldMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	if len(r.Base) > 0 {
+		base, err := unmarshalField(r.Base)
+		if err != nil {
+			return err
+		}
+		f.Base = base
+	}
+	f.Deterministic = r.Deterministic
+	return nil
+}
@@ -0,0 +1,252 @@
+package gomodel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEncryptedField tests the EncryptedField struct methods
+func TestEncryptedField(t *testing.T) {
+	keys := StaticKeys{
+		Current: "k1",
+		Keys:    map[string][]byte{"k1": []byte("0123456789abcdef")},
+	}
+	EncryptionKeys = keys
+	defer func() { EncryptionKeys = nil }()
+	field := EncryptedField{Base: CharField{MaxLength: 100, Null: true}}
+	binField := EncryptedField{Base: BinaryField{}}
+	scan := func(f EncryptedField, stored interface{}) (Value, error) {
+		rec := f.Recipient()
+		if err := rec.(sql.Scanner).Scan(stored); err != nil {
+			return nil, err
+		}
+		return f.Value(reflect.Indirect(reflect.ValueOf(rec)).Interface()), nil
+	}
+
+	t.Run("DataType", func(t *testing.T) {
+		if dt := field.DataType("postgres"); dt != "TEXT" {
+			t.Errorf("expected TEXT, got %s", dt)
+		}
+		if dt := (EncryptedField{}).DataType("postgres"); dt != "" {
+			t.Errorf("expected blank data type, got %s", dt)
+		}
+	})
+
+	t.Run("IsNull", func(t *testing.T) {
+		if !field.IsNull() || binField.IsNull() {
+			t.Error("expected null settings of base field")
+		}
+	})
+
+	t.Run("DriverValueNoProvider", func(t *testing.T) {
+		EncryptionKeys = nil
+		defer func() { EncryptionKeys = keys }()
+		if _, err := field.DriverValue("secret", "sqlite3"); err == nil {
+			t.Error("expected key provider not set error")
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		val, err := field.DriverValue(nil, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueInvalidBase", func(t *testing.T) {
+		f := EncryptedField{Base: IntegerField{}}
+		if _, err := f.DriverValue(42, "sqlite3"); err == nil {
+			t.Error("expected invalid base field error")
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		val, err := field.DriverValue("secret", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored := val.(string)
+		if !strings.HasPrefix(stored, "k1$") {
+			t.Errorf("expected value encrypted with k1, got %s", stored)
+		}
+		if strings.Contains(stored, "secret") {
+			t.Fatalf("unexpected stored value: %s", stored)
+		}
+		result, err := scan(field, stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != "secret" {
+			t.Errorf("expected secret, got %#v", result)
+		}
+		again, _ := field.DriverValue("secret", "sqlite3")
+		if again == val {
+			t.Error("expected different ciphertexts")
+		}
+	})
+
+	t.Run("RoundTripBinary", func(t *testing.T) {
+		val, err := binField.DriverValue([]byte("secret"), "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := scan(binField, []byte(val.(string)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, ok := result.([]byte); !ok || string(b) != "secret" {
+			t.Errorf("expected secret bytes, got %#v", result)
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		f := EncryptedField{Base: TextField{}, Deterministic: true}
+		val, err := f.DriverValue("secret", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, _ := f.DriverValue("secret", "sqlite3")
+		if again != val {
+			t.Errorf("expected %s, got %s", val, again)
+		}
+	})
+
+	t.Run("DeterministicColumns", func(t *testing.T) {
+		f := EncryptedField{Base: TextField{}, Deterministic: true}
+		f.aad = "users_user.ssn"
+		other := f
+		other.aad = "users_user.phone"
+		val, _ := f.DriverValue("secret", "sqlite3")
+		otherVal, _ := other.DriverValue("secret", "sqlite3")
+		if val == otherVal {
+			t.Error("expected different ciphertexts on different columns")
+		}
+	})
+
+	t.Run("AssociatedData", func(t *testing.T) {
+		f := field
+		f.aad = "users_user.ssn"
+		val, err := f.DriverValue("secret", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value, err := scan(f, val); err != nil || value != "secret" {
+			t.Errorf("expected secret, got %v (%v)", value, err)
+		}
+		other := field
+		other.aad = "users_user.phone"
+		if _, err := scan(other, val); err == nil {
+			t.Error("expected value copied to another column not to decrypt")
+		}
+	})
+
+	t.Run("SetupEncryptedFields", func(t *testing.T) {
+		model := New(
+			"User",
+			Fields{"ssn": EncryptedField{Base: TextField{Column: "enc_ssn"}}},
+			Options{Table: "users_user"},
+		).Model
+		model.SetupEncryptedFields()
+		f := model.fields["ssn"].(EncryptedField)
+		if f.aad != "users_user.enc_ssn" {
+			t.Errorf("expected users_user.enc_ssn, got %s", f.aad)
+		}
+	})
+
+	t.Run("RegisterIndex", func(t *testing.T) {
+		app := &Application{name: "users", models: map[string]*Model{}}
+		model := New(
+			"User",
+			Fields{"ssn": EncryptedField{Base: CharField{Unique: true}}},
+			Options{},
+		).Model
+		if err := model.Register(app); err == nil {
+			t.Error("expected non deterministic index error")
+		}
+		model = New(
+			"User",
+			Fields{"ssn": EncryptedField{
+				Base:          CharField{Index: true},
+				Deterministic: true,
+			}},
+			Options{},
+		).Model
+		if err := model.Register(app); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("KeyRotation", func(t *testing.T) {
+		val, err := field.DriverValue("secret", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		EncryptionKeys = StaticKeys{
+			Current: "k2",
+			Keys: map[string][]byte{
+				"k1": []byte("0123456789abcdef"),
+				"k2": []byte("fedcba9876543210"),
+			},
+		}
+		defer func() { EncryptionKeys = keys }()
+		if result, err := scan(field, val); err != nil || result != "secret" {
+			t.Errorf("expected secret, got %#v (%v)", result, err)
+		}
+		val, err = field.DriverValue("secret", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(val.(string), "k2$") {
+			t.Errorf("expected value encrypted with k2, got %s", val)
+		}
+	})
+
+	t.Run("ScanUnknownKey", func(t *testing.T) {
+		if _, err := scan(field, "k0$AAAA"); err == nil {
+			t.Error("expected unknown key error")
+		}
+	})
+
+	t.Run("ScanNull", func(t *testing.T) {
+		if result, err := scan(field, nil); err != nil || result != nil {
+			t.Errorf("expected nil, got %#v (%v)", result, err)
+		}
+	})
+
+	t.Run("SetValue", func(t *testing.T) {
+		model := New(
+			"User",
+			Fields{"ssn": field},
+			Options{Table: "users_user"},
+		).Model
+		instance := newInstance(model, Values{})
+		if err := instance.Set("ssn", "secret"); err != nil {
+			t.Fatal(err)
+		}
+		if result := instance.Get("ssn"); result != "secret" {
+			t.Errorf("expected secret, got %#v", result)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		f := EncryptedField{Base: CharField{MaxLength: 50}, Deterministic: true}
+		data, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := EncryptedField{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		base, ok := result.Base.(*CharField)
+		if !ok || base.MaxLength != 50 || !result.Deterministic {
+			t.Errorf("expected %+v, got %+v", f, result)
+		}
+	})
+}
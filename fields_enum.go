@@ -0,0 +1,249 @@
+package gomodel
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// enumLabel returns the label representing the given enum value, and false if
+// the value is not a string or an integer. Integral floats are accepted, since
+// that's how integers are loaded from JSON.
+func enumLabel(v Value) (label string, isInt bool, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), false, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true, true
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); f == math.Trunc(f) {
+			return strconv.FormatInt(int64(f), 10), true, true
+		}
+	}
+	return "", false, false
+}
+
+// EnumField implements the Field interface for a set of Go string or integer
+// values, usually declared as constants of a named type. It uses a native
+// enum type on postgres and a check constraint on other drivers.
+type EnumField struct {
+	// Values is the list of accepted values, all of them strings or integers.
+	Values []Value
+	// TypeName is the name of the postgres enum type. If blank, it will be
+	// {table}_{column} when the model is registered (see SetupEnumTypes).
+	// Fields can share a type with an explicit name, as long as the values
+	// are the same.
+	TypeName string `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Nil for no default.
+	Default Value `json:",omitempty"`
+}
+
+// enumField returns the given field as an EnumField, and false if it's not an
+// enum one.
+func enumField(field Field) (EnumField, bool) {
+	switch f := field.(type) {
+	case EnumField:
+		return f, true
+	case *EnumField:
+		if f != nil {
+			return *f, true
+		}
+	}
+	return EnumField{}, false
+}
+
+// isInt returns true if the enum values are integers.
+func (f EnumField) isInt() bool {
+	if len(f.Values) == 0 {
+		return false
+	}
+	_, isInt, _ := enumLabel(f.Values[0])
+	return isInt
+}
+
+// labels returns the labels of the enum values.
+func (f EnumField) labels() []string {
+	labels := make([]string, 0, len(f.Values))
+	for _, val := range f.Values {
+		label, _, _ := enumLabel(val)
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// choices returns the enum values as a list of choices.
+func (f EnumField) choices() []Choice {
+	choices := make([]Choice, 0, len(f.Values))
+	for _, val := range f.Values {
+		label, _, _ := enumLabel(val)
+		choices = append(choices, Choice{Value: val, Label: label})
+	}
+	return choices
+}
+
+// lookup returns the enum value matching the given one, and false if it's not
+// one of the field values.
+func (f EnumField) lookup(v Value) (Value, bool) {
+	label, isInt, ok := enumLabel(v)
+	if !ok {
+		return nil, false
+	}
+	for _, val := range f.Values {
+		l, i, _ := enumLabel(val)
+		if l == label && i == isInt {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f EnumField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f EnumField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f EnumField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f EnumField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f EnumField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f EnumField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f EnumField) HasIndex() bool {
+	return f.Index && !f.Unique
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f EnumField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface. It returns
+// a blank string on postgres if the enum type name is not set.
+func (f EnumField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		if f.TypeName != "" {
+			return fmt.Sprintf("\"%s\"", f.TypeName)
+		}
+		return ""
+	}
+	if f.isInt() {
+		return "INTEGER"
+	}
+	return "TEXT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f EnumField) DefaultValue() (Value, bool) {
+	if f.Default != nil {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f EnumField) Recipient() interface{} {
+	var val sql.NullString
+	return &val
+}
+
+// Value implements the Value method of the Field interface. It returns the
+// matching value of the Values list, keeping its Go type.
+func (f EnumField) Value(rec interface{}) Value {
+	val, ok := rec.(sql.NullString)
+	if !ok {
+		return rec
+	}
+	if !val.Valid {
+		return nil
+	}
+	for _, v := range f.Values {
+		if label, _, _ := enumLabel(v); label == val.String {
+			return v
+		}
+	}
+	return val.String
+}
+
+// DriverValue implements the DriverValue method of the Field interface. It
+// returns an error if the value is not one of the field Values.
+func (f EnumField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	val, ok := f.lookup(v)
+	if !ok {
+		return nil, fmt.Errorf("invalid enum value: %v", v)
+	}
+	label, isInt, _ := enumLabel(val)
+	if isInt && dvr != "postgres" {
+		return strconv.ParseInt(label, 10, 64)
+	}
+	return label, nil
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not one of the field Values.
+func (f EnumField) Validate(v Value) error {
+	if _, ok := f.lookup(v); v != nil && !ok {
+		return fmt.Errorf("invalid enum value: %v", v)
+	}
+	return nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f EnumField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f EnumField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f EnumField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
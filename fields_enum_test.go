@@ -0,0 +1,142 @@
+package gomodel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	testStatusActive   testStatus = "active"
+	testStatusInactive testStatus = "inactive"
+)
+
+type testLevel int
+
+// TestEnumField tests the EnumField struct methods
+func TestEnumField(t *testing.T) {
+	field := EnumField{
+		Values:   []Value{testStatusActive, testStatusInactive},
+		TypeName: "teststatus",
+	}
+	intField := EnumField{
+		Values:   []Value{testLevel(1), testLevel(2)},
+		TypeName: "testlevel",
+	}
+
+	t.Run("DataType", func(t *testing.T) {
+		if dt := field.DataType("postgres"); dt != `"teststatus"` {
+			t.Errorf("expected \"teststatus\", got %s", dt)
+		}
+		if dt := field.DataType("sqlite3"); dt != "TEXT" {
+			t.Errorf("expected TEXT, got %s", dt)
+		}
+		if dt := intField.DataType("sqlite3"); dt != "INTEGER" {
+			t.Errorf("expected INTEGER, got %s", dt)
+		}
+	})
+
+	t.Run("DataTypeName", func(t *testing.T) {
+		f := EnumField{Values: []Value{"a", "b"}}
+		if dt := f.DataType("postgres"); dt != "" {
+			t.Errorf("expected blank data type, got %s", dt)
+		}
+		f.TypeName = "letter"
+		if dt := f.DataType("postgres"); dt != `"letter"` {
+			t.Errorf("expected \"letter\", got %s", dt)
+		}
+	})
+
+	t.Run("SetupEnumTypes", func(t *testing.T) {
+		model := New(
+			"Account",
+			Fields{
+				"status": EnumField{Values: field.Values},
+				"level":  intField,
+			},
+			Options{Table: "users_account"},
+		).Model
+		model.SetupEnumTypes()
+		f := model.fields["status"].(EnumField)
+		if f.TypeName != "users_account_status" {
+			t.Errorf("expected users_account_status, got %s", f.TypeName)
+		}
+		if f := model.fields["level"].(EnumField); f.TypeName != "testlevel" {
+			t.Errorf("expected explicit testlevel, got %s", f.TypeName)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		val := field.Value(sql.NullString{String: "inactive", Valid: true})
+		if val != testStatusInactive {
+			t.Errorf("expected testStatusInactive, got %#v", val)
+		}
+		val = intField.Value(sql.NullString{String: "2", Valid: true})
+		if val != testLevel(2) {
+			t.Errorf("expected testLevel(2), got %#v", val)
+		}
+		if val := field.Value(sql.NullString{}); val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		val, err := field.DriverValue(testStatusActive, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "active" {
+			t.Errorf("expected active, got %#v", val)
+		}
+		val, err = intField.DriverValue(2, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != int64(2) {
+			t.Errorf("expected int64(2), got %#v", val)
+		}
+		val, err = intField.DriverValue(testLevel(1), "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "1" {
+			t.Errorf("expected \"1\", got %#v", val)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("deleted", "sqlite3"); err == nil {
+			t.Error("expected invalid enum value error")
+		}
+		if _, err := intField.DriverValue("1", "sqlite3"); err == nil {
+			t.Error("expected invalid enum value error")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(intField)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"Values":[1,2],"TypeName":"testlevel"}`
+		if string(data) != expected {
+			t.Fatalf("expected %s, got %s", expected, data)
+		}
+		result := EnumField{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		if dt := result.DataType("postgres"); dt != `"testlevel"` {
+			t.Errorf("expected \"testlevel\", got %s", dt)
+		}
+		val, err := result.DriverValue(testLevel(2), "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != int64(2) {
+			t.Errorf("expected int64(2), got %#v", val)
+		}
+	})
+}
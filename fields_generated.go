@@ -0,0 +1,173 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// generatedFieldMarshaler is used to serialize the base field of a
+// GeneratedField.
+type generatedFieldMarshaler struct {
+	Base       json.RawMessage `json:",omitempty"`
+	Expression Expression      `json:",omitempty"`
+	Index      bool            `json:",omitempty"`
+	Column     string          `json:",omitempty"`
+}
+
+// GeneratedField implements the Field interface for stored columns computed
+// by the database from the given Expression. The column type and values are
+// handled by the Base field. Generated values are never inserted or updated,
+// and require postgres 12 or sqlite3 3.31.0 at least.
+type GeneratedField struct {
+	// Base is the field definition of the generated values.
+	Base Field
+	// Expression is the SQL expression used to compute the column values.
+	Expression Expression `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+}
+
+// generatedField returns the given field as a GeneratedField, and false if
+// it's not a generated one.
+func generatedField(field Field) (GeneratedField, bool) {
+	switch f := field.(type) {
+	case GeneratedField:
+		return f, true
+	case *GeneratedField:
+		return *f, f != nil
+	}
+	return GeneratedField{}, false
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f GeneratedField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f GeneratedField) IsUnique() bool {
+	return false
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f GeneratedField) IsNull() bool {
+	return true
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f GeneratedField) IsAuto() bool {
+	return false
+}
+
+// IsGenerated implements the IsGenerated method of the GeneratedColumn
+// interface.
+func (f GeneratedField) IsGenerated() bool {
+	return true
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f GeneratedField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f GeneratedField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f GeneratedField) HasIndex() bool {
+	return f.Index
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f GeneratedField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f GeneratedField) DataType(dvr string) string {
+	if f.Base == nil || f.Expression == "" {
+		return ""
+	}
+	return f.Base.DataType(dvr)
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f GeneratedField) DefaultValue() (Value, bool) {
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f GeneratedField) Recipient() interface{} {
+	if f.Base == nil {
+		var val interface{}
+		return &val
+	}
+	return f.Base.Recipient()
+}
+
+// Value implements the Value method of the Field interface.
+func (f GeneratedField) Value(rec interface{}) Value {
+	if f.Base == nil {
+		return rec
+	}
+	return f.Base.Value(rec)
+}
+
+// DriverValue implements the DriverValue method of the Field interface.
+func (f GeneratedField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if f.Base == nil {
+		return nil, fmt.Errorf("generated field without base field")
+	}
+	return f.Base.DriverValue(v, dvr)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f GeneratedField) DisplayValue(val Value) string {
+	if f.Base == nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return f.Base.DisplayValue(val)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f GeneratedField) MarshalJSON() ([]byte, error) {
+	result := generatedFieldMarshaler{
+		Expression: f.Expression,
+		Index:      f.Index,
+		Column:     f.Column,
+	}
+	if f.Base != nil {
+		base, err := marshalField(f.Base)
+		if err != nil {
+			return nil, err
+		}
+		result.Base = base
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (f *GeneratedField) UnmarshalJSON(data []byte) error {
+	r := generatedFieldMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	if len(r.Base) > 0 {
+		base, err := unmarshalField(r.Base)
+		if err != nil {
+			return err
+		}
+		f.Base = base
+	}
+	f.Expression = r.Expression
+	f.Index = r.Index
+	f.Column = r.Column
+	return nil
+}
@@ -0,0 +1,69 @@
+package gomodel
+
+import (
+	"testing"
+)
+
+// TestGeneratedField tests the GeneratedField struct methods
+func TestGeneratedField(t *testing.T) {
+	field := GeneratedField{
+		Base:       IntegerField{},
+		Expression: `"price" * "quantity"`,
+	}
+
+	t.Run("IsAuto", func(t *testing.T) {
+		if field.IsAuto() {
+			t.Error("expected false, got true")
+		}
+	})
+
+	t.Run("IsGenerated", func(t *testing.T) {
+		if !isGenerated(field) || !isGenerated(&field) {
+			t.Error("expected generated field")
+		}
+		if isGenerated(IntegerField{Auto: true}) {
+			t.Error("expected auto field not to be generated")
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		if dt := field.DataType("postgres"); dt != "INTEGER" {
+			t.Errorf("expected INTEGER, got %s", dt)
+		}
+	})
+
+	t.Run("DataTypeNoExpression", func(t *testing.T) {
+		field := GeneratedField{Base: IntegerField{}}
+		if dt := field.DataType("postgres"); dt != "" {
+			t.Errorf("expected blank type, got %s", dt)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		if _, ok := field.Recipient().(*int32); !ok {
+			t.Errorf("expected *int32, got %T", field.Recipient())
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		if val := field.Value(int32(42)); val != int32(42) {
+			t.Errorf("expected 42, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueNoBase", func(t *testing.T) {
+		field := GeneratedField{Expression: "1"}
+		if _, err := field.DriverValue(1, "postgres"); err == nil {
+			t.Error("expected no base field error")
+		}
+	})
+
+	t.Run("GeneratedPointer", func(t *testing.T) {
+		if _, ok := generatedField(&field); !ok {
+			t.Error("expected pointer to be a generated field")
+		}
+		if _, ok := generatedField(IntegerField{}); ok {
+			t.Error("expected IntegerField not to be a generated field")
+		}
+	})
+}
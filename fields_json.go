@@ -0,0 +1,230 @@
+package gomodel
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// NullJSON represents an encoded JSON document that may be null.
+type NullJSON struct {
+	JSON  []byte
+	Valid bool // Valid is true if JSON is not NULL
+}
+
+// Scan implements the Scanner interface. Strings and byte slices are
+// considered encoded JSON documents, any other value will be encoded.
+func (n *NullJSON) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		n.JSON, n.Valid = nil, false
+		return nil
+	case []byte:
+		data = cloneBytes(v)
+	case string:
+		data = []byte(v)
+	case json.RawMessage:
+		data = cloneBytes(v)
+	case NullJSON:
+		n.JSON, n.Valid = cloneBytes(v.JSON), v.Valid
+		return nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		data = encoded
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid json document: %s", data)
+	}
+	n.JSON, n.Valid = data, true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (n NullJSON) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return string(n.JSON), nil
+}
+
+// jsonLookuper is the interface implemented by fields holding JSON documents,
+// enabling key path lookups (e.g. "meta__plan__tier") and the has_key and
+// contains operators on conditioners.
+type jsonLookuper interface {
+	jsonLookups() bool
+}
+
+// JSONField implements the Field interface for JSON documents.
+type JSONField struct {
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Nil for no default.
+	Default Value `json:",omitempty"`
+	// Type is an optional value of the Go type that documents are decoded
+	// into (e.g. a struct). If nil, objects are decoded as
+	// map[string]interface{} and arrays as []interface{}.
+	Type interface{} `json:"-"`
+	// UseNumber is true if numbers must be decoded as json.Number instead of
+	// float64, keeping the exact value of large integers.
+	UseNumber bool `json:",omitempty"`
+}
+
+// decode decodes the given JSON document into the value pointed by v.
+func (f JSONField) decode(data []byte, v interface{}) error {
+	if !f.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// jsonLookups implements the jsonLookuper interface.
+func (f JSONField) jsonLookups() bool {
+	return true
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f JSONField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f JSONField) IsUnique() bool {
+	return false
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f JSONField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f JSONField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f JSONField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f JSONField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f JSONField) HasIndex() bool {
+	return false
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f JSONField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f JSONField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "JSONB"
+	}
+	return "TEXT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f JSONField) DefaultValue() (Value, bool) {
+	if f.Default != nil {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f JSONField) Recipient() interface{} {
+	var val NullJSON
+	return &val
+}
+
+// Value implements the Value method of the Field interface. The document is
+// decoded into a value of the field Type if defined.
+func (f JSONField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullJSON); ok {
+		if !val.Valid {
+			return nil
+		}
+		if f.Type != nil {
+			ptr := reflect.New(reflect.TypeOf(f.Type))
+			if err := f.decode(val.JSON, ptr.Interface()); err == nil {
+				return ptr.Elem().Interface()
+			}
+			return rec
+		}
+		var doc interface{}
+		if err := f.decode(val.JSON, &doc); err == nil {
+			return doc
+		}
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// returned value is the encoded JSON document. Strings and byte slices are
+// considered already encoded documents.
+func (f JSONField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	if v == nil {
+		return nil, nil
+	}
+	doc := NullJSON{}
+	if err := doc.Scan(v); err != nil {
+		return nil, err
+	}
+	return string(doc.JSON), nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f JSONField) DisplayValue(val Value) string {
+	if doc, ok := val.(NullJSON); ok {
+		if !doc.Valid {
+			return fmt.Sprintf("%v", nil)
+		}
+		return string(doc.JSON)
+	}
+	if data, err := json.Marshal(val); err == nil {
+		return string(data)
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f JSONField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f JSONField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
@@ -0,0 +1,170 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestJSONField tests the JSONField struct methods
+func TestJSONField(t *testing.T) {
+	field := JSONField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "JSONB" {
+			t.Errorf("expected JSONB, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "TEXT" {
+			t.Errorf("expected TEXT, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		field.Default = map[string]interface{}{}
+		if _, ok := field.DefaultValue(); !ok {
+			t.Error("expected default value")
+		}
+		field.Default = nil
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullJSON); !ok {
+			t.Errorf("expected *gomodel.NullJSON, got %T", recipient)
+		}
+	})
+
+	t.Run("ScanNull", func(t *testing.T) {
+		doc := NullJSON{JSON: []byte("{}"), Valid: true}
+		if err := doc.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		if doc.Valid || doc.JSON != nil {
+			t.Errorf("expected null document, got %s", doc.JSON)
+		}
+	})
+
+	t.Run("ScanEncoded", func(t *testing.T) {
+		doc := NullJSON{}
+		if err := doc.Scan(`{"plan": "pro"}`); err != nil {
+			t.Fatal(err)
+		}
+		if !doc.Valid || string(doc.JSON) != `{"plan": "pro"}` {
+			t.Errorf("expected document, got %s", doc.JSON)
+		}
+	})
+
+	t.Run("ScanInvalid", func(t *testing.T) {
+		doc := NullJSON{}
+		if err := doc.Scan([]byte("{plan")); err == nil {
+			t.Error("expected invalid document error")
+		}
+	})
+
+	t.Run("ScanValue", func(t *testing.T) {
+		doc := NullJSON{}
+		if err := doc.Scan([]int{1, 2}); err != nil {
+			t.Fatal(err)
+		}
+		if string(doc.JSON) != "[1,2]" {
+			t.Errorf("expected [1,2], got %s", doc.JSON)
+		}
+	})
+
+	t.Run("SetValues", func(t *testing.T) {
+		values := Values{}
+		meta := map[string]interface{}{"plan": "pro"}
+		if err := values.Set("meta", meta, field); err != nil {
+			t.Fatal(err)
+		}
+		if value := field.Value(values["meta"]); !reflect.DeepEqual(value, meta) {
+			t.Errorf("expected %v, got %v", meta, value)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(NullJSON{}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("ValueSlice", func(t *testing.T) {
+		value := field.Value(NullJSON{JSON: []byte(`["a"]`), Valid: true})
+		if !reflect.DeepEqual(value, []interface{}{"a"}) {
+			t.Errorf("expected [a], got %v", value)
+		}
+	})
+
+	t.Run("ValueType", func(t *testing.T) {
+		type plan struct {
+			Tier  string
+			Seats int
+		}
+		field := JSONField{Type: plan{}}
+		doc := NullJSON{JSON: []byte(`{"Tier":"pro","Seats":3}`), Valid: true}
+		value := field.Value(doc)
+		if p, ok := value.(plan); !ok || p.Tier != "pro" || p.Seats != 3 {
+			t.Errorf("expected plan{pro 3}, got %v", value)
+		}
+	})
+
+	t.Run("ValueUseNumber", func(t *testing.T) {
+		field := JSONField{UseNumber: true}
+		doc := NullJSON{JSON: []byte(`{"id": 9007199254740993}`), Valid: true}
+		value, _ := field.Value(doc).(map[string]interface{})
+		if n, ok := value["id"].(json.Number); !ok || n != "9007199254740993" {
+			t.Errorf("expected exact json.Number, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue(map[string]int{"seats": 3}, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != `{"seats":3}` {
+			t.Errorf(`expected {"seats":3}, got %v`, value)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		value, err := field.DriverValue(NullJSON{}, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("{plan", "sqlite3"); err == nil {
+			t.Error("expected invalid document error")
+		}
+	})
+
+	t.Run("DisplayValue", func(t *testing.T) {
+		doc := NullJSON{JSON: []byte(`{"plan":"pro"}`), Valid: true}
+		if value := field.DisplayValue(doc); value != `{"plan":"pro"}` {
+			t.Errorf(`expected {"plan":"pro"}, got %s`, value)
+		}
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		fields := Fields{"meta": JSONField{Null: true, Type: struct{}{}}}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"meta":{"JSONField":{"Null":true}}}`
+		if string(data) != expected {
+			t.Errorf("expected %s, got %s", expected, data)
+		}
+	})
+}
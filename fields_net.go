@@ -0,0 +1,538 @@
+package gomodel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// stringValue returns the string held by the given value, or false if the
+// value is null.
+func stringValue(v Value) (string, bool, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return "", false, err
+		}
+		v = val
+	}
+	switch val := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return val, true, nil
+	case []byte:
+		return string(val), true, nil
+	}
+	return "", false, fmt.Errorf("invalid string value: %T", v)
+}
+
+// GenericIPAddressField implements the Field interface for IPv4 and IPv6
+// addresses. Values are represented by their string form.
+type GenericIPAddressField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Protocol restricts the accepted addresses to "IPv4" or "IPv6". Both are
+	// accepted if blank.
+	Protocol string `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default string `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f GenericIPAddressField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f GenericIPAddressField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f GenericIPAddressField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f GenericIPAddressField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f GenericIPAddressField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f GenericIPAddressField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f GenericIPAddressField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f GenericIPAddressField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f GenericIPAddressField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "INET"
+	}
+	return "VARCHAR(39)"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f GenericIPAddressField) DefaultValue() (Value, bool) {
+	if f.Default != "" {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f GenericIPAddressField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullString
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f GenericIPAddressField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullString); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.String
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value can be a net.IP or an address string, and is returned normalized.
+func (f GenericIPAddressField) DriverValue(
+	v Value,
+	dvr string,
+) (interface{}, error) {
+	return f.address(v)
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not a valid address for the field Protocol.
+func (f GenericIPAddressField) Validate(v Value) error {
+	_, err := f.address(v)
+	return err
+}
+
+// address returns the normalized address string of the given value, or nil
+// if it's a nil value.
+func (f GenericIPAddressField) address(v Value) (interface{}, error) {
+	ip, ok := v.(net.IP)
+	if !ok {
+		s, ok, err := stringValue(v)
+		if err != nil || !ok {
+			return nil, err
+		}
+		if ip = net.ParseIP(s); ip == nil {
+			return nil, fmt.Errorf("invalid ip address: %s", s)
+		}
+	}
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return nil, fmt.Errorf("invalid ip address: %v", ip)
+	}
+	isIPv4 := ip.To4() != nil
+	if strings.EqualFold(f.Protocol, "IPv4") && !isIPv4 {
+		return nil, fmt.Errorf("invalid IPv4 address: %s", ip)
+	} else if strings.EqualFold(f.Protocol, "IPv6") && isIPv4 {
+		return nil, fmt.Errorf("invalid IPv6 address: %s", ip)
+	}
+	return ip.String(), nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f GenericIPAddressField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f GenericIPAddressField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f GenericIPAddressField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// EmailField implements the Field interface for email addresses.
+type EmailField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Empty strings are only
+	// accepted on blank fields.
+	Blank bool `json:",omitempty"`
+	// MaxLength is the max length accepted for field values. Defaults to 254.
+	MaxLength int `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default string `json:",omitempty"`
+}
+
+// maxLength returns the field MaxLength or the default one.
+func (f EmailField) maxLength() int {
+	if f.MaxLength > 0 {
+		return f.MaxLength
+	}
+	return 254
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f EmailField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f EmailField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f EmailField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f EmailField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f EmailField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f EmailField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f EmailField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f EmailField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f EmailField) DataType(dvr string) string {
+	return fmt.Sprintf("VARCHAR(%d)", f.maxLength())
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f EmailField) DefaultValue() (Value, bool) {
+	if f.Default != "" {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f EmailField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullString
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f EmailField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullString); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.String
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface.
+func (f EmailField) DriverValue(v Value, dvr string) (interface{}, error) {
+	s, ok, err := stringValue(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not a valid email address.
+func (f EmailField) Validate(v Value) error {
+	s, ok, err := stringValue(v)
+	if err != nil || !ok {
+		return err
+	}
+	if s == "" && f.Blank {
+		return nil
+	}
+	if len(s) > f.maxLength() {
+		return fmt.Errorf("email address too long: %s", s)
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s || addr.Name != "" {
+		return fmt.Errorf("invalid email address: %s", s)
+	}
+	domain := s[strings.LastIndex(s, "@")+1:]
+	if !strings.Contains(domain, ".") && domain != "localhost" {
+		return fmt.Errorf("invalid email address: %s", s)
+	}
+	return nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f EmailField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// FieldMaxLength implements the FieldMaxLength method of the MaxLengthField
+// interface.
+func (f EmailField) FieldMaxLength() int {
+	return f.MaxLength
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f EmailField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f EmailField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// URLField implements the Field interface for URLs.
+type URLField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Empty strings are only
+	// accepted on blank fields.
+	Blank bool `json:",omitempty"`
+	// MaxLength is the max length accepted for field values. Defaults to 200.
+	MaxLength int `json:",omitempty"`
+	// Schemes is the list of accepted URL schemes. Defaults to http, https,
+	// ftp and ftps.
+	Schemes []string `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default string `json:",omitempty"`
+}
+
+// maxLength returns the field MaxLength or the default one.
+func (f URLField) maxLength() int {
+	if f.MaxLength > 0 {
+		return f.MaxLength
+	}
+	return 200
+}
+
+// schemes returns the field Schemes or the default ones.
+func (f URLField) schemes() []string {
+	if len(f.Schemes) > 0 {
+		return f.Schemes
+	}
+	return []string{"http", "https", "ftp", "ftps"}
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f URLField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f URLField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f URLField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f URLField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f URLField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f URLField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f URLField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f URLField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f URLField) DataType(dvr string) string {
+	return fmt.Sprintf("VARCHAR(%d)", f.maxLength())
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f URLField) DefaultValue() (Value, bool) {
+	if f.Default != "" {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f URLField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullString
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f URLField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullString); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.String
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value can be a *url.URL or a string.
+func (f URLField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if u, ok := v.(*url.URL); ok {
+		v = u.String()
+	}
+	s, ok, err := stringValue(v)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not an absolute url with one of the allowed schemes.
+func (f URLField) Validate(v Value) error {
+	if u, ok := v.(*url.URL); ok {
+		v = u.String()
+	}
+	s, ok, err := stringValue(v)
+	if err != nil || !ok {
+		return err
+	}
+	if s == "" && f.Blank {
+		return nil
+	}
+	if len(s) > f.maxLength() {
+		return fmt.Errorf("url too long: %s", s)
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid url: %s", s)
+	}
+	for _, scheme := range f.schemes() {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid url scheme: %s", u.Scheme)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f URLField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// FieldMaxLength implements the FieldMaxLength method of the MaxLengthField
+// interface.
+func (f URLField) FieldMaxLength() int {
+	return f.MaxLength
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f URLField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f URLField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
@@ -0,0 +1,213 @@
+package gomodel
+
+import (
+	"database/sql"
+	"net"
+	"net/url"
+	"testing"
+)
+
+// TestGenericIPAddressField tests the GenericIPAddressField struct methods
+func TestGenericIPAddressField(t *testing.T) {
+	field := GenericIPAddressField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "INET" {
+			t.Errorf("expected INET, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "VARCHAR(39)" {
+			t.Errorf("expected VARCHAR(39), got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		rec := sql.NullString{String: "127.0.0.1", Valid: true}
+		if val := field.Value(rec); val != "127.0.0.1" {
+			t.Errorf("expected 127.0.0.1, got %v", val)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		tests := map[string]string{
+			"192.168.0.1":          "192.168.0.1",
+			"2001:0DB8:0000::0001": "2001:db8::1",
+			"::ffff:192.168.0.1":   "192.168.0.1",
+		}
+		for v, expected := range tests {
+			val, err := field.DriverValue(v, "postgres")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if val != expected {
+				t.Errorf("expected %s, got %v", expected, val)
+			}
+		}
+	})
+
+	t.Run("DriverValueIP", func(t *testing.T) {
+		val, err := field.DriverValue(net.IPv4(10, 0, 0, 1), "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "10.0.0.1" {
+			t.Errorf("expected 10.0.0.1, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("256.0.0.1", "postgres"); err == nil {
+			t.Error("expected invalid address error")
+		}
+	})
+
+	t.Run("DriverValueProtocol", func(t *testing.T) {
+		field := GenericIPAddressField{Protocol: "IPv4"}
+		if _, err := field.DriverValue("::1", "postgres"); err == nil {
+			t.Error("expected invalid IPv4 address error")
+		}
+		field.Protocol = "IPv6"
+		if _, err := field.DriverValue("10.0.0.1", "postgres"); err == nil {
+			t.Error("expected invalid IPv6 address error")
+		}
+	})
+}
+
+// TestEmailField tests the EmailField struct methods
+func TestEmailField(t *testing.T) {
+	field := EmailField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "VARCHAR(254)" {
+			t.Errorf("expected VARCHAR(254), got %s", field.DataType("postgres"))
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		val, err := field.DriverValue("user@test.com", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "user@test.com" {
+			t.Errorf("expected user@test.com, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		val, err := field.DriverValue(sql.NullString{}, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueLookup", func(t *testing.T) {
+		val, err := field.DriverValue("@corp", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "@corp" {
+			t.Errorf("expected @corp, got %v", val)
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		if err := field.Validate("user@test.com"); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("ValidateInvalid", func(t *testing.T) {
+		invalid := []string{
+			"", "user", "user@", "user@test", "User <user@test.com>",
+		}
+		for _, s := range invalid {
+			if err := field.Validate(s); err == nil {
+				t.Errorf("expected invalid email error for %q", s)
+			}
+		}
+	})
+
+	t.Run("ValidateBlank", func(t *testing.T) {
+		field := EmailField{Blank: true}
+		if err := field.Validate(""); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("ValidateTooLong", func(t *testing.T) {
+		field := EmailField{MaxLength: 10}
+		if err := field.Validate("user@test.com"); err == nil {
+			t.Error("expected email too long error")
+		}
+	})
+}
+
+// TestURLField tests the URLField struct methods
+func TestURLField(t *testing.T) {
+	field := URLField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "VARCHAR(200)" {
+			t.Errorf("expected VARCHAR(200), got %s", field.DataType("postgres"))
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		val, err := field.DriverValue("https://test.com/path?q=1", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "https://test.com/path?q=1" {
+			t.Errorf("expected https://test.com/path?q=1, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueURL", func(t *testing.T) {
+		u := &url.URL{Scheme: "http", Host: "test.com", Path: "/"}
+		val, err := field.DriverValue(u, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "http://test.com/" {
+			t.Errorf("expected http://test.com/, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueLookup", func(t *testing.T) {
+		val, err := field.DriverValue("test.com", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "test.com" {
+			t.Errorf("expected test.com, got %v", val)
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		u := &url.URL{Scheme: "http", Host: "test.com", Path: "/"}
+		if err := field.Validate(u); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("ValidateInvalid", func(t *testing.T) {
+		invalid := []string{"", "test.com", "/path", "mailto:user@test.com"}
+		for _, s := range invalid {
+			if err := field.Validate(s); err == nil {
+				t.Errorf("expected invalid url error for %q", s)
+			}
+		}
+	})
+
+	t.Run("ValidateSchemes", func(t *testing.T) {
+		field := URLField{Schemes: []string{"ssh"}}
+		if err := field.Validate("ssh://test.com"); err != nil {
+			t.Error(err)
+		}
+		if err := field.Validate("http://test.com"); err == nil {
+			t.Error("expected invalid scheme error")
+		}
+	})
+}
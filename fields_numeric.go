@@ -0,0 +1,958 @@
+package gomodel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// toInt64 converts the given integer value to int64, returning an error if
+// the conversion is not possible or the result is outside the [min, max] range.
+func toInt64(v Value, min int64, max int64) (int64, error) {
+	var i64 int64
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i64 = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		u64 := rv.Uint()
+		if u64 > math.MaxInt64 {
+			return 0, fmt.Errorf("value out of range: %d", u64)
+		}
+		i64 = int64(u64)
+	case reflect.String:
+		val, err := strconv.ParseInt(rv.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid integer value: %s", rv.String())
+		}
+		i64 = val
+	default:
+		return 0, fmt.Errorf("invalid integer value: %v", v)
+	}
+	if i64 < min || i64 > max {
+		return 0, fmt.Errorf("value out of range: %d", i64)
+	}
+	return i64, nil
+}
+
+// intDriverValue returns the int64 driver value for the given integer value,
+// checking it's in the [min, max] range.
+func intDriverValue(v Value, min int64, max int64) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return toInt64(v, min, max)
+}
+
+// NullInt16 represents an int16 that may be null.
+type NullInt16 struct {
+	Int16 int16
+	Valid bool // Valid is true if Int16 is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *NullInt16) Scan(value interface{}) error {
+	if value == nil {
+		n.Int16, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return setRecipient(&n.Int16, value)
+}
+
+// Value implements the driver Valuer interface.
+func (n NullInt16) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Int16), nil
+}
+
+// SmallIntegerField implements the Field interface for 16-bit integers.
+type SmallIntegerField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Auto is true if the field value will be auto incremented.
+	Auto bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Choices is a list of possible choices for the field.
+	Choices []Choice `json:",omitempty"`
+	// StrictChoices is true if the choices must be enforced by a database
+	// constraint.
+	StrictChoices bool `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default int16 `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f SmallIntegerField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f SmallIntegerField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f SmallIntegerField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f SmallIntegerField) IsAuto() bool {
+	return f.Auto
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f SmallIntegerField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f SmallIntegerField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f SmallIntegerField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f SmallIntegerField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f SmallIntegerField) DataType(dvr string) string {
+	if f.IsAuto() {
+		if dvr == "postgres" {
+			return "SMALLSERIAL"
+		}
+		return "INTEGER"
+	}
+	return "SMALLINT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f SmallIntegerField) DefaultValue() (Value, bool) {
+	if f.Default != 0 || f.DefaultZero {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f SmallIntegerField) Recipient() interface{} {
+	if f.Null {
+		var val NullInt16
+		return &val
+	}
+	var val int16
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f SmallIntegerField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullInt16); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Int16
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. An
+// error is returned if the value is out of the int16 range.
+func (f SmallIntegerField) DriverValue(
+	v Value,
+	dvr string,
+) (interface{}, error) {
+	return intDriverValue(v, math.MinInt16, math.MaxInt16)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f SmallIntegerField) DisplayValue(val Value) string {
+	for _, choice := range f.Choices {
+		if sameChoice(f.Value(val), choice.Value) {
+			return choice.Label
+		}
+	}
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f SmallIntegerField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f SmallIntegerField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesField
+// interface.
+func (f SmallIntegerField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, f.StrictChoices
+}
+
+// WithChoices implements the WithChoices method of the ChoicesField interface.
+func (f SmallIntegerField) WithChoices(choices []Choice, strict bool) Field {
+	f.Choices = choices
+	f.StrictChoices = strict
+	return f
+}
+
+// BigIntegerField implements the Field interface for 64-bit integers.
+type BigIntegerField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Auto is true if the field value will be auto incremented.
+	Auto bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Choices is a list of possible choices for the field.
+	Choices []Choice `json:",omitempty"`
+	// StrictChoices is true if the choices must be enforced by a database
+	// constraint.
+	StrictChoices bool `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default int64 `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f BigIntegerField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f BigIntegerField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f BigIntegerField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f BigIntegerField) IsAuto() bool {
+	return f.Auto
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f BigIntegerField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f BigIntegerField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f BigIntegerField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f BigIntegerField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f BigIntegerField) DataType(dvr string) string {
+	if f.IsAuto() {
+		if dvr == "postgres" {
+			return "BIGSERIAL"
+		}
+		return "INTEGER"
+	}
+	return "BIGINT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f BigIntegerField) DefaultValue() (Value, bool) {
+	if f.Default != 0 || f.DefaultZero {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f BigIntegerField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullInt64
+		return &val
+	}
+	var val int64
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f BigIntegerField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullInt64); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Int64
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. An
+// error is returned if the value is out of the int64 range.
+func (f BigIntegerField) DriverValue(
+	v Value,
+	dvr string,
+) (interface{}, error) {
+	return intDriverValue(v, math.MinInt64, math.MaxInt64)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f BigIntegerField) DisplayValue(val Value) string {
+	for _, choice := range f.Choices {
+		if sameChoice(f.Value(val), choice.Value) {
+			return choice.Label
+		}
+	}
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f BigIntegerField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f BigIntegerField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesField
+// interface.
+func (f BigIntegerField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, f.StrictChoices
+}
+
+// WithChoices implements the WithChoices method of the ChoicesField interface.
+func (f BigIntegerField) WithChoices(choices []Choice, strict bool) Field {
+	f.Choices = choices
+	f.StrictChoices = strict
+	return f
+}
+
+// PositiveIntegerField implements the Field interface for integers from 0 to
+// 2147483647.
+type PositiveIntegerField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Choices is a list of possible choices for the field.
+	Choices []Choice `json:",omitempty"`
+	// StrictChoices is true if the choices must be enforced by a database
+	// constraint.
+	StrictChoices bool `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default int32 `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f PositiveIntegerField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f PositiveIntegerField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f PositiveIntegerField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f PositiveIntegerField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f PositiveIntegerField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f PositiveIntegerField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f PositiveIntegerField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f PositiveIntegerField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f PositiveIntegerField) DataType(dvr string) string {
+	return "INTEGER"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f PositiveIntegerField) DefaultValue() (Value, bool) {
+	if f.Default != 0 || f.DefaultZero {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f PositiveIntegerField) Recipient() interface{} {
+	if f.Null {
+		var val NullInt32
+		return &val
+	}
+	var val int32
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f PositiveIntegerField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullInt32); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Int32
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. An
+// error is returned if the value is negative or out of the int32 range.
+func (f PositiveIntegerField) DriverValue(
+	v Value,
+	dvr string,
+) (interface{}, error) {
+	return intDriverValue(v, 0, math.MaxInt32)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f PositiveIntegerField) DisplayValue(val Value) string {
+	for _, choice := range f.Choices {
+		if sameChoice(f.Value(val), choice.Value) {
+			return choice.Label
+		}
+	}
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f PositiveIntegerField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f PositiveIntegerField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesField
+// interface.
+func (f PositiveIntegerField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, f.StrictChoices
+}
+
+// WithChoices implements the WithChoices method of the ChoicesField interface.
+func (f PositiveIntegerField) WithChoices(choices []Choice, strict bool) Field {
+	f.Choices = choices
+	f.StrictChoices = strict
+	return f
+}
+
+// FloatField implements the Field interface for double precision floating
+// point numbers.
+type FloatField struct {
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default float64 `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f FloatField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f FloatField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f FloatField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f FloatField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f FloatField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f FloatField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f FloatField) HasIndex() bool {
+	return f.Index && !f.Unique
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f FloatField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f FloatField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "DOUBLE PRECISION"
+	}
+	return "REAL"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f FloatField) DefaultValue() (Value, bool) {
+	if f.Default != 0 || f.DefaultZero {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f FloatField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullFloat64
+		return &val
+	}
+	var val float64
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f FloatField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullFloat64); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Float64
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface.
+func (f FloatField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	if v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.String:
+		f64, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float value: %s", rv.String())
+		}
+		return f64, nil
+	}
+	return nil, fmt.Errorf("invalid float value: %v", v)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f FloatField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f FloatField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f FloatField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
+// NullDecimal represents a decimal string that may be null.
+type NullDecimal struct {
+	Decimal string
+	Valid   bool // Valid is true if Decimal is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *NullDecimal) Scan(value interface{}) error {
+	if value == nil {
+		n.Decimal, n.Valid = "", false
+		return nil
+	}
+	n.Valid = true
+	if f64, ok := value.(float64); ok {
+		n.Decimal = strconv.FormatFloat(f64, 'f', -1, 64)
+		return nil
+	}
+	return setRecipient(&n.Decimal, value)
+}
+
+// Value implements the driver Valuer interface.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal, nil
+}
+
+// DecimalField implements the Field interface for fixed-precision decimal
+// numbers. Values are represented by strings to avoid any loss of precision,
+// and formatted with exactly DecimalPlaces decimals (or the fewest decimals
+// representing them if MaxDigits is zero). On sqlite, where decimal columns
+// are stored as floating point numbers, the values are stored as TEXT: since
+// they're normalised, equality lookups work, but comparison lookups (>, >=, <
+// and <=) and ordering are lexicographic and shouldn't be used.
+type DecimalField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// MaxDigits is the maximum number of digits allowed, including the
+	// decimal places.
+	MaxDigits int `json:",omitempty"`
+	// DecimalPlaces is the number of decimal places stored.
+	DecimalPlaces int `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Choices is a list of possible choices for the field.
+	Choices []Choice `json:",omitempty"`
+	// Default is the default value for the field. Blank for no default.
+	Default string `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// decimal returns the normalised string representation of the given value
+// with the field decimal places. An error is returned if the value is not a
+// valid number or it cannot be represented without loss of precision.
+func (f DecimalField) decimal(v Value) (string, error) {
+	var s string
+	switch val := v.(type) {
+	case string:
+		s = strings.TrimSpace(val)
+	case []byte:
+		s = strings.TrimSpace(string(val))
+	case float32:
+		s = strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case float64:
+		s = strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+			reflect.Uint32, reflect.Uint64:
+			s = asString(v)
+		default:
+			return "", fmt.Errorf("invalid decimal value: %v", v)
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", fmt.Errorf("invalid decimal value: %s", s)
+	}
+	if f.MaxDigits == 0 {
+		// Unbounded values are normalised to the fewest decimal places that
+		// represent them exactly, and at least DecimalPlaces.
+		places, exact := 0, new(big.Rat).Set(r)
+		ten := big.NewRat(10, 1)
+		for !exact.IsInt() {
+			exact.Mul(exact, ten)
+			places++
+		}
+		if places < f.DecimalPlaces {
+			places = f.DecimalPlaces
+		}
+		return r.FloatString(places), nil
+	}
+	dec := r.FloatString(f.DecimalPlaces)
+	if rounded, _ := new(big.Rat).SetString(dec); rounded.Cmp(r) != 0 {
+		return "", fmt.Errorf(
+			"%s: more than %d decimal places", s, f.DecimalPlaces,
+		)
+	}
+	intPart := strings.SplitN(strings.TrimPrefix(dec, "-"), ".", 2)[0]
+	intPart = strings.TrimLeft(intPart, "0")
+	if len(intPart) > f.MaxDigits-f.DecimalPlaces {
+		return "", fmt.Errorf("%s: more than %d digits", s, f.MaxDigits)
+	}
+	return dec, nil
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f DecimalField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f DecimalField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f DecimalField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f DecimalField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f DecimalField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f DecimalField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f DecimalField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f DecimalField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f DecimalField) DataType(dvr string) string {
+	if dvr == "sqlite3" {
+		return "TEXT"
+	}
+	if f.MaxDigits == 0 {
+		return "DECIMAL"
+	}
+	return fmt.Sprintf("DECIMAL(%d, %d)", f.MaxDigits, f.DecimalPlaces)
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f DecimalField) DefaultValue() (Value, bool) {
+	if f.Default != "" {
+		return f.Value(f.Default), true
+	} else if f.DefaultZero {
+		return f.Value("0"), true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f DecimalField) Recipient() interface{} {
+	if f.Null {
+		var val NullDecimal
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f DecimalField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullDecimal); ok {
+		if !val.Valid {
+			return nil
+		}
+		rec = val.Decimal
+	}
+	if s, ok := rec.(string); ok {
+		if dec, err := f.decimal(s); err == nil {
+			return dec
+		}
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. An
+// error is returned if the value exceeds MaxDigits or DecimalPlaces.
+func (f DecimalField) DriverValue(v Value, dvr string) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return f.decimal(v)
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not a valid number or it exceeds MaxDigits or DecimalPlaces.
+func (f DecimalField) Validate(v Value) error {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return err
+		}
+		v = val
+	}
+	if v == nil {
+		return nil
+	}
+	_, err := f.decimal(v)
+	return err
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f DecimalField) DisplayValue(val Value) string {
+	val = f.Value(val)
+	for _, choice := range f.Choices {
+		if sameChoice(val, choice.Value) {
+			return choice.Label
+		}
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// FieldChoices implements the FieldChoices method of the ChoicesLister
+// interface. The choices are never enforced by a database constraint.
+func (f DecimalField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, false
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f DecimalField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f DecimalField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
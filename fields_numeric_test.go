@@ -0,0 +1,500 @@
+package gomodel
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// TestSmallIntegerField tests the SmallIntegerField struct methods
+func TestSmallIntegerField(t *testing.T) {
+	field := SmallIntegerField{}
+
+	t.Run("IsAuto", func(t *testing.T) {
+		field.Auto = true
+		if !field.IsAuto() {
+			t.Error("expected true, got false")
+		}
+	})
+
+	t.Run("HasIndex", func(t *testing.T) {
+		field.Index = true
+		field.PrimaryKey = true
+		if field.HasIndex() {
+			t.Error("expected false, got true")
+		}
+		field.PrimaryKey = false
+		if !field.HasIndex() {
+			t.Error("expected true, got false")
+		}
+	})
+
+	t.Run("DataTypeAuto", func(t *testing.T) {
+		if field.DataType("postgres") != "SMALLSERIAL" {
+			t.Errorf("expected SMALLSERIAL, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "INTEGER" {
+			t.Errorf("expected INTEGER, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		field.Auto = false
+		if field.DataType("postgres") != "SMALLINT" {
+			t.Errorf("expected SMALLINT, got %s", field.DataType("postgres"))
+		}
+	})
+
+	t.Run("DefaultZero", func(t *testing.T) {
+		field.DefaultZero = true
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.(int16); !ok || val != 0 {
+			t.Errorf("expected 0, got %d", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*int16); !ok {
+			t.Errorf("expected *int16, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullInt16); !ok {
+			t.Errorf("expected *gomodel.NullInt16, got %T", recipient)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(NullInt16{Valid: false}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("ValueNotNull", func(t *testing.T) {
+		value := field.Value(NullInt16{Int16: 42, Valid: true})
+		if v, ok := value.(int16); !ok || v != 42 {
+			t.Errorf("expected 42, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue(int16(42), "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(int64); !ok || v != 42 {
+			t.Errorf("expected 42, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		value, err := field.DriverValue(NullInt16{}, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueOutOfRange", func(t *testing.T) {
+		if _, err := field.DriverValue(40000, "sqlite3"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue(true, "sqlite3"); err == nil {
+			t.Error("expected invalid value error")
+		}
+	})
+
+	t.Run("DisplayValueChoice", func(t *testing.T) {
+		field.Choices = []Choice{{int16(1), "One"}}
+		if value := field.DisplayValue(int16(1)); value != "One" {
+			t.Errorf("expected One, got %s", value)
+		}
+	})
+
+	t.Run("DisplayValueChoiceInt", func(t *testing.T) {
+		field.Choices = []Choice{{1, "One"}}
+		if value := field.DisplayValue(int16(1)); value != "One" {
+			t.Errorf("expected One, got %s", value)
+		}
+	})
+
+	t.Run("WithChoices", func(t *testing.T) {
+		choices := []Choice{{1, "One"}, {2, "Two"}}
+		f := field.WithChoices(choices, true).(ChoicesField)
+		got, strict := f.FieldChoices()
+		if !strict {
+			t.Error("expected strict choices")
+		}
+		if len(got) != 2 {
+			t.Errorf("expected 2 choices, got %d", len(got))
+		}
+	})
+}
+
+// TestBigIntegerField tests the BigIntegerField struct methods
+func TestBigIntegerField(t *testing.T) {
+	field := BigIntegerField{}
+
+	t.Run("DataTypeAuto", func(t *testing.T) {
+		field.Auto = true
+		if field.DataType("postgres") != "BIGSERIAL" {
+			t.Errorf("expected BIGSERIAL, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "INTEGER" {
+			t.Errorf("expected INTEGER, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		field.Auto = false
+		if field.DataType("sqlite3") != "BIGINT" {
+			t.Errorf("expected BIGINT, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		field.Default = 1 << 40
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.(int64); !ok || val != 1<<40 {
+			t.Errorf("expected %d, got %d", int64(1<<40), val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*int64); !ok {
+			t.Errorf("expected *int64, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*sql.NullInt64); !ok {
+			t.Errorf("expected *sql.NullInt64, got %T", recipient)
+		}
+	})
+
+	t.Run("ValueNotNull", func(t *testing.T) {
+		value := field.Value(sql.NullInt64{Int64: 1 << 40, Valid: true})
+		if v, ok := value.(int64); !ok || v != 1<<40 {
+			t.Errorf("expected %d, got %v", int64(1<<40), value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue(uint32(1<<31), "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(int64); !ok || v != 1<<31 {
+			t.Errorf("expected %d, got %v", int64(1<<31), value)
+		}
+	})
+
+	t.Run("DriverValueOutOfRange", func(t *testing.T) {
+		if _, err := field.DriverValue(uint64(1<<63), "postgres"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+}
+
+// TestPositiveIntegerField tests the PositiveIntegerField struct methods
+func TestPositiveIntegerField(t *testing.T) {
+	field := PositiveIntegerField{}
+
+	t.Run("IsAuto", func(t *testing.T) {
+		if field.IsAuto() {
+			t.Error("expected false, got true")
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "INTEGER" {
+			t.Errorf("expected INTEGER, got %s", field.DataType("postgres"))
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullInt32); !ok {
+			t.Errorf("expected *gomodel.NullInt32, got %T", recipient)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue("42", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(int64); !ok || v != 42 {
+			t.Errorf("expected 42, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueNegative", func(t *testing.T) {
+		if _, err := field.DriverValue(int32(-1), "sqlite3"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+
+	t.Run("DriverValueOutOfRange", func(t *testing.T) {
+		if _, err := field.DriverValue(int64(1<<31), "sqlite3"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+}
+
+// TestFloatField tests the FloatField struct methods
+func TestFloatField(t *testing.T) {
+	field := FloatField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "DOUBLE PRECISION" {
+			t.Errorf(
+				"expected DOUBLE PRECISION, got %s", field.DataType("postgres"),
+			)
+		}
+		if field.DataType("sqlite3") != "REAL" {
+			t.Errorf("expected REAL, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		field.Default = 1.5
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.(float64); !ok || val != 1.5 {
+			t.Errorf("expected 1.5, got %v", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*float64); !ok {
+			t.Errorf("expected *float64, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*sql.NullFloat64); !ok {
+			t.Errorf("expected *sql.NullFloat64, got %T", recipient)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(sql.NullFloat64{}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue(float32(0.5), "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(float64); !ok || v != 0.5 {
+			t.Errorf("expected 0.5, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueInteger", func(t *testing.T) {
+		value, err := field.DriverValue(3, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := value.(float64); !ok || v != 3 {
+			t.Errorf("expected 3, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("foo", "sqlite3"); err == nil {
+			t.Error("expected invalid value error")
+		}
+	})
+}
+
+// TestDecimalField tests the DecimalField struct methods
+func TestDecimalField(t *testing.T) {
+	field := DecimalField{MaxDigits: 8, DecimalPlaces: 2}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "DECIMAL(8, 2)" {
+			t.Errorf("expected DECIMAL(8, 2), got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "TEXT" {
+			t.Errorf("expected TEXT, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		value, err := field.DriverValue("12345678.9", "sqlite3")
+		if err == nil {
+			t.Errorf("expected max digits error, got %v", value)
+		}
+		value, err = field.DriverValue("123456.7", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		recipient := field.Recipient()
+		if err := setRecipient(recipient, []byte(value.(string))); err != nil {
+			t.Fatal(err)
+		}
+		result := field.Value(*recipient.(*string))
+		if result != "123456.70" {
+			t.Errorf("expected 123456.70, got %v", result)
+		}
+	})
+
+	t.Run("DefaultZero", func(t *testing.T) {
+		field.DefaultZero = true
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val != "0.00" {
+			t.Errorf("expected 0.00, got %v", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*string); !ok {
+			t.Errorf("expected *string, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullDecimal); !ok {
+			t.Errorf("expected *gomodel.NullDecimal, got %T", recipient)
+		}
+	})
+
+	t.Run("ScanFloat", func(t *testing.T) {
+		recipient := NullDecimal{}
+		if err := recipient.Scan(float64(1234.5)); err != nil {
+			t.Fatal(err)
+		}
+		if value := field.Value(recipient); value != "1234.50" {
+			t.Errorf("expected 1234.50, got %v", value)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(NullDecimal{}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		if value := field.Value("-12.5"); value != "-12.50" {
+			t.Errorf("expected -12.50, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue("123456.78", "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != "123456.78" {
+			t.Errorf("expected 123456.78, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueFloat", func(t *testing.T) {
+		value, err := field.DriverValue(0.1, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != "0.10" {
+			t.Errorf("expected 0.10, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		value, err := field.DriverValue(NullDecimal{}, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueUnbounded", func(t *testing.T) {
+		f := DecimalField{}
+		tests := map[string]string{
+			"1.50":   "1.5",
+			" +007 ": "7",
+			"1e3":    "1000",
+			"-0.0":   "0",
+			"2.5e-2": "0.025",
+		}
+		for val, expected := range tests {
+			value, err := f.DriverValue(val, "sqlite3")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != expected {
+				t.Errorf("expected %s, got %v", expected, value)
+			}
+		}
+		f.DecimalPlaces = 2
+		if value, _ := f.DriverValue("1.5", "sqlite3"); value != "1.50" {
+			t.Errorf("expected 1.50, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueDecimalPlaces", func(t *testing.T) {
+		if _, err := field.DriverValue("1.001", "sqlite3"); err == nil {
+			t.Error("expected decimal places error")
+		}
+	})
+
+	t.Run("DriverValueMaxDigits", func(t *testing.T) {
+		if _, err := field.DriverValue(1234567, "sqlite3"); err == nil {
+			t.Error("expected max digits error")
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("foo", "sqlite3"); err == nil {
+			t.Error("expected invalid value error")
+		}
+	})
+
+	t.Run("DisplayValue", func(t *testing.T) {
+		if value := field.DisplayValue("3"); value != "3.00" {
+			t.Errorf("expected 3.00, got %s", value)
+		}
+	})
+}
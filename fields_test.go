@@ -5,6 +5,11 @@ import (
 	"testing"
 )
 
+// slugField is a custom field type used to test the field registration.
+type slugField struct {
+	CharField
+}
+
 // TestFields tests Fields marshal/unmarshall methods
 func TestFields(t *testing.T) {
 
@@ -67,4 +72,183 @@ func TestFields(t *testing.T) {
 			t.Fatalf("expected %s, got %s", expected, string(data))
 		}
 	})
+
+	t.Run("MarshalDecimal", func(t *testing.T) {
+		fields := Fields{"price": DecimalField{MaxDigits: 10, DecimalPlaces: 2}}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		field, ok := result["price"].(*DecimalField)
+		if !ok {
+			t.Fatalf("expected *DecimalField, got %T", result["price"])
+		}
+		if field.MaxDigits != 10 || field.DecimalPlaces != 2 {
+			t.Errorf("expected DECIMAL(10, 2), got %s", field.DataType(""))
+		}
+	})
+
+	t.Run("MarshalBinary", func(t *testing.T) {
+		fields := Fields{"data": BinaryField{Default: []byte("foo")}}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		field, ok := result["data"].(*BinaryField)
+		if !ok {
+			t.Fatalf("expected *BinaryField, got %T", result["data"])
+		}
+		if string(field.Default) != "foo" {
+			t.Errorf("expected foo, got %s", field.Default)
+		}
+	})
+
+	t.Run("MarshalArray", func(t *testing.T) {
+		fields := Fields{
+			"tags": ArrayField{Base: CharField{MaxLength: 20}, Null: true},
+		}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"tags":{"ArrayField":{"Base":` +
+			`{"CharField":{"MaxLength":20}},"Null":true}}}`
+		if string(data) != expected {
+			t.Fatalf("expected %s, got %s", expected, string(data))
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		field, ok := result["tags"].(*ArrayField)
+		if !ok {
+			t.Fatalf("expected *ArrayField, got %T", result["tags"])
+		}
+		if !field.Null || field.DataType("postgres") != "VARCHAR(20)[]" {
+			t.Errorf(
+				"expected null VARCHAR(20)[], got %s", field.DataType("postgres"),
+			)
+		}
+	})
+
+	t.Run("MarshalGenerated", func(t *testing.T) {
+		fields := Fields{
+			"total": GeneratedField{
+				Base: IntegerField{}, Expression: `"price" * 2`,
+			},
+			"created": DateTimeField{DBDefault: Now()},
+		}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		field, ok := result["total"].(*GeneratedField)
+		if !ok {
+			t.Fatalf("expected *GeneratedField, got %T", result["total"])
+		}
+		if field.Expression != `"price" * 2` {
+			t.Errorf("expected \"price\" * 2, got %s", field.Expression)
+		}
+		if _, ok := field.Base.(*IntegerField); !ok {
+			t.Errorf("expected *IntegerField base, got %T", field.Base)
+		}
+		if expr := dbDefault(result["created"]); expr != Now() {
+			t.Errorf("expected %s, got %s", Now(), expr)
+		}
+	})
+
+	t.Run("MarshalStringFields", func(t *testing.T) {
+		fields := Fields{
+			"ip":      GenericIPAddressField{Protocol: "IPv4"},
+			"email":   EmailField{Unique: true},
+			"website": URLField{Schemes: []string{"https"}},
+			"timeout": DurationField{DefaultZero: true},
+		}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		if f, ok := result["ip"].(*GenericIPAddressField); !ok {
+			t.Errorf("expected *GenericIPAddressField, got %T", result["ip"])
+		} else if f.Protocol != "IPv4" {
+			t.Errorf("expected IPv4 protocol, got %s", f.Protocol)
+		}
+		if f, ok := result["email"].(*EmailField); !ok || !f.Unique {
+			t.Errorf("expected unique *EmailField, got %#v", result["email"])
+		}
+		if f, ok := result["website"].(*URLField); !ok || len(f.Schemes) != 1 {
+			t.Errorf("expected *URLField with schemes, got %#v", result["website"])
+		}
+		if f, ok := result["timeout"].(*DurationField); !ok || !f.DefaultZero {
+			t.Errorf("expected *DurationField, got %#v", result["timeout"])
+		}
+	})
+
+	t.Run("MarshalUnregistered", func(t *testing.T) {
+		fields := Fields{"slug": slugField{CharField{MaxLength: 50}}}
+		if _, err := fields.MarshalJSON(); err == nil {
+			t.Error("expected unregistered field type error")
+		}
+	})
+
+	t.Run("RegisterField", func(t *testing.T) {
+		defer delete(fieldsRegistry, "SlugField")
+		if err := RegisterField("SlugField", slugField{}); err != nil {
+			t.Fatal(err)
+		}
+		fields := Fields{"slug": slugField{CharField{MaxLength: 50}}}
+		data, err := fields.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"slug":{"SlugField":{"MaxLength":50}}}`
+		if string(data) != expected {
+			t.Fatalf("expected %s, got %s", expected, string(data))
+		}
+		result := Fields{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		field, ok := result["slug"].(*slugField)
+		if !ok {
+			t.Fatalf("expected *slugField, got %T", result["slug"])
+		}
+		if field.MaxLength != 50 {
+			t.Errorf("expected MaxLength to be 50, got %d", field.MaxLength)
+		}
+	})
+
+	t.Run("RegisterDuplicateName", func(t *testing.T) {
+		if err := RegisterField("CharField", slugField{}); err == nil {
+			t.Error("expected duplicate field error")
+		}
+		if _, ok := fieldsRegistry["CharField"].(CharField); !ok {
+			t.Error("expected registered CharField to be kept")
+		}
+	})
+
+	t.Run("RegisterDuplicateType", func(t *testing.T) {
+		if err := RegisterField("StringField", &CharField{}); err == nil {
+			t.Error("expected duplicate field type error")
+		}
+		if _, found := fieldsRegistry["StringField"]; found {
+			t.Error("expected StringField not to be registered")
+		}
+	})
 }
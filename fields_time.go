@@ -4,10 +4,34 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// UseTZ enables timezone aware datetimes. When true, DateTimeField values are
+// normalised to UTC before being stored, and converted to the TimeZone
+// location when read. It determines the datetime columns storage, so it's
+// fixed once they're created: migrations record the setting, and they can't
+// be made or run if it changes.
+var UseTZ = false
+
+// TimeZone is the location DateTimeField values are converted to when UseTZ
+// is enabled. A nil location is treated as UTC.
+var TimeZone = time.UTC
+
+// tzLayout is the ISO-8601 layout used to store timezone aware datetimes.
+const tzLayout = "2006-01-02T15:04:05.000000-07:00"
+
+// timeZone returns the location set in TimeZone, or UTC if nil.
+func timeZone() *time.Location {
+	if TimeZone == nil {
+		return time.UTC
+	}
+	return TimeZone
+}
+
 // NullTime represents a time.Time that may be null.
 type NullTime struct {
 	Time  time.Time
@@ -40,16 +64,17 @@ func (d NullTime) Value() (driver.Value, error) {
 
 // timeFieldMarshaler is used to deal with time.Time zero value for JSON.
 type timeFieldMarshaler struct {
-	PrimaryKey bool     `json:",omitempty"`
-	Unique     bool     `json:",omitempty"`
-	Null       bool     `json:",omitempty"`
-	AutoNow    bool     `json:",omitempty"`
-	AutoNowAdd bool     `json:",omitempty"`
-	Blank      bool     `json:",omitempty"`
-	Index      bool     `json:",omitempty"`
-	Column     string   `json:",omitempty"`
-	Choices    []Choice `json:",omitempty"`
-	Default    string   `json:",omitempty"`
+	PrimaryKey bool       `json:",omitempty"`
+	Unique     bool       `json:",omitempty"`
+	Null       bool       `json:",omitempty"`
+	AutoNow    bool       `json:",omitempty"`
+	AutoNowAdd bool       `json:",omitempty"`
+	Blank      bool       `json:",omitempty"`
+	Index      bool       `json:",omitempty"`
+	Column     string     `json:",omitempty"`
+	DBDefault  Expression `json:",omitempty"`
+	Choices    []Choice   `json:",omitempty"`
+	Default    string     `json:",omitempty"`
 }
 
 // DateField implements the Field interface for dates.
@@ -70,6 +95,8 @@ type DateField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Choices is a list of possible choices for the field.
 	Choices []Choice `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default
@@ -170,7 +197,7 @@ func (f DateField) DriverValue(v Value, dvr string) (interface{}, error) {
 func (f DateField) DisplayValue(val Value) string {
 	val = f.Value(val)
 	for _, choice := range f.Choices {
-		if reflect.DeepEqual(val, choice.Value) {
+		if sameChoice(val, choice.Value) {
 			return choice.Label
 		}
 	}
@@ -180,6 +207,23 @@ func (f DateField) DisplayValue(val Value) string {
 	return fmt.Sprintf("%v", val)
 }
 
+// FieldChoices implements the FieldChoices method of the ChoicesLister
+// interface. The choices are never enforced by a database constraint.
+func (f DateField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, false
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f DateField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f DateField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (f DateField) MarshalJSON() ([]byte, error) {
 	result := timeFieldMarshaler{
@@ -191,6 +235,7 @@ func (f DateField) MarshalJSON() ([]byte, error) {
 		Blank:      f.Blank,
 		Index:      f.Index,
 		Column:     f.Column,
+		DBDefault:  f.DBDefault,
 		Choices:    f.Choices,
 	}
 	if !f.Default.IsZero() {
@@ -220,6 +265,7 @@ func (f *DateField) UnmarshalJSON(data []byte) error {
 	f.Blank = r.Blank
 	f.Index = r.Index
 	f.Column = r.Column
+	f.DBDefault = r.DBDefault
 	f.Choices = r.Choices
 	return nil
 }
@@ -242,6 +288,8 @@ type TimeField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Choices is a list of possible choices for the field.
 	Choices []Choice `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default
@@ -342,7 +390,7 @@ func (f TimeField) DriverValue(v Value, dvr string) (interface{}, error) {
 func (f TimeField) DisplayValue(val Value) string {
 	val = f.Value(val)
 	for _, choice := range f.Choices {
-		if reflect.DeepEqual(val, choice.Value) {
+		if sameChoice(val, choice.Value) {
 			return choice.Label
 		}
 	}
@@ -352,6 +400,23 @@ func (f TimeField) DisplayValue(val Value) string {
 	return fmt.Sprintf("%v", val)
 }
 
+// FieldChoices implements the FieldChoices method of the ChoicesLister
+// interface. The choices are never enforced by a database constraint.
+func (f TimeField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, false
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f TimeField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f TimeField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (f TimeField) MarshalJSON() ([]byte, error) {
 	result := timeFieldMarshaler{
@@ -363,6 +428,7 @@ func (f TimeField) MarshalJSON() ([]byte, error) {
 		Blank:      f.Blank,
 		Index:      f.Index,
 		Column:     f.Column,
+		DBDefault:  f.DBDefault,
 		Choices:    f.Choices,
 	}
 	if !f.Default.IsZero() {
@@ -391,6 +457,7 @@ func (f *TimeField) UnmarshalJSON(data []byte) error {
 	f.Blank = r.Blank
 	f.Index = r.Index
 	f.Column = r.Column
+	f.DBDefault = r.DBDefault
 	f.Choices = r.Choices
 	return nil
 }
@@ -413,6 +480,8 @@ type DateTimeField struct {
 	Index bool `json:",omitempty"`
 	// Column is the name of the db column. If blank, it will be the field name.
 	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
 	// Choices is a list of possible choices for the field.
 	Choices []Choice `json:",omitempty"`
 	// Default is the default value for the field. Blank for no default
@@ -465,6 +534,9 @@ func (f DateTimeField) DBColumn(name string) string {
 // DataType implements the DataType method of the Field interface.
 func (f DateTimeField) DataType(dvr string) string {
 	if dvr == "postgres" {
+		if UseTZ {
+			return "TIMESTAMP WITH TIME ZONE"
+		}
 		return "TIMESTAMP"
 	}
 	return "DATETIME"
@@ -490,7 +562,10 @@ func (f DateTimeField) Value(rec interface{}) Value {
 		if !val.Valid {
 			return nil
 		}
-		return val.Time
+		rec = val.Time
+	}
+	if t, ok := rec.(time.Time); ok && UseTZ {
+		return t.In(timeZone())
 	}
 	return rec
 }
@@ -505,6 +580,9 @@ func (f DateTimeField) DriverValue(v Value, dvr string) (interface{}, error) {
 	if v == nil {
 		return v, nil
 	} else if t, ok := v.(time.Time); ok {
+		if UseTZ {
+			return t.UTC().Format(tzLayout), nil
+		}
 		return t.Format("2006-01-02 15:04:05"), nil
 	} else if s, ok := v.(string); ok {
 		return s, nil
@@ -516,7 +594,7 @@ func (f DateTimeField) DriverValue(v Value, dvr string) (interface{}, error) {
 func (f DateTimeField) DisplayValue(val Value) string {
 	val = f.Value(val)
 	for _, choice := range f.Choices {
-		if reflect.DeepEqual(val, choice.Value) {
+		if sameChoice(val, choice.Value) {
 			return choice.Label
 		}
 	}
@@ -526,6 +604,23 @@ func (f DateTimeField) DisplayValue(val Value) string {
 	return fmt.Sprintf("%v", val)
 }
 
+// FieldChoices implements the FieldChoices method of the ChoicesLister
+// interface. The choices are never enforced by a database constraint.
+func (f DateTimeField) FieldChoices() ([]Choice, bool) {
+	return f.Choices, false
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f DateTimeField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f DateTimeField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (f DateTimeField) MarshalJSON() ([]byte, error) {
 	result := timeFieldMarshaler{
@@ -537,6 +632,7 @@ func (f DateTimeField) MarshalJSON() ([]byte, error) {
 		Blank:      f.Blank,
 		Index:      f.Index,
 		Column:     f.Column,
+		DBDefault:  f.DBDefault,
 		Choices:    f.Choices,
 	}
 	if !f.Default.IsZero() {
@@ -544,3 +640,241 @@ func (f DateTimeField) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(result)
 }
+
+// parsePostgresInterval returns the duration represented by the given postgres
+// interval output (e.g. "1 day 02:03:04.5"). Intervals with months or years
+// are not supported, as they don't represent a fixed duration.
+func parsePostgresInterval(s string) (time.Duration, error) {
+	var d time.Duration
+	tokens := strings.Fields(s)
+	if len(tokens) == 0 {
+		return 0, fmt.Errorf("invalid interval: %s", s)
+	}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if strings.Contains(token, ":") {
+			neg := strings.HasPrefix(token, "-")
+			parts := strings.Split(strings.TrimLeft(token, "+-"), ":")
+			if len(parts) != 3 {
+				return 0, fmt.Errorf("invalid interval: %s", s)
+			}
+			hours, err := strconv.ParseInt(parts[0], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid interval: %s", s)
+			}
+			minutes, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid interval: %s", s)
+			}
+			seconds, err := strconv.ParseFloat(parts[2], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid interval: %s", s)
+			}
+			secs := time.Duration(seconds * float64(time.Second))
+			clock := time.Duration(hours)*time.Hour +
+				time.Duration(minutes)*time.Minute +
+				secs.Round(time.Microsecond)
+			if neg {
+				clock = -clock
+			}
+			d += clock
+			continue
+		}
+		n, err := strconv.ParseInt(token, 10, 64)
+		if err != nil || i+1 >= len(tokens) {
+			return 0, fmt.Errorf("invalid interval: %s", s)
+		}
+		i++
+		switch strings.TrimSuffix(tokens[i], "s") {
+		case "day":
+			d += time.Duration(n) * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("unsupported interval unit: %s", tokens[i])
+		}
+	}
+	return d, nil
+}
+
+// NullDuration represents a time.Duration that may be null.
+type NullDuration struct {
+	Duration time.Duration
+	Valid    bool // Valid is true if Duration is not NULL
+}
+
+// Scan implements the Scanner interface. Integers are considered microseconds
+// and strings postgres intervals or Go duration strings (e.g. "1h30m").
+func (n *NullDuration) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		n.Duration, n.Valid = 0, false
+		return nil
+	case time.Duration:
+		n.Duration, n.Valid = v, true
+		return nil
+	case NullDuration:
+		n.Duration, n.Valid = v.Duration, v.Valid
+		return nil
+	case []byte:
+		return n.Scan(string(v))
+	case string:
+		if us, err := strconv.ParseInt(v, 10, 64); err == nil {
+			n.Duration, n.Valid = time.Duration(us)*time.Microsecond, true
+			return nil
+		} else if d, err := time.ParseDuration(v); err == nil {
+			n.Duration, n.Valid = d, true
+			return nil
+		}
+		d, err := parsePostgresInterval(v)
+		if err != nil {
+			return err
+		}
+		n.Duration, n.Valid = d, true
+		return nil
+	}
+	us, err := toInt64(value, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return fmt.Errorf("converting type %T to a duration", value)
+	}
+	n.Duration, n.Valid = time.Duration(us)*time.Microsecond, true
+	return nil
+}
+
+// Value implements the driver Valuer interface. The duration is returned in
+// microseconds.
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.Duration / time.Microsecond), nil
+}
+
+// DurationField implements the Field interface for periods of time. Values are
+// stored as intervals on postgres and as microseconds on other databases.
+type DurationField struct {
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+	// Default is the default value for the field. Zero for no default.
+	Default time.Duration `json:",omitempty"`
+	// DefaultZero is true if zero is the field default value.
+	DefaultZero bool `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f DurationField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f DurationField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f DurationField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f DurationField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f DurationField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f DurationField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f DurationField) HasIndex() bool {
+	return f.Index && !f.Unique
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f DurationField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f DurationField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "INTERVAL"
+	}
+	return "BIGINT"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f DurationField) DefaultValue() (Value, bool) {
+	if f.Default != 0 || f.DefaultZero {
+		return f.Default, true
+	}
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f DurationField) Recipient() interface{} {
+	var val NullDuration
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f DurationField) Value(rec interface{}) Value {
+	if val, ok := rec.(NullDuration); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.Duration
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value can be a time.Duration, an integer number of microseconds or a
+// duration string.
+func (f DurationField) DriverValue(v Value, dvr string) (interface{}, error) {
+	d := NullDuration{}
+	if err := d.Scan(v); err != nil {
+		return nil, err
+	}
+	if !d.Valid {
+		return nil, nil
+	}
+	us := int64(d.Duration / time.Microsecond)
+	if dvr == "postgres" {
+		return fmt.Sprintf("%d microseconds", us), nil
+	}
+	return us, nil
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f DurationField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f DurationField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f DurationField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
@@ -740,3 +740,211 @@ func TestDateTimeField(t *testing.T) {
 		}
 	})
 }
+
+// TestDateTimeFieldUseTZ tests the DateTimeField methods with UseTZ enabled
+func TestDateTimeFieldUseTZ(t *testing.T) {
+	field := DateTimeField{}
+	madrid := time.FixedZone("CEST", 2*60*60)
+	UseTZ, TimeZone = true, madrid
+	defer func() { UseTZ, TimeZone = false, time.UTC }()
+
+	t.Run("DataType", func(t *testing.T) {
+		expected := "TIMESTAMP WITH TIME ZONE"
+		if dt := field.DataType("postgres"); dt != expected {
+			t.Errorf("expected %s, got %s", expected, dt)
+		}
+		if dt := field.DataType("sqlite3"); dt != "DATETIME" {
+			t.Errorf("expected DATETIME, got %s", dt)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		recipient := time.Date(2019, 8, 24, 16, 18, 03, 5000, madrid)
+		value, err := field.DriverValue(recipient, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "2019-08-24T14:18:03.000005+00:00"
+		if v, ok := value.(string); !ok || v != expected {
+			t.Errorf("expected %s, got %s", expected, v)
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		recipient := NullTime{}
+		err := recipient.Scan("2019-08-24T14:18:03.000005+00:00")
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, ok := field.Value(recipient).(time.Time)
+		if !ok {
+			t.Fatalf("expected time.Time, got %T", value)
+		}
+		expected := time.Date(2019, 8, 24, 16, 18, 03, 5000, madrid)
+		if !value.Equal(expected) || value.Location() != madrid {
+			t.Errorf("expected %s, got %s", expected, value)
+		}
+	})
+
+	t.Run("ValueNilTimeZone", func(t *testing.T) {
+		TimeZone = nil
+		defer func() { TimeZone = madrid }()
+		recipient := time.Date(2019, 8, 24, 16, 18, 03, 0, madrid)
+		value, ok := field.Value(recipient).(time.Time)
+		if !ok || value.Location() != time.UTC {
+			t.Errorf("expected UTC time, got %s", value)
+		}
+	})
+
+	t.Run("ScanPostgresOffset", func(t *testing.T) {
+		recipient := NullTime{}
+		if err := recipient.Scan("2019-08-24 16:18:03+02"); err != nil {
+			t.Fatal(err)
+		}
+		expected := time.Date(2019, 8, 24, 14, 18, 03, 0, time.UTC)
+		if !recipient.Time.Equal(expected) {
+			t.Errorf("expected %s, got %s", expected, recipient.Time)
+		}
+	})
+
+	t.Run("DisplayValue", func(t *testing.T) {
+		recipient := time.Date(2019, 8, 24, 14, 18, 03, 0, time.UTC)
+		value := field.DisplayValue(recipient)
+		if value != "2019-08-24 16:18:03" {
+			t.Errorf("expected 2019-08-24 16:18:03, got %s", value)
+		}
+	})
+}
+
+// TestDurationField tests the DurationField struct methods
+func TestDurationField(t *testing.T) {
+	field := DurationField{}
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "INTERVAL" {
+			t.Errorf("expected INTERVAL, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "BIGINT" {
+			t.Errorf("expected BIGINT, got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("DefaultZero", func(t *testing.T) {
+		field := DurationField{DefaultZero: true}
+		val, ok := field.DefaultValue()
+		if !ok || val != time.Duration(0) {
+			t.Errorf("expected zero default value, got %v", val)
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*NullDuration); !ok {
+			t.Errorf("expected *gomodel.NullDuration, got %T", recipient)
+		}
+	})
+
+	t.Run("ScanMicroseconds", func(t *testing.T) {
+		d := NullDuration{}
+		if err := d.Scan(int64(1500000)); err != nil {
+			t.Fatal(err)
+		}
+		if !d.Valid || d.Duration != 1500*time.Millisecond {
+			t.Errorf("expected 1.5s, got %s", d.Duration)
+		}
+	})
+
+	t.Run("ScanInterval", func(t *testing.T) {
+		tests := map[string]time.Duration{
+			"00:00:00":              0,
+			"01:02:03.5":            time.Hour + 2*time.Minute + 3500*time.Millisecond,
+			"-00:00:01":             -time.Second,
+			"2 days":                48 * time.Hour,
+			"1 day -01:00:00":       23 * time.Hour,
+			"-1 days +00:00:00.001": -24*time.Hour + time.Millisecond,
+		}
+		for s, expected := range tests {
+			d := NullDuration{}
+			if err := d.Scan([]byte(s)); err != nil {
+				t.Fatal(err)
+			}
+			if d.Duration != expected {
+				t.Errorf("%s: expected %s, got %s", s, expected, d.Duration)
+			}
+		}
+	})
+
+	t.Run("ScanInvalidInterval", func(t *testing.T) {
+		d := NullDuration{}
+		if err := d.Scan("1 mon 2 days"); err == nil {
+			t.Error("expected unsupported unit error")
+		}
+		if err := d.Scan("foo"); err == nil {
+			t.Error("expected invalid interval error")
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		d := NullDuration{Duration: time.Second, Valid: true}
+		if val := field.Value(d); val != time.Second {
+			t.Errorf("expected 1s, got %v", val)
+		}
+		if val := field.Value(NullDuration{}); val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		val, err := field.DriverValue(90*time.Second, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != "90000000 microseconds" {
+			t.Errorf("expected 90000000 microseconds, got %v", val)
+		}
+		val, err = field.DriverValue("1m30s", "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != int64(90000000) {
+			t.Errorf("expected 90000000, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		val, err := field.DriverValue(nil, "postgres")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != nil {
+			t.Errorf("expected nil, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue(1.5, "postgres"); err == nil {
+			t.Error("expected invalid value error")
+		}
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		field := DurationField{Default: time.Minute}
+		data, err := json.Marshal(field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := DurationField{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Default != time.Minute {
+			t.Errorf("expected 1m0s, got %s", result.Default)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package gomodel
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// newUUID returns a random (version 4) UUID in its canonical string form.
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatUUID(u[:]), nil
+}
+
+// formatUUID returns the canonical string form of the given 16 bytes.
+func formatUUID(u []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
+
+// parseUUID returns the canonical string form of the given UUID string, that
+// can be hyphenated or not, and optionally wrapped in braces or prefixed with
+// urn:uuid:.
+func parseUUID(s string) (string, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	str = strings.TrimPrefix(str, "urn:uuid:")
+	str = strings.TrimSuffix(strings.TrimPrefix(str, "{"), "}")
+	if len(str) == 36 {
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return "", fmt.Errorf("invalid uuid: %s", s)
+		}
+		str = strings.Replace(str, "-", "", -1)
+	}
+	u, err := hex.DecodeString(str)
+	if err != nil || len(u) != 16 {
+		return "", fmt.Errorf("invalid uuid: %s", s)
+	}
+	return formatUUID(u), nil
+}
+
+// UUIDField implements the Field interface for universally unique
+// identifiers. Values are represented by their canonical string form.
+type UUIDField struct {
+	// PrimaryKey is true if the field is the model primary key.
+	PrimaryKey bool `json:",omitempty"`
+	// Unique is true if the field value must be unique.
+	Unique bool `json:",omitempty"`
+	// Null is true if the field can have null values.
+	Null bool `json:",omitempty"`
+	// AutoGenerate is true if a random UUID should be generated when a new
+	// row is inserted without a value.
+	AutoGenerate bool `json:",omitempty"`
+	// Blank is true if the field is not required. Only used for validation.
+	Blank bool `json:",omitempty"`
+	// Index is true if the field column should be indexed.
+	Index bool `json:",omitempty"`
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+	// DBDefault is an expression used as the column default by the database.
+	DBDefault Expression `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f UUIDField) IsPK() bool {
+	return f.PrimaryKey
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f UUIDField) IsUnique() bool {
+	return f.Unique
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f UUIDField) IsNull() bool {
+	return f.Null
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f UUIDField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f UUIDField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f UUIDField) IsAutoNowAdd() bool {
+	return false
+}
+
+// IsAutoGenerated implements the IsAutoGenerated method of the AutoGenerator
+// interface.
+func (f UUIDField) IsAutoGenerated() bool {
+	return f.AutoGenerate
+}
+
+// AutoValue implements the AutoValue method of the AutoGenerator interface.
+func (f UUIDField) AutoValue() (Value, error) {
+	return newUUID()
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f UUIDField) HasIndex() bool {
+	return f.Index && !(f.PrimaryKey || f.Unique)
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f UUIDField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f UUIDField) DataType(dvr string) string {
+	if dvr == "postgres" {
+		return "UUID"
+	}
+	return "CHAR(36)"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f UUIDField) DefaultValue() (Value, bool) {
+	return nil, false
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f UUIDField) Recipient() interface{} {
+	if f.Null {
+		var val sql.NullString
+		return &val
+	}
+	var val string
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f UUIDField) Value(rec interface{}) Value {
+	if val, ok := rec.(sql.NullString); ok {
+		if !val.Valid {
+			return nil
+		}
+		return val.String
+	}
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. The
+// value can be a UUID string, or a [16]byte/[]byte with the raw UUID bytes.
+func (f UUIDField) DriverValue(v Value, dvr string) (interface{}, error) {
+	return uuidValue(v)
+}
+
+// Validate implements the Validator interface. It returns an error if the
+// value is not a valid UUID.
+func (f UUIDField) Validate(v Value) error {
+	_, err := uuidValue(v)
+	return err
+}
+
+// uuidValue returns the UUID string of the given value, or nil if it's a nil
+// value.
+func uuidValue(v Value) (interface{}, error) {
+	if vlr, ok := v.(driver.Valuer); ok {
+		val, err := vlr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return parseUUID(val)
+	case [16]byte:
+		return formatUUID(val[:]), nil
+	case []byte:
+		if len(val) == 16 {
+			return formatUUID(val), nil
+		}
+		return parseUUID(string(val))
+	}
+	return nil, fmt.Errorf("invalid uuid value: %T", v)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f UUIDField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// IsBlank implements the IsBlank method of the BlankField interface.
+func (f UUIDField) IsBlank() bool {
+	return f.Blank
+}
+
+// DBDefaultExpression implements the DBDefaultExpression method of the
+// DBDefaulter interface.
+func (f UUIDField) DBDefaultExpression() Expression {
+	return f.DBDefault
+}
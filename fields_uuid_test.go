@@ -0,0 +1,123 @@
+package gomodel
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// TestUUIDField tests the UUIDField struct methods
+func TestUUIDField(t *testing.T) {
+	field := UUIDField{}
+	id := "2b1c9b3e-5d3a-4f5e-9a43-0d2a4d6f8c11"
+
+	t.Run("IsAuto", func(t *testing.T) {
+		field.AutoGenerate = true
+		if field.IsAuto() {
+			t.Error("expected false, got true")
+		}
+	})
+
+	t.Run("IsAutoGenerated", func(t *testing.T) {
+		if !field.IsAutoGenerated() {
+			t.Error("expected true, got false")
+		}
+	})
+
+	t.Run("AutoValue", func(t *testing.T) {
+		val, err := field.AutoValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, ok := val.(string)
+		if !ok || len(s) != 36 {
+			t.Fatalf("expected uuid string, got %v", val)
+		}
+		if s[14] != '4' {
+			t.Errorf("expected version 4 uuid, got %s", s)
+		}
+		if other, _ := field.AutoValue(); other == val {
+			t.Error("expected different uuids")
+		}
+	})
+
+	t.Run("DataType", func(t *testing.T) {
+		if field.DataType("postgres") != "UUID" {
+			t.Errorf("expected UUID, got %s", field.DataType("postgres"))
+		}
+		if field.DataType("sqlite3") != "CHAR(36)" {
+			t.Errorf("expected CHAR(36), got %s", field.DataType("sqlite3"))
+		}
+	})
+
+	t.Run("NoDefault", func(t *testing.T) {
+		if _, ok := field.DefaultValue(); ok {
+			t.Error("expected no default value")
+		}
+	})
+
+	t.Run("Recipient", func(t *testing.T) {
+		recipient := field.Recipient()
+		if _, ok := recipient.(*string); !ok {
+			t.Errorf("expected *string, got %T", recipient)
+		}
+	})
+
+	t.Run("NullRecipient", func(t *testing.T) {
+		field.Null = true
+		recipient := field.Recipient()
+		if _, ok := recipient.(*sql.NullString); !ok {
+			t.Errorf("expected *sql.NullString, got %T", recipient)
+		}
+	})
+
+	t.Run("ValueNull", func(t *testing.T) {
+		if value := field.Value(sql.NullString{}); value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValue", func(t *testing.T) {
+		value, err := field.DriverValue(
+			"{2B1C9B3E-5D3A-4F5E-9A43-0D2A4D6F8C11}", "postgres",
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != id {
+			t.Errorf("expected %s, got %v", id, value)
+		}
+	})
+
+	t.Run("DriverValueBytes", func(t *testing.T) {
+		raw := [16]byte{
+			0x2b, 0x1c, 0x9b, 0x3e, 0x5d, 0x3a, 0x4f, 0x5e,
+			0x9a, 0x43, 0x0d, 0x2a, 0x4d, 0x6f, 0x8c, 0x11,
+		}
+		value, err := field.DriverValue(raw, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != id {
+			t.Errorf("expected %s, got %v", id, value)
+		}
+	})
+
+	t.Run("DriverValueNull", func(t *testing.T) {
+		value, err := field.DriverValue(sql.NullString{}, "sqlite3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != nil {
+			t.Errorf("expected nil, got %v", value)
+		}
+	})
+
+	t.Run("DriverValueInvalid", func(t *testing.T) {
+		if _, err := field.DriverValue("2b1c9b3e-5d3a", "sqlite3"); err == nil {
+			t.Error("expected invalid uuid error")
+		}
+		if _, err := field.DriverValue(42, "sqlite3"); err == nil {
+			t.Error("expected invalid uuid error")
+		}
+	})
+}
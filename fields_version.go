@@ -0,0 +1,124 @@
+package gomodel
+
+import (
+	"fmt"
+	"math"
+)
+
+// VersionField implements the Field interface for the version number used for
+// optimistic locking. The version is 1 for new objects and it's incremented
+// every time an instance is saved, checking the stored row still holds the
+// version the instance was loaded with. Otherwise, the instance is stale and a
+// *StaleObjectError is returned instead of overwriting the row.
+//
+// QuerySet updates don't check nor increment the version.
+type VersionField struct {
+	// Column is the name of the db column. If blank, it will be the field name.
+	Column string `json:",omitempty"`
+}
+
+// IsPK implements the IsPK method of the Field interface.
+func (f VersionField) IsPK() bool {
+	return false
+}
+
+// IsUnique implements the IsUnique method of the Field interface.
+func (f VersionField) IsUnique() bool {
+	return false
+}
+
+// IsNull implements the IsNull method of the Field interface.
+func (f VersionField) IsNull() bool {
+	return false
+}
+
+// IsAuto implements the IsAuto method of the Field interface.
+func (f VersionField) IsAuto() bool {
+	return false
+}
+
+// IsAutoNow implements the IsAutoNow method of the Field interface.
+func (f VersionField) IsAutoNow() bool {
+	return false
+}
+
+// IsAutoNowAdd implements the IsAutoNowAdd method of the Field interface.
+func (f VersionField) IsAutoNowAdd() bool {
+	return false
+}
+
+// HasIndex implements the HasIndex method of the Field interface.
+func (f VersionField) HasIndex() bool {
+	return false
+}
+
+// DBColumn implements the DBColumn method of the Field interface.
+func (f VersionField) DBColumn(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+// DataType implements the DataType method of the Field interface.
+func (f VersionField) DataType(dvr string) string {
+	return "INTEGER"
+}
+
+// DefaultValue implements the DefaultValue method of the Field interface.
+func (f VersionField) DefaultValue() (Value, bool) {
+	return int32(1), true
+}
+
+// Recipient implements the Recipient method of the Field interface.
+func (f VersionField) Recipient() interface{} {
+	var val int32
+	return &val
+}
+
+// Value implements the Value method of the Field interface.
+func (f VersionField) Value(rec interface{}) Value {
+	return rec
+}
+
+// DriverValue implements the DriverValue method of the Field interface. An
+// error is returned if the value is out of the int32 range.
+func (f VersionField) DriverValue(v Value, dvr string) (interface{}, error) {
+	return intDriverValue(v, math.MinInt32, math.MaxInt32)
+}
+
+// DisplayValue implements the DisplayValue method of the Field interface.
+func (f VersionField) DisplayValue(val Value) string {
+	return fmt.Sprintf("%v", f.Value(val))
+}
+
+// versionField returns the name of the model VersionField, blank if none.
+func versionField(m *Model) string {
+	for name, field := range m.fields {
+		if _, ok := field.(VersionField); ok {
+			return name
+		}
+		if _, ok := field.(*VersionField); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// nextVersion returns the locking condition and the next version for the
+// given instance, or a nil condition if the model is not versioned.
+func (i Instance) nextVersion(pkVal Value) (Conditioner, Value, error) {
+	name := versionField(i.model)
+	if name == "" {
+		return nil, nil, nil
+	}
+	current, ok := i.GetIf(name)
+	if !ok || current == nil {
+		return nil, nil, fmt.Errorf("missing version value")
+	}
+	version, err := toInt64(current, math.MinInt32, math.MaxInt32-1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Q{"pk": pkVal, name: current}, int32(version + 1), nil
+}
@@ -0,0 +1,161 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestVersionField tests the VersionField struct methods
+func TestVersionField(t *testing.T) {
+	field := VersionField{}
+
+	t.Run("DefaultValue", func(t *testing.T) {
+		val, ok := field.DefaultValue()
+		if !ok {
+			t.Fatal("expected default value")
+		}
+		if val, ok := val.(int32); !ok || val != 1 {
+			t.Errorf("expected 1, got %v", val)
+		}
+	})
+
+	t.Run("DriverValueOutOfRange", func(t *testing.T) {
+		if _, err := field.DriverValue(int64(1)<<40, "sqlite3"); err == nil {
+			t.Error("expected out of range error")
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var fields Fields
+		data := []byte(`{"version": {"VersionField": {"Column": "v"}}}`)
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if fields["version"].DBColumn("version") != "v" {
+			t.Errorf("expected v column")
+		}
+	})
+}
+
+// TestOptimisticLocking tests the saving of versioned instances
+func TestOptimisticLocking(t *testing.T) {
+	// Model setup
+	model := &Model{
+		name: "Document",
+		pk:   "id",
+		fields: Fields{
+			"id":      IntegerField{Auto: true},
+			"title":   CharField{MaxLength: 100},
+			"version": VersionField{},
+		},
+		meta: Options{Container: Values{}},
+	}
+	loaded := func() *Instance {
+		instance := newInstance(model, Values{
+			"id":      int32(1),
+			"title":   "Draft",
+			"version": int32(3),
+		})
+		instance.snapshot()
+		return instance
+	}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+
+	t.Run("Create", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := newInstance(model, Values{"title": "Draft"})
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		values := mockedEngine.Args.InsertRow.Values
+		if v, ok := values["version"].(int32); !ok || v != 1 {
+			t.Errorf("expected version 1, got %v", values["version"])
+		}
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		instance := loaded()
+		if err := instance.Set("title", "Final"); err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.UpdateRows
+		if v, ok := args.Values["version"].(int32); !ok || v != 4 {
+			t.Errorf("expected version 4, got %v", args.Values["version"])
+		}
+		q, ok := args.Options.Conditioner.(Q)
+		if !ok || q["version"] != int32(3) || q["pk"] != int32(1) {
+			t.Errorf("expected pk and version condition, got %v", q)
+		}
+		if v := instance.Get("version"); v != int32(4) {
+			t.Errorf("expected instance version 4, got %v", v)
+		}
+		if instance.Changed() {
+			t.Errorf("expected no changes, got %v", instance.ChangedFields())
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := loaded()
+		if err := instance.Set("title", "Final"); err != nil {
+			t.Fatal(err)
+		}
+		err := instance.Save()
+		if _, ok := err.(*StaleObjectError); !ok {
+			t.Fatalf("expected StaleObjectError, got %T", err)
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected engine InsertRow not to be called")
+		}
+		if v := instance.Get("version"); v != int32(3) {
+			t.Errorf("expected instance version 3, got %v", v)
+		}
+	})
+
+	t.Run("CreateExplicitPk", func(t *testing.T) {
+		mockedEngine.Reset()
+		codeModel := &Model{
+			name: "Code",
+			pk:   "code",
+			fields: Fields{
+				"code":    CharField{PrimaryKey: true, MaxLength: 10},
+				"version": VersionField{},
+			},
+			meta: Options{Container: Values{}},
+		}
+		instance, err := Dispatcher{Model: codeModel}.New(Values{"code": "abc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 1 {
+			t.Fatal("expected engine InsertRow to be called")
+		}
+		values := mockedEngine.Args.InsertRow.Values
+		if values["code"] != "abc" || values["version"] != int32(1) {
+			t.Errorf("expected code and version values, got %v", values)
+		}
+	})
+
+	t.Run("MissingVersion", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := newInstance(model, Values{"id": int32(1)})
+		if err := instance.Save("title"); err == nil {
+			t.Error("expected missing version error")
+		}
+		if mockedEngine.Calls("UpdateRows") != 0 {
+			t.Error("expected engine UpdateRows not to be called")
+		}
+	})
+}
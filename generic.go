@@ -0,0 +1,231 @@
+package gomodel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GenericForeignKey references an object of any registered model by the pair
+// of fields holding the id of the model content type (see ContentType) and the
+// object pk.
+type GenericForeignKey struct {
+	// ContentTypeField is the name of the field holding the content type id.
+	// If blank, it will be content_type. A nullable IntegerField is added to
+	// the model if not defined.
+	ContentTypeField string
+	// ObjectIDField is the name of the field holding the object pk as a
+	// string. If blank, it will be object_id. A nullable CharField is added
+	// to the model if not defined.
+	ObjectIDField string
+}
+
+// fieldNames returns the names of the content type and object id fields.
+func (gfk GenericForeignKey) fieldNames() (string, string) {
+	ctField, idField := gfk.ContentTypeField, gfk.ObjectIDField
+	if ctField == "" {
+		ctField = "content_type"
+	}
+	if idField == "" {
+		idField = "object_id"
+	}
+	return ctField, idField
+}
+
+// setupGenericForeignKeys adds the missing fields of the model generic foreign
+// keys, and an index on each pair of fields.
+func (m *Model) setupGenericForeignKeys() error {
+	used := map[string]bool{}
+	for name, gfk := range m.meta.GenericForeignKeys {
+		if _, ok := m.fields[name]; ok {
+			return fmt.Errorf("generic foreign key clashes with field %s", name)
+		}
+		ctField, idField := gfk.fieldNames()
+		if used[ctField] || used[idField] || ctField == idField {
+			return fmt.Errorf("duplicate generic foreign key field %s", name)
+		}
+		used[ctField], used[idField] = true, true
+		if _, ok := m.fields[ctField]; !ok {
+			m.fields[ctField] = IntegerField{Null: true}
+		}
+		if _, ok := m.fields[idField]; !ok {
+			m.fields[idField] = CharField{MaxLength: 255, Null: true}
+		}
+		idxName := fmt.Sprintf(
+			"%s_%s_%s_gfk_idx",
+			strings.ToLower(m.app.name),
+			strings.ToLower(m.name),
+			strings.ToLower(name),
+		)
+		if !m.hasIndex(idxName) {
+			m.meta.Indexes[idxName] = []string{ctField, idField}
+		}
+	}
+	return nil
+}
+
+// genericForeignKey returns the named generic foreign key of the model or its
+// concrete parents.
+func (m Model) genericForeignKey(name string) (GenericForeignKey, bool) {
+	if gfk, ok := m.meta.GenericForeignKeys[name]; ok {
+		return gfk, true
+	}
+	if m.parent != nil {
+		return m.parent.genericForeignKey(name)
+	}
+	return GenericForeignKey{}, false
+}
+
+// SetGeneric sets the fields of the named generic foreign key to reference the
+// given object, or to nil if the object is nil. The content type of the object
+// model is created on the default database if not found. The change doesn't
+// propagate to the database unless the Save method is called.
+func (i Instance) SetGeneric(name string, object *Instance) error {
+	return i.setGeneric("default", name, object)
+}
+
+// SetGenericOn works as SetGeneric, but the content type is resolved on the
+// given target, that can be a *Transaction or a string representing a
+// database identifier. It should be the target where the instance is saved.
+func (i Instance) SetGenericOn(
+	target interface{},
+	name string,
+	object *Instance,
+) error {
+	return i.setGeneric(target, name, object)
+}
+
+// setGeneric sets the fields of the named generic foreign key to reference the
+// given object, resolving the content type on the given target.
+func (i Instance) setGeneric(
+	target interface{},
+	name string,
+	object *Instance,
+) error {
+	gfk, ok := i.model.genericForeignKey(name)
+	if !ok {
+		err := fmt.Errorf("unknown generic foreign key %s", name)
+		return &ContainerError{i.trace(err)}
+	}
+	ctField, idField := gfk.fieldNames()
+	if object == nil {
+		if err := i.Set(ctField, nil); err != nil {
+			return err
+		}
+		return i.Set(idField, nil)
+	}
+	pkVal, ok := object.GetIf("pk")
+	if !ok || pkVal == nil {
+		return &ContainerError{i.trace(fmt.Errorf("object pk not found"))}
+	}
+	id, err := contentTypeID(target, object.model)
+	if err != nil {
+		return err
+	}
+	if err := i.Set(ctField, id); err != nil {
+		return err
+	}
+	return i.Set(idField, fmt.Sprint(pkVal))
+}
+
+// GetGeneric returns the object referenced by the named generic foreign key,
+// loaded from the default database, or nil if the fields are null.
+func (i Instance) GetGeneric(name string) (*Instance, error) {
+	return i.getGeneric("default", name)
+}
+
+// GetGenericOn works as GetGeneric, but the object is loaded from the given
+// target, that can be a *Transaction or a string representing a database
+// identifier.
+func (i Instance) GetGenericOn(
+	target interface{},
+	name string,
+) (*Instance, error) {
+	return i.getGeneric(target, name)
+}
+
+// getGeneric returns the object referenced by the named generic foreign key,
+// loaded from the given target.
+func (i Instance) getGeneric(
+	target interface{},
+	name string,
+) (*Instance, error) {
+	gfk, ok := i.model.genericForeignKey(name)
+	if !ok {
+		err := fmt.Errorf("unknown generic foreign key %s", name)
+		return nil, &ContainerError{i.trace(err)}
+	}
+	ctField, idField := gfk.fieldNames()
+	id, objectID := i.Get(ctField), i.Get(idField)
+	if id == nil || objectID == nil {
+		return nil, nil
+	}
+	model, err := contentTypeModel(target, id)
+	if err != nil {
+		return nil, err
+	}
+	pkField := model.fields[model.pk]
+	recipient := pkField.Recipient()
+	if err := setRecipient(recipient, objectID); err != nil {
+		return nil, &ContainerError{i.trace(err)}
+	}
+	pkVal := reflect.Indirect(reflect.ValueOf(recipient)).Interface()
+	manager := Manager{Model: model, QuerySet: GenericQuerySet{}}
+	qs := onTarget(manager.All(), target)
+	return qs.Get(Q{"pk": pkField.Value(pkVal)})
+}
+
+// GenericRelated returns a QuerySet of the objects of the given model whose
+// named generic foreign key references the instance. The content type of the
+// instance model is created on the default database if not found.
+func (i Instance) GenericRelated(model *Model, name string) (QuerySet, error) {
+	return i.genericRelated("default", model, name)
+}
+
+// GenericRelatedOn works as GenericRelated, but the content type is resolved
+// and the objects are loaded on the given target, that can be a *Transaction
+// or a string representing a database identifier.
+func (i Instance) GenericRelatedOn(
+	target interface{},
+	model *Model,
+	name string,
+) (QuerySet, error) {
+	return i.genericRelated(target, model, name)
+}
+
+// genericRelated returns a QuerySet of the objects of the given model on the
+// given target whose named generic foreign key references the instance.
+func (i Instance) genericRelated(
+	target interface{},
+	model *Model,
+	name string,
+) (QuerySet, error) {
+	gfk, ok := model.genericForeignKey(name)
+	if !ok {
+		err := fmt.Errorf("unknown generic foreign key %s", name)
+		trace := ErrorTrace{App: model.app, Model: model, Err: err}
+		return nil, &QuerySetError{trace}
+	}
+	pkVal, ok := i.GetIf("pk")
+	if !ok || pkVal == nil {
+		return nil, &ContainerError{i.trace(fmt.Errorf("pk not found"))}
+	}
+	id, err := contentTypeID(target, i.model)
+	if err != nil {
+		return nil, err
+	}
+	ctField, idField := gfk.fieldNames()
+	manager := Manager{Model: model, QuerySet: GenericQuerySet{}}
+	qs := manager.Filter(Q{ctField: id, idField: fmt.Sprint(pkVal)})
+	return onTarget(qs, target), nil
+}
+
+// onTarget returns the given QuerySet on the given target, that can be a
+// *Transaction or a string representing a database identifier.
+func onTarget(qs QuerySet, target interface{}) QuerySet {
+	if tx, ok := target.(*Transaction); ok {
+		return qs.WithTx(tx)
+	}
+	database, _ := target.(string)
+	return qs.WithDB(database)
+}
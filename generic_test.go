@@ -0,0 +1,190 @@
+package gomodel
+
+import (
+	"testing"
+)
+
+// TestGenericForeignKeys tests the generic foreign keys and relations
+func TestGenericForeignKeys(t *testing.T) {
+	// Models setup
+	app := &Application{name: "blog", models: map[string]*Model{}}
+	post := New("Post", Fields{"title": CharField{MaxLength: 100}}, Options{})
+	if err := post.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	comment := New(
+		"Comment",
+		Fields{"text": TextField{}},
+		Options{
+			GenericForeignKeys: map[string]GenericForeignKey{"target": {}},
+		},
+	)
+	if err := comment.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	registry["blog"] = app
+	defer ClearRegistry()
+	// DB setup
+	err := Start(map[string]Database{"default": {Driver: "mocker"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { dbRegistry = map[string]Database{} }()
+	defer clearContentTypes()
+	mockedEngine := dbRegistry["default"].Engine.(MockedEngine)
+	// Cached content type
+	cacheContentType("default", int32(1), post.Model)
+
+	t.Run("Setup", func(t *testing.T) {
+		ct, ok := comment.Model.fields["content_type"].(IntegerField)
+		if !ok || !ct.Null {
+			t.Error("expected nullable content_type IntegerField")
+		}
+		id, ok := comment.Model.fields["object_id"].(CharField)
+		if !ok || !id.Null {
+			t.Error("expected nullable object_id CharField")
+		}
+		idx := comment.Model.meta.Indexes["blog_comment_target_gfk_idx"]
+		if len(idx) != 2 {
+			t.Errorf("expected generic foreign key index, got %v", idx)
+		}
+	})
+
+	t.Run("SetupClash", func(t *testing.T) {
+		model := New(
+			"Tag",
+			Fields{"target": IntegerField{}},
+			Options{
+				GenericForeignKeys: map[string]GenericForeignKey{"target": {}},
+			},
+		).Model
+		if err := model.Register(app); err == nil {
+			t.Error("expected field clash error")
+		}
+	})
+
+	t.Run("SetupDuplicate", func(t *testing.T) {
+		model := New(
+			"Tag",
+			Fields{},
+			Options{
+				GenericForeignKeys: map[string]GenericForeignKey{
+					"target": {},
+					"source": {ContentTypeField: "source_type"},
+				},
+			},
+		).Model
+		if err := model.Register(app); err == nil {
+			t.Error("expected duplicate field error")
+		}
+	})
+
+	t.Run("SetGeneric", func(t *testing.T) {
+		instance := newInstance(comment.Model, Values{})
+		target := newInstance(post.Model, Values{"id": int32(5)})
+		if err := instance.SetGeneric("target", target); err != nil {
+			t.Fatal(err)
+		}
+		if ct := instance.Get("content_type"); ct != int32(1) {
+			t.Errorf("expected content type 1, got %v", ct)
+		}
+		if id := instance.Get("object_id"); id != "5" {
+			t.Errorf("expected object id 5, got %v", id)
+		}
+	})
+
+	t.Run("SetGenericOnInvalidTarget", func(t *testing.T) {
+		instance := newInstance(comment.Model, Values{})
+		target := newInstance(post.Model, Values{"id": int32(5)})
+		err := instance.SetGenericOn("unknown", "target", target)
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("SetGenericNull", func(t *testing.T) {
+		model := New(
+			"Attachment",
+			Fields{
+				"owner_type": IntegerField{Null: true},
+				"owner_id":   CharField{MaxLength: 50, Null: true},
+			},
+			Options{
+				GenericForeignKeys: map[string]GenericForeignKey{
+					"owner": {
+						ContentTypeField: "owner_type",
+						ObjectIDField:    "owner_id",
+					},
+				},
+			},
+		).Model
+		if err := model.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		instance := newInstance(model, Values{
+			"owner_type": int32(1),
+			"owner_id":   "5",
+		})
+		if err := instance.SetGeneric("owner", nil); err != nil {
+			t.Fatal(err)
+		}
+		if instance.Get("owner_type") != nil {
+			t.Errorf("expected null owner_type, got %v", instance.Container())
+		}
+		if instance.Get("owner_id") != nil {
+			t.Errorf("expected null owner_id, got %v", instance.Container())
+		}
+	})
+
+	t.Run("SetGenericUnknown", func(t *testing.T) {
+		instance := newInstance(comment.Model, Values{})
+		target := newInstance(post.Model, Values{"id": int32(5)})
+		if err := instance.SetGeneric("author", target); err == nil {
+			t.Error("expected unknown generic foreign key error")
+		}
+	})
+
+	t.Run("GetGenericNull", func(t *testing.T) {
+		instance := newInstance(comment.Model, Values{})
+		target, err := instance.GetGeneric("target")
+		if err != nil || target != nil {
+			t.Errorf("expected nil object, got %v, %v", target, err)
+		}
+	})
+
+	t.Run("GetGeneric", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		instance := newInstance(comment.Model, Values{
+			"content_type": int32(1),
+			"object_id":    "5",
+		})
+		_, err := instance.GetGeneric("target")
+		if _, ok := err.(*ObjectNotFoundError); !ok {
+			t.Errorf("expected ObjectNotFoundError, got %T", err)
+		}
+		args := mockedEngine.Args.GetRows
+		if args.Model != post.Model {
+			t.Fatal("expected Post rows to be queried")
+		}
+		q, ok := args.Options.Conditioner.(Q)
+		if !ok || q["pk"] != int32(5) {
+			t.Errorf("expected pk condition, got %v", args.Options.Conditioner)
+		}
+	})
+
+	t.Run("GenericRelated", func(t *testing.T) {
+		target := newInstance(post.Model, Values{"id": int32(5)})
+		qs, err := target.GenericRelated(comment.Model, "target")
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, ok := qs.(GenericQuerySet).cond.(Q)
+		if !ok || q["content_type"] != int32(1) || q["object_id"] != "5" {
+			t.Errorf("expected generic relation condition, got %v", q)
+		}
+		if _, err := target.GenericRelated(post.Model, "target"); err == nil {
+			t.Error("expected unknown generic foreign key error")
+		}
+	})
+}
@@ -15,14 +15,19 @@ import (
 type Dispatcher struct {
 	*Model
 	Objects Manager
+	// AllObjects is the manager including the soft deleted objects.
+	AllObjects Manager
+	// Deleted is the manager including only the soft deleted objects.
+	Deleted Manager
 }
 
 // New returns an Instance of the embedded model, populating the fields with the
 // values argument. If no value is provided for a field, it will try to get a
-// default value from the model definition.
+// default value from the model definition. The PostInit signal is sent once the
+// instance is populated.
 func (d Dispatcher) New(values Container) (*Instance, error) {
 	model := d.Model
-	instance := &Instance{model, model.meta.Container}
+	instance := newInstance(model, model.meta.Container)
 	for name, field := range model.fields {
 		var value Value
 		if val, ok := getContainerField(values, name); ok {
@@ -34,6 +39,9 @@ func (d Dispatcher) New(values Container) (*Instance, error) {
 			return nil, err
 		}
 	}
+	if err := send(Event{Signal: PostInit, Instance: instance}); err != nil {
+		return nil, err
+	}
 	return instance, nil
 }
 
@@ -41,6 +49,10 @@ func (d Dispatcher) New(values Container) (*Instance, error) {
 // name and the value the list of indexes fields.
 type Indexes map[string][]string
 
+// The IndexDefinitions type describes the unique, partial, expression and
+// descending indexes of a model, where the key is the index name.
+type IndexDefinitions map[string]Index
+
 // Options holds extra model options.
 type Options struct {
 	// Table is the name of the database Table for the model. If blank, the
@@ -53,16 +65,62 @@ type Options struct {
 	// Indexes is used to declare composite indexes. Indexes with one column
 	// should be defined at field level.
 	Indexes Indexes
+	// IndexDefinitions is used to declare unique, partial, expression and
+	// descending indexes. The names must not clash with the Indexes ones.
+	IndexDefinitions IndexDefinitions
+	// Constraints is used to declare multi-column unique constraints and
+	// check constraints, where the key is the constraint name.
+	Constraints Constraints
+	// Validators holds custom validation functions for the field values, where
+	// the key is the field name.
+	Validators map[string][]ValidatorFunc
+	// ValidateOnSave is true if instance values must be validated before
+	// being saved or created.
+	ValidateOnSave bool
+	// Abstract is true if the model is only used as a base for other models.
+	// Abstract models have no table and can't be registered.
+	Abstract bool
+	// Extends is the list of models the model inherits from. The fields,
+	// indexes, constraints and validation options of abstract models are
+	// merged into the model definition. A concrete model can also be extended,
+	// in which case the model table is linked to the parent one and queries
+	// join both tables.
+	Extends []*Model
+	// Proxy is true if the model shares the table, fields, indexes and
+	// constraints of the concrete model it extends. Proxy models can't define
+	// fields, indexes or constraints and are ignored by migrations.
+	Proxy bool
+	// Filter is the conditioner applied by default to the querysets of the
+	// model managers.
+	Filter Conditioner
+	// SoftDelete is true if deleted objects must be kept, setting the time of
+	// the deletion on a nullable deleted_at field, which is added to the model
+	// if not defined. The Objects manager excludes the soft deleted objects.
+	SoftDelete bool
+	// History is true if every create, update and delete of the model objects
+	// must be recorded on a companion {model_name}History model, registered
+	// to the same app. Entries are written on the same target as the change.
+	// Bulk updates and deletes through querysets record an entry for each
+	// matching object.
+	History bool
+	// GenericForeignKeys declares the references to objects of any model,
+	// where the key is the name used by the SetGeneric and GetGeneric
+	// instance methods.
+	GenericForeignKeys map[string]GenericForeignKey
 }
 
 // A Model represents a single basic data structure of an application and how
 // to map that data to the database schema.
 type Model struct {
-	app    *Application
-	name   string
-	pk     string
-	fields Fields
-	meta   Options
+	app       *Application
+	name      string
+	pk        string
+	fields    Fields
+	meta      Options
+	parent    *Model
+	inherited map[string]bool
+	proxyFor  *Model
+	history   *Model
 }
 
 // Name returns the model name.
@@ -106,22 +164,77 @@ func (m Model) Indexes() Indexes {
 	return indexes
 }
 
+// IndexDefinitions returns the model IndexDefinitions map.
+func (m Model) IndexDefinitions() IndexDefinitions {
+	definitions := IndexDefinitions{}
+	for name, idx := range m.meta.IndexDefinitions {
+		definitions[name] = idx.copy()
+	}
+	return definitions
+}
+
+// indexes returns all the model indexes, including the plain ones.
+func (m Model) indexes() IndexDefinitions {
+	indexes := IndexDefinitions{}
+	for name, fields := range m.meta.Indexes {
+		indexes[name] = Index{Fields: fields}
+	}
+	for name, idx := range m.meta.IndexDefinitions {
+		indexes[name] = idx
+	}
+	return indexes
+}
+
+// hasIndex returns true if the model has an index with the given name.
+func (m Model) hasIndex(name string) bool {
+	if _, found := m.meta.Indexes[name]; found {
+		return true
+	}
+	_, found := m.meta.IndexDefinitions[name]
+	return found
+}
+
+// Constraints returns the model Constraints map.
+func (m Model) Constraints() Constraints {
+	constraints := Constraints{}
+	for name, c := range m.meta.Constraints {
+		constraints[name] = c
+	}
+	return constraints
+}
+
 // Container returns a new zero value of the model Container.
 func (m Model) Container() Container {
 	return newContainer(m.meta.Container)
 }
 
-// Register validates the model definition, calls the SetupPrimaryKey and
-// SetupIndexes methods, and adds the model to the given app.
+// Register validates the model definition, calls the SetupParent (if the model
+// extends a concrete one), SetupPrimaryKey and SetupIndexes methods, and adds
+// the model to the given app. The concrete parent must be registered first to
+// the same app. Proxy models share the definition of the parent instead.
+//
+// The companion history model of models with the History option is registered
+// to the app as well.
 func (m *Model) Register(app *Application) error {
 	if _, found := app.models[m.name]; found {
 		return fmt.Errorf("duplicate model")
 	}
+	if m.meta.Abstract {
+		return fmt.Errorf("abstract model")
+	}
 	m.app = app
-	if err := m.SetupPrimaryKey(); err != nil {
+	parent, err := m.concreteParent()
+	if err != nil {
 		return err
 	}
-	if err := m.SetupIndexes(); err != nil {
+	if parent != nil && parent.app != app {
+		return fmt.Errorf("parent not registered to app: %s", parent.name)
+	}
+	if m.meta.Proxy {
+		if err := m.setupProxy(parent); err != nil {
+			return err
+		}
+	} else if err := m.setupTable(parent); err != nil {
 		return err
 	}
 	if m.meta.Container != nil {
@@ -131,10 +244,49 @@ func (m *Model) Register(app *Application) error {
 	} else {
 		m.meta.Container = Values{}
 	}
+	if err := m.setupHistory(app); err != nil {
+		return err
+	}
 	app.models[m.name] = m
 	return nil
 }
 
+// setupTable sets up the parent, soft delete and generic foreign key fields,
+// primary key, indexes and constraints of the model table.
+func (m *Model) setupTable(parent *Model) error {
+	if parent != nil {
+		if err := m.SetupParent(parent); err != nil {
+			return err
+		}
+		if parent.meta.SoftDelete {
+			m.meta.SoftDelete = true
+		}
+	}
+	if err := m.setupSoftDelete(); err != nil {
+		return err
+	}
+	if err := m.setupGenericForeignKeys(); err != nil {
+		return err
+	}
+	if err := m.SetupPrimaryKey(); err != nil {
+		return err
+	}
+	m.SetupEncryptedFields()
+	if err := m.checkEncryptedFields(); err != nil {
+		return err
+	}
+	m.SetupEnumTypes()
+	if err := m.SetupIndexes(); err != nil {
+		return err
+	}
+	for name, c := range m.meta.Constraints {
+		if err := c.Validate(m); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+	}
+	return nil
+}
+
 // SetupPrimaryKey searches the model fields for a primary key. If not found,
 // it will add an auto incremented IntegerField called id.
 //
@@ -161,31 +313,67 @@ func (m *Model) SetupPrimaryKey() error {
 	return nil
 }
 
+// SetupEncryptedFields binds the encrypted fields of the model to the table
+// and columns storing them, which are authenticated along with the values.
+//
+// This method is automatically called when a model is registered and should
+// only be used to modify a model state during migration operations.
+func (m *Model) SetupEncryptedFields() {
+	if m.meta.Table == "" && m.app == nil {
+		return
+	}
+	for name, field := range m.fields {
+		if f, ok := encryptedField(field); ok && !m.isInherited(name) {
+			f.aad = m.Table() + "." + f.DBColumn(name)
+			m.fields[name] = f
+		}
+	}
+}
+
+// SetupEnumTypes sets the postgres enum type name of the enum fields without
+// TypeName to {table}_{column}, so the types of different models don't clash.
+//
+// This method is automatically called when a model is registered and should
+// only be used to modify a model state during migration operations.
+func (m *Model) SetupEnumTypes() {
+	if m.meta.Table == "" && m.app == nil {
+		return
+	}
+	for name, field := range m.fields {
+		if f, ok := enumField(field); ok && f.TypeName == "" {
+			if !m.isInherited(name) {
+				f.TypeName = m.Table() + "_" + f.DBColumn(name)
+				m.fields[name] = f
+			}
+		}
+	}
+}
+
 // SetupIndexes validates the model Indexes definition and adds individually
 // indexes fields.
 //
 // This method ia automatically called when a model is registered and should
 // only be used to modify a model state during migration operations.
 func (m *Model) SetupIndexes() error {
-	for name, fields := range m.meta.Indexes {
-		if len(fields) == 0 {
-			return fmt.Errorf("index with no fields: %s", name)
+	for name := range m.meta.Indexes {
+		if _, found := m.meta.IndexDefinitions[name]; found {
+			return fmt.Errorf("duplicate index: %s", name)
 		}
-		for _, indexedField := range fields {
-			if _, ok := m.fields[indexedField]; !ok {
-				return fmt.Errorf("unknown indexed field: %s", indexedField)
-			}
+	}
+	for name, idx := range m.indexes() {
+		if err := idx.Validate(m); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
 		}
 	}
 	for name, field := range m.fields {
-		if field.HasIndex() {
+		if field.HasIndex() && !m.isInherited(name) {
 			idxName := fmt.Sprintf(
 				"%s_%s_%s_auto_idx",
 				strings.ToLower(m.app.name),
 				strings.ToLower(m.name),
 				strings.ToLower(name),
 			)
-			if _, found := m.meta.Indexes[idxName]; found {
+			if m.hasIndex(idxName) {
 				return fmt.Errorf("duplicate index: %s", idxName)
 			}
 			m.meta.Indexes[idxName] = []string{name}
@@ -222,20 +410,118 @@ func (m *Model) RemoveField(name string) error {
 	if m.pk == name {
 		return fmt.Errorf("pk field cannot be removed")
 	}
+	if m.isInherited(name) {
+		return fmt.Errorf("cannot remove parent field: %s", name)
+	}
 	if _, ok := m.fields[name]; !ok {
 		return fmt.Errorf("field not found: %s", name)
 	}
-	for _, fields := range m.meta.Indexes {
-		for _, indexedField := range fields {
+	for _, idx := range m.indexes() {
+		for _, indexedField := range idx.IndexFields() {
 			if name == indexedField {
 				return fmt.Errorf("cannot remove indexed field: %s", name)
 			}
 		}
 	}
+	for cName, c := range m.meta.Constraints {
+		for _, constrained := range c.ConstraintFields() {
+			if name == constrained {
+				return fmt.Errorf(
+					"cannot remove field used by constraint %s: %s",
+					cName, name,
+				)
+			}
+		}
+	}
 	delete(m.fields, name)
 	return nil
 }
 
+// AlterChoices updates the choices of the named field in the model definition.
+// It returns an error if the field doesn't exist or it doesn't implement the
+// ChoicesField interface.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) AlterChoices(name string, choices []Choice, strict bool) error {
+	field, ok := m.fields[name]
+	if !ok {
+		return fmt.Errorf("field not found: %s", name)
+	}
+	cf, ok := field.(ChoicesField)
+	if !ok {
+		return fmt.Errorf("field doesn't support choices: %s", name)
+	}
+	m.fields[name] = cf.WithChoices(choices, strict)
+	return nil
+}
+
+// AddEnumValues adds the given values to the named enum field in the model
+// definition. It returns an error if the field doesn't exist, it's not an
+// EnumField or any of the values is invalid or duplicate.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) AddEnumValues(name string, values ...Value) error {
+	field, ok := m.fields[name]
+	if !ok {
+		return fmt.Errorf("field not found: %s", name)
+	}
+	enum, ok := enumField(field)
+	if !ok {
+		return fmt.Errorf("not an enum field: %s", name)
+	}
+	enum.Values = append([]Value{}, enum.Values...)
+	for _, val := range values {
+		if _, _, ok := enumLabel(val); !ok {
+			return fmt.Errorf("invalid enum value: %v", val)
+		}
+		if _, found := enum.lookup(val); found {
+			return fmt.Errorf("duplicate enum value: %v", val)
+		}
+		enum.Values = append(enum.Values, val)
+	}
+	m.fields[name] = enum
+	return nil
+}
+
+// DropEnumValues removes the given values from the named enum field in the
+// model definition. It returns an error if the field doesn't exist, it's not
+// an EnumField or any of the values is not found.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) DropEnumValues(name string, values ...Value) error {
+	field, ok := m.fields[name]
+	if !ok {
+		return fmt.Errorf("field not found: %s", name)
+	}
+	enum, ok := enumField(field)
+	if !ok {
+		return fmt.Errorf("not an enum field: %s", name)
+	}
+	dropped := map[string]bool{}
+	for _, val := range values {
+		if _, found := enum.lookup(val); !found {
+			return fmt.Errorf("unknown enum value: %v", val)
+		}
+		label, _, _ := enumLabel(val)
+		dropped[label] = true
+	}
+	kept := []Value{}
+	for _, val := range enum.Values {
+		if label, _, _ := enumLabel(val); !dropped[label] {
+			kept = append(kept, val)
+		}
+	}
+	enum.Values = kept
+	m.fields[name] = enum
+	return nil
+}
+
 // AddIndex adds a new index to the model definition. It returns an error if the
 // name is duplicate or any of the indexed fields doesn't exist.
 //
@@ -243,7 +529,7 @@ func (m *Model) RemoveField(name string) error {
 // operations or to construct models programatically. Changing the model
 // definition after it has been registered could cause unexpected errors.
 func (m *Model) AddIndex(name string, fields ...string) error {
-	if _, found := m.meta.Indexes[name]; found {
+	if m.hasIndex(name) {
 		return fmt.Errorf("duplicate index: %s", name)
 	}
 	if len(fields) == 0 {
@@ -258,6 +544,26 @@ func (m *Model) AddIndex(name string, fields ...string) error {
 	return nil
 }
 
+// AddIndexDefinition adds a new index to the model definition. It returns an
+// error if the name is duplicate or the index is not valid for the model.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) AddIndexDefinition(name string, index Index) error {
+	if m.hasIndex(name) {
+		return fmt.Errorf("duplicate index: %s", name)
+	}
+	if err := index.Validate(m); err != nil {
+		return err
+	}
+	if m.meta.IndexDefinitions == nil {
+		m.meta.IndexDefinitions = IndexDefinitions{}
+	}
+	m.meta.IndexDefinitions[name] = index.copy()
+	return nil
+}
+
 // RemoveIndex removes the named index from the model definition. It returns an
 // error if the index doesn't exist.
 //
@@ -265,10 +571,48 @@ func (m *Model) AddIndex(name string, fields ...string) error {
 // operations or to construct models programatically. Changing the model
 // definition after it has been registered could cause unexpected errors.
 func (m *Model) RemoveIndex(name string) error {
-	if _, ok := m.meta.Indexes[name]; !ok {
+	if !m.hasIndex(name) {
 		return fmt.Errorf("index not found: %s", name)
 	}
 	delete(m.meta.Indexes, name)
+	delete(m.meta.IndexDefinitions, name)
+	return nil
+}
+
+// AddConstraint adds a new constraint to the model definition. It returns an
+// error if the name is duplicate or the constraint is not valid for the model.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) AddConstraint(name string, constraint Constraint) error {
+	if _, found := m.meta.Constraints[name]; found {
+		return fmt.Errorf("duplicate constraint: %s", name)
+	}
+	if constraint == nil {
+		return fmt.Errorf("nil constraint: %s", name)
+	}
+	if err := constraint.Validate(m); err != nil {
+		return err
+	}
+	if m.meta.Constraints == nil {
+		m.meta.Constraints = Constraints{}
+	}
+	m.meta.Constraints[name] = constraint
+	return nil
+}
+
+// RemoveConstraint removes the named constraint from the model definition. It
+// returns an error if the constraint doesn't exist.
+//
+// This method should only be used to modify a model state during migration
+// operations or to construct models programatically. Changing the model
+// definition after it has been registered could cause unexpected errors.
+func (m *Model) RemoveConstraint(name string) error {
+	if _, ok := m.meta.Constraints[name]; !ok {
+		return fmt.Errorf("constraint not found: %s", name)
+	}
+	delete(m.meta.Constraints, name)
 	return nil
 }
 
@@ -278,12 +622,35 @@ func (m *Model) RemoveIndex(name string) error {
 // The model won't be ready to interact with the database until it's been
 // registered to an application using either the Register function or the
 // homonymous model method.
+//
+// The definitions of the abstract models in the Extends option are merged into
+// the new model.
 func New(name string, fields Fields, options Options) *Dispatcher {
 	if options.Indexes == nil {
 		options.Indexes = Indexes{}
 	}
+	if options.IndexDefinitions == nil {
+		options.IndexDefinitions = IndexDefinitions{}
+	}
+	if options.Constraints == nil {
+		options.Constraints = Constraints{}
+	}
 	model := &Model{name: name, fields: fields, meta: options}
+	for _, base := range options.Extends {
+		if base.meta.Abstract {
+			model.mergeAbstract(base)
+		}
+	}
 	return &Dispatcher{
-		model, Manager{Model: model, QuerySet: GenericQuerySet{}},
+		Model:   model,
+		Objects: Manager{Model: model, QuerySet: GenericQuerySet{}},
+		AllObjects: Manager{
+			Model:    model,
+			QuerySet: GenericQuerySet{deleted: includeDeleted},
+		},
+		Deleted: Manager{
+			Model:    model,
+			QuerySet: GenericQuerySet{deleted: onlyDeleted},
+		},
 	}
 }
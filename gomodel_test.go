@@ -180,6 +180,30 @@ func TestModel(t *testing.T) {
 		}
 	})
 
+	t.Run("DuplicateIndexDefinition", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}}
+		model.meta.Indexes = Indexes{"email_idx": {"email"}}
+		model.meta.IndexDefinitions = IndexDefinitions{
+			"email_idx": {Fields: []string{"email"}, Unique: true},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		if err := model.SetupIndexes(); err == nil {
+			t.Error("expected duplicate index error")
+		}
+	})
+
+	t.Run("IndexDefinitionUnknownField", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}}
+		model.meta.Indexes = Indexes{}
+		model.meta.IndexDefinitions = IndexDefinitions{
+			"email_idx": {Fields: []string{"-username"}},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		if err := model.SetupIndexes(); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
 	t.Run("SetupIndexes", func(t *testing.T) {
 		model.fields = Fields{"username": CharField{Index: true}}
 		model.meta.Indexes = Indexes{}
@@ -223,6 +247,19 @@ func TestModel(t *testing.T) {
 		}
 	})
 
+	t.Run("RegisterInvalidConstraints", func(t *testing.T) {
+		app.models = map[string]*Model{}
+		model.fields = Fields{}
+		model.meta.Indexes = Indexes{}
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{Fields: []string{"email"}},
+		}
+		if err := model.Register(app); err == nil {
+			t.Error("expected unknown constraint field error")
+		}
+		model.meta.Constraints = Constraints{}
+	})
+
 	t.Run("AddDuplicateField", func(t *testing.T) {
 		model.fields = Fields{"email": CharField{}}
 		if err := model.AddField("email", CharField{}); err == nil {
@@ -272,6 +309,33 @@ func TestModel(t *testing.T) {
 		}
 	})
 
+	t.Run("RemoveConstraintField", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}}
+		model.meta.Indexes = Indexes{}
+		model.meta.Constraints = Constraints{
+			"email_check": CheckConstraint{Check: Q{"email >": ""}},
+		}
+		if err := model.RemoveField("email"); err == nil {
+			t.Error("expected cannot remove constrained field error")
+		}
+		model.meta.Constraints = Constraints{}
+	})
+
+	t.Run("RemovePartialIndexField", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}, "active": BooleanField{}}
+		model.meta.Indexes = Indexes{}
+		model.meta.IndexDefinitions = IndexDefinitions{
+			"email_idx": {
+				Fields:    []string{"email"},
+				Condition: Q{"active": true},
+			},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		if err := model.RemoveField("active"); err == nil {
+			t.Error("expected cannot remove indexed field error")
+		}
+	})
+
 	t.Run("RemoveField", func(t *testing.T) {
 		model.fields = Fields{"email": CharField{}}
 		model.meta.Indexes = Indexes{}
@@ -283,6 +347,57 @@ func TestModel(t *testing.T) {
 		}
 	})
 
+	t.Run("AddEnumValuesNotEnum", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}}
+		if err := model.AddEnumValues("email", "a"); err == nil {
+			t.Error("expected not an enum field error")
+		}
+	})
+
+	t.Run("AddDuplicateEnumValues", func(t *testing.T) {
+		model.fields = Fields{"level": EnumField{Values: []Value{1, 2}}}
+		if err := model.AddEnumValues("level", 3, 2.0); err == nil {
+			t.Error("expected duplicate enum value error")
+		}
+	})
+
+	t.Run("AddEnumValues", func(t *testing.T) {
+		field := EnumField{Values: []Value{1, 2}}
+		model.fields = Fields{"level": field}
+		if err := model.AddEnumValues("level", 3); err != nil {
+			t.Fatal(err)
+		}
+		enum := model.fields["level"].(EnumField)
+		if len(enum.Values) != 3 || enum.Values[2] != 3 {
+			t.Errorf("expected values [1 2 3], got %v", enum.Values)
+		}
+		if len(field.Values) != 2 {
+			t.Errorf("expected original field to be unchanged")
+		}
+	})
+
+	t.Run("DropUnknownEnumValues", func(t *testing.T) {
+		model.fields = Fields{"level": EnumField{Values: []Value{1, 2}}}
+		if err := model.DropEnumValues("level", 3); err == nil {
+			t.Error("expected unknown enum value error")
+		}
+	})
+
+	t.Run("DropEnumValues", func(t *testing.T) {
+		field := EnumField{Values: []Value{1, 2, 3}}
+		model.fields = Fields{"level": field}
+		if err := model.DropEnumValues("level", 2.0); err != nil {
+			t.Fatal(err)
+		}
+		enum := model.fields["level"].(EnumField)
+		if len(enum.Values) != 2 || enum.Values[1] != 3 {
+			t.Errorf("expected values [1 3], got %v", enum.Values)
+		}
+		if len(field.Values) != 3 {
+			t.Errorf("expected original field to be unchanged")
+		}
+	})
+
 	t.Run("AddDuplicateIndex", func(t *testing.T) {
 		model.meta.Indexes = Indexes{"email_idx": []string{"email"}}
 		if err := model.AddIndex("email_idx", "email"); err == nil {
@@ -320,6 +435,38 @@ func TestModel(t *testing.T) {
 		}
 	})
 
+	t.Run("AddInvalidIndexDefinition", func(t *testing.T) {
+		model.meta.Indexes = Indexes{}
+		model.fields = Fields{"email": CharField{}}
+		if err := model.AddIndexDefinition("test_idx", Index{}); err == nil {
+			t.Error("expected index with no fields error")
+		}
+	})
+
+	t.Run("AddIndexDefinition", func(t *testing.T) {
+		model.meta.Indexes = Indexes{}
+		model.fields = Fields{"email": CharField{}}
+		idx := Index{Expressions: []Expression{Lower("email")}, Unique: true}
+		if err := model.AddIndexDefinition("test_idx", idx); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := model.meta.IndexDefinitions["test_idx"]; !ok {
+			t.Fatal("index was not added to model")
+		}
+		if err := model.AddIndexDefinition("test_idx", idx); err == nil {
+			t.Error("expected duplicate index error")
+		}
+		if err := model.AddIndex("test_idx", "email"); err == nil {
+			t.Error("expected duplicate index error")
+		}
+		if err := model.RemoveIndex("test_idx"); err != nil {
+			t.Fatal(err)
+		}
+		if _, found := model.meta.IndexDefinitions["test_idx"]; found {
+			t.Error("index was not removed from model")
+		}
+	})
+
 	t.Run("RemoveUnknownIndex", func(t *testing.T) {
 		model.meta.Indexes = Indexes{}
 		if err := model.RemoveIndex("foo"); err == nil {
@@ -413,4 +560,53 @@ func TestDispatcher(t *testing.T) {
 			t.Error("manager was not set up correctly")
 		}
 	})
+
+	t.Run("AddDuplicateConstraint", func(t *testing.T) {
+		model.fields = Fields{"email": CharField{}}
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{Fields: []string{"email"}},
+		}
+		c := UniqueConstraint{Fields: []string{"email"}}
+		if err := model.AddConstraint("unique_email", c); err == nil {
+			t.Error("expected duplicate constraint error")
+		}
+	})
+
+	t.Run("AddInvalidConstraint", func(t *testing.T) {
+		model.meta.Constraints = Constraints{}
+		c := CheckConstraint{Check: Q{"foo": 1}}
+		if err := model.AddConstraint("foo_check", c); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
+	t.Run("AddConstraint", func(t *testing.T) {
+		model.meta.Constraints = nil
+		c := UniqueConstraint{Fields: []string{"email"}}
+		if err := model.AddConstraint("unique_email", c); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := model.meta.Constraints["unique_email"]; !ok {
+			t.Error("constraint was not added to model")
+		}
+	})
+
+	t.Run("RemoveUnknownConstraint", func(t *testing.T) {
+		model.meta.Constraints = Constraints{}
+		if err := model.RemoveConstraint("foo"); err == nil {
+			t.Error("expected constraint not found error")
+		}
+	})
+
+	t.Run("RemoveConstraint", func(t *testing.T) {
+		model.meta.Constraints = Constraints{
+			"unique_email": UniqueConstraint{Fields: []string{"email"}},
+		}
+		if err := model.RemoveConstraint("unique_email"); err != nil {
+			t.Fatal(err)
+		}
+		if _, found := model.meta.Constraints["unique_email"]; found {
+			t.Error("constraint was not removed from model")
+		}
+	})
 }
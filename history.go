@@ -0,0 +1,364 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// The actions recorded on the history entries.
+const (
+	HistoryCreate = "create"
+	HistoryUpdate = "update"
+	HistoryDelete = "delete"
+)
+
+// HistoryActor returns the actor recorded on the history entries written for
+// the given instance on the given database (e.g. the current user). If nil or
+// blank, no actor is recorded.
+var HistoryActor func(instance *Instance, database string) string
+
+// historyFields returns the fields of the companion model holding the history
+// entries of a model.
+func historyFields() Fields {
+	return Fields{
+		"object_id":  CharField{MaxLength: 255, Index: true},
+		"action":     CharField{MaxLength: 10},
+		"old_values": JSONField{Null: true, UseNumber: true},
+		"new_values": JSONField{Null: true, UseNumber: true},
+		"actor":      CharField{MaxLength: 255, Null: true},
+		"timestamp":  DateTimeField{AutoNowAdd: true, Index: true},
+	}
+}
+
+// setupHistory registers the companion model holding the history entries of
+// models with the History option to the given app.
+func (m *Model) setupHistory(app *Application) error {
+	if !m.meta.History || m.proxyFor != nil {
+		return nil
+	}
+	name := m.name + "History"
+	if _, found := app.models[name]; found {
+		return fmt.Errorf("duplicate history model: %s", name)
+	}
+	history := New(name, historyFields(), Options{}).Model
+	if err := history.Register(app); err != nil {
+		return err
+	}
+	m.history = history
+	return nil
+}
+
+// HistoryModel returns the companion model holding the history entries of the
+// model, nil if the model doesn't keep history.
+func (m Model) HistoryModel() *Model {
+	return m.history
+}
+
+// historyValues returns the driver values of the given fields, or all of them
+// if none is provided, to be recorded on a history entry. The values are the
+// original ones (see Original) if original is true. Nil is returned if the
+// model doesn't keep history.
+func (i Instance) historyValues(
+	dbName string,
+	original bool,
+	fields ...string,
+) (Values, error) {
+	if i.model.history == nil {
+		return nil, nil
+	}
+	if len(fields) == 0 {
+		for name := range i.model.fields {
+			fields = append(fields, name)
+		}
+	}
+	values := Values{}
+	for _, name := range fields {
+		if name == "pk" {
+			name = i.model.pk
+		}
+		field, ok := i.model.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+		var val Value
+		if original && i.tracker != nil {
+			val, ok = i.tracker.original[name]
+		} else if !original {
+			val, ok = i.GetIf(name)
+		}
+		if !ok {
+			continue
+		}
+		if val != nil {
+			dvr := dbRegistry[dbName].Driver
+			dbVal, err := field.DriverValue(val, dvr)
+			if err != nil {
+				return nil, err
+			}
+			val = dbVal
+		}
+		values[name] = val
+	}
+	return values, nil
+}
+
+// recordHistory inserts a history entry for the instance on the given engine,
+// unless the model doesn't keep history.
+func (i Instance) recordHistory(
+	eng Engine,
+	dbName string,
+	action string,
+	oldValues Values,
+	newValues Values,
+) error {
+	if i.model.history == nil {
+		return nil
+	}
+	values := Values{
+		"object_id": fmt.Sprint(i.Get("pk")),
+		"action":    action,
+		"timestamp": time.Now(),
+	}
+	if oldValues != nil {
+		values["old_values"] = oldValues
+	}
+	if newValues != nil {
+		values["new_values"] = newValues
+	}
+	if HistoryActor != nil {
+		if actor := HistoryActor(&i, dbName); actor != "" {
+			values["actor"] = actor
+		}
+	}
+	if _, err := eng.InsertRow(i.model.history, values); err != nil {
+		return &DatabaseError{dbName, i.trace(err)}
+	}
+	return nil
+}
+
+// atomicHistory runs the given change on the target, within a transaction if
+// the model keeps history, so that the change and its history entries are
+// committed together.
+func (i Instance) atomicHistory(
+	target interface{},
+	change func(target interface{}) error,
+) error {
+	if i.model.history == nil {
+		return change(target)
+	}
+	return atomic(target, change)
+}
+
+// recordChanges runs the given bulk change and records a history entry for
+// each affected object, within a transaction, if the model keeps history. The
+// objects are loaded first, and the change receives the queryset restricted to
+// their primary keys. The values are the ones set by the change, nil if the
+// rows are removed.
+func (qs GenericQuerySet) recordChanges(
+	values Values,
+	change func(qs GenericQuerySet) error,
+) error {
+	if qs.model.history == nil {
+		return change(qs)
+	}
+	var target interface{} = qs.database
+	if qs.tx != nil {
+		target = qs.tx
+	}
+	return atomic(target, func(target interface{}) error {
+		if tx, ok := target.(*Transaction); ok {
+			qs.tx = tx
+		}
+		loader := qs
+		loader.container = Values{}
+		loader.fields = make([]string, 0, len(qs.model.fields))
+		for name := range qs.model.fields {
+			loader.fields = append(loader.fields, name)
+		}
+		instances, err := loader.load(0, -1)
+		if err != nil || len(instances) == 0 {
+			return err
+		}
+		var cond Conditioner
+		for _, instance := range instances {
+			q := Q{"pk": instance.Get("pk")}
+			if cond == nil {
+				cond = q
+			} else {
+				cond = cond.Or(q)
+			}
+		}
+		affected := qs
+		affected.cond = cond
+		if err := change(affected); err != nil {
+			return err
+		}
+		eng, err := qs.engine()
+		if err != nil {
+			return err
+		}
+		dbName := qs.dbName()
+		for _, instance := range instances {
+			if values == nil {
+				old, err := instance.historyValues(dbName, false)
+				if err != nil {
+					return &ContainerError{instance.trace(err)}
+				}
+				err = instance.recordHistory(
+					eng, dbName, HistoryDelete, old, nil,
+				)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			fields := valuesNames(values)
+			for _, name := range fields {
+				if err := instance.Set(name, values[name]); err != nil {
+					return err
+				}
+			}
+			old, err := instance.historyValues(dbName, true, fields...)
+			if err != nil {
+				return &ContainerError{instance.trace(err)}
+			}
+			err = instance.saveHistory(eng, dbName, false, old, fields...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveHistory records the creation of the instance on the given engine, or
+// the update of the given fields from the given old values.
+func (i Instance) saveHistory(
+	eng Engine,
+	dbName string,
+	created bool,
+	old Values,
+	fields ...string,
+) error {
+	if i.model.history == nil {
+		return nil
+	}
+	if created {
+		fields = nil
+	}
+	values, err := i.historyValues(dbName, false, fields...)
+	if err != nil {
+		return &ContainerError{i.trace(err)}
+	}
+	if created {
+		return i.recordHistory(eng, dbName, HistoryCreate, nil, values)
+	}
+	return i.recordHistory(eng, dbName, HistoryUpdate, old, values)
+}
+
+// History returns a QuerySet of the history entries of the object, nil if
+// the model doesn't keep history. The entries are instances of the model
+// returned by the HistoryModel method.
+func (i Instance) History() QuerySet {
+	if i.model.history == nil {
+		return nil
+	}
+	manager := Manager{Model: i.model.history, QuerySet: GenericQuerySet{}}
+	return manager.Filter(Q{"object_id": fmt.Sprint(i.Get("pk"))})
+}
+
+// asOf reconstructs the object from the history entries on the given target.
+func (i Instance) asOf(target interface{}, t time.Time) (*Instance, error) {
+	qs := i.History()
+	if qs == nil {
+		err := fmt.Errorf("model doesn't keep history")
+		return nil, &QuerySetError{i.trace(err)}
+	}
+	switch tar := target.(type) {
+	case *Transaction:
+		qs = qs.WithTx(tar)
+	case string:
+		qs = qs.WithDB(tar)
+	}
+	entries, err := qs.Filter(Q{"timestamp <=": t}).Load()
+	if err != nil {
+		return nil, err
+	}
+	return i.replayHistory(entries)
+}
+
+// AsOf returns a new instance holding the field values of the object at the
+// given time, reconstructed from the history entries on the default database.
+// If the object didn't exist at that time, *ObjectNotFoundError is returned.
+//
+// Saving the returned instance restores the object to that state.
+func (i Instance) AsOf(t time.Time) (*Instance, error) {
+	return i.asOf("default", t)
+}
+
+// AsOfOn works as AsOf, but the history entries are loaded from the given
+// target, that can be a *Transaction or a string representing a database
+// identifier.
+func (i Instance) AsOfOn(target interface{}, t time.Time) (*Instance, error) {
+	return i.asOf(target, t)
+}
+
+// replayHistory applies the new values of the given history entries in
+// chronological order and returns an instance holding the resulting values.
+func (i Instance) replayHistory(entries []*Instance) (*Instance, error) {
+	sort.SliceStable(entries, func(a, b int) bool {
+		ta, _ := entries[a].Get("timestamp").(time.Time)
+		tb, _ := entries[b].Get("timestamp").(time.Time)
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		ida, _ := toInt64(entries[a].Get("pk"), math.MinInt64, math.MaxInt64)
+		idb, _ := toInt64(entries[b].Get("pk"), math.MinInt64, math.MaxInt64)
+		return ida < idb
+	})
+	var state map[string]interface{}
+	for _, entry := range entries {
+		action := entry.Get("action")
+		if action == HistoryDelete {
+			state = nil
+			continue
+		}
+		if action == HistoryCreate || state == nil {
+			state = map[string]interface{}{}
+		}
+		values, _ := entry.Get("new_values").(map[string]interface{})
+		for name, val := range values {
+			state[name] = val
+		}
+	}
+	if state == nil {
+		err := fmt.Errorf("object does not exist")
+		return nil, &ObjectNotFoundError{i.trace(err)}
+	}
+	instance := newInstance(i.model, i.model.Container())
+	for name, val := range state {
+		field, ok := i.model.fields[name]
+		if !ok {
+			continue
+		}
+		if n, ok := val.(json.Number); ok {
+			val = n.String()
+		}
+		if val != nil {
+			recipient := field.Recipient()
+			if err := setRecipient(recipient, val); err != nil {
+				return nil, &ContainerError{i.trace(err)}
+			}
+			val = reflect.Indirect(reflect.ValueOf(recipient)).Interface()
+			val = field.Value(val)
+		}
+		if err := instance.Set(name, val); err != nil {
+			return nil, err
+		}
+	}
+	return instance, nil
+}
@@ -0,0 +1,422 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type postRows struct {
+	number int
+}
+
+func (r *postRows) Close() error {
+	return nil
+}
+
+func (r postRows) Err() error {
+	return nil
+}
+
+func (r *postRows) Next() bool {
+	r.number--
+	return r.number > -1
+}
+
+func (r *postRows) Scan(dest ...interface{}) error {
+	for _, rec := range dest {
+		switch v := rec.(type) {
+		case *int32:
+			*v = int32(r.number + 1)
+		case *string:
+			*v = "Draft"
+		}
+	}
+	return nil
+}
+
+// TestHistory tests the recording of the model history entries
+func TestHistory(t *testing.T) {
+	// Models setup
+	app := &Application{name: "blog", models: map[string]*Model{}}
+	post := New(
+		"Post",
+		Fields{
+			"title":     CharField{MaxLength: 100},
+			"views":     IntegerField{},
+			"published": DateTimeField{Null: true},
+		},
+		Options{History: true},
+	)
+	if err := post.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	history := post.Model.HistoryModel()
+	loaded := func() *Instance {
+		instance := newInstance(post.Model, Values{
+			"id":        int32(1),
+			"title":     "Draft",
+			"views":     int32(0),
+			"published": nil,
+		})
+		instance.snapshot()
+		return instance
+	}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+
+	t.Run("Setup", func(t *testing.T) {
+		if history == nil || app.models["PostHistory"] != history {
+			t.Fatal("expected PostHistory model to be registered")
+		}
+		for _, name := range []string{"object_id", "old_values", "timestamp"} {
+			if _, ok := history.fields[name]; !ok {
+				t.Errorf("expected history field %s", name)
+			}
+		}
+		if history.HistoryModel() != nil {
+			t.Error("expected history model without history")
+		}
+	})
+
+	t.Run("SetupDuplicate", func(t *testing.T) {
+		model := New("Post", Fields{}, Options{History: true}).Model
+		other := &Application{name: "other", models: map[string]*Model{}}
+		other.models["PostHistory"] = history
+		if err := model.Register(other); err == nil {
+			t.Error("expected duplicate history model error")
+		}
+	})
+
+	t.Run("SetupProxy", func(t *testing.T) {
+		proxy := New(
+			"PublishedPost",
+			Fields{},
+			Options{Extends: []*Model{post.Model}, Proxy: true},
+		).Model
+		if err := proxy.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		if proxy.HistoryModel() != history {
+			t.Error("expected proxy to share the history model")
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRow.Id = 1
+		if _, err := post.Objects.Create(Values{"title": "Draft"}); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 2 {
+			t.Fatal("expected engine InsertRow to be called twice")
+		}
+		args := mockedEngine.Args.InsertRow
+		if args.Model != history {
+			t.Fatal("expected history entry to be inserted")
+		}
+		if args.Values["action"] != HistoryCreate {
+			t.Errorf("expected create action, got %v", args.Values["action"])
+		}
+		if args.Values["object_id"] != "1" {
+			t.Errorf("expected object id 1, got %v", args.Values["object_id"])
+		}
+		values, _ := args.Values["new_values"].(Values)
+		if values["title"] != "Draft" {
+			t.Errorf("expected new title, got %v", values)
+		}
+		if _, ok := args.Values["old_values"]; ok {
+			t.Error("expected no old values")
+		}
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		instance := loaded()
+		if err := instance.Set("title", "Final"); err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.InsertRow
+		if args.Values["action"] != HistoryUpdate {
+			t.Errorf("expected update action, got %v", args.Values["action"])
+		}
+		old, _ := args.Values["old_values"].(Values)
+		values, _ := args.Values["new_values"].(Values)
+		if len(old) != 1 || old["title"] != "Draft" {
+			t.Errorf("expected old title, got %v", old)
+		}
+		if len(values) != 1 || values["title"] != "Final" {
+			t.Errorf("expected new title, got %v", values)
+		}
+	})
+
+	t.Run("SaveUnchanged", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := loaded().Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected no history entry")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := loaded().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.InsertRow
+		if args.Values["action"] != HistoryDelete {
+			t.Errorf("expected delete action, got %v", args.Values["action"])
+		}
+		old, _ := args.Values["old_values"].(Values)
+		if old["title"] != "Draft" {
+			t.Errorf("expected old title, got %v", old)
+		}
+	})
+
+	t.Run("Actor", func(t *testing.T) {
+		mockedEngine.Reset()
+		HistoryActor = func(instance *Instance, db string) string {
+			return "admin"
+		}
+		defer func() { HistoryActor = nil }()
+		if err := loaded().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		values := mockedEngine.Args.InsertRow.Values
+		if actor := values["actor"]; actor != "admin" {
+			t.Errorf("expected admin actor, got %v", actor)
+		}
+	})
+
+	t.Run("InsertError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRow.Err = fmt.Errorf("db error")
+		err := loaded().Delete()
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.TxSupport = true
+		if err := loaded().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("BeginTx") != 1 {
+			t.Error("expected engine BeginTx to be called")
+		}
+		if mockedEngine.Calls("CommitTx") != 1 {
+			t.Error("expected engine CommitTx to be called")
+		}
+	})
+
+	t.Run("TransactionRollback", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.TxSupport = true
+		mockedEngine.Results.InsertRow.Err = fmt.Errorf("db error")
+		if err := loaded().Delete(); err == nil {
+			t.Fatal("expected history error")
+		}
+		if mockedEngine.Calls("RollbackTx") != 1 {
+			t.Error("expected engine RollbackTx to be called")
+		}
+		if mockedEngine.Calls("CommitTx") != 0 {
+			t.Error("expected engine CommitTx not to be called")
+		}
+	})
+
+	t.Run("BulkUpdate", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &postRows{number: 2}
+		mockedEngine.Results.UpdateRows.Number = 2
+		rows, err := post.Objects.All().Update(Values{"title": "Final"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows != 2 {
+			t.Errorf("expected 2 rows, got %d", rows)
+		}
+		if mockedEngine.Calls("InsertRow") != 2 {
+			t.Fatal("expected a history entry per row")
+		}
+		args := mockedEngine.Args.InsertRow
+		if args.Values["action"] != HistoryUpdate {
+			t.Errorf("expected update action, got %v", args.Values["action"])
+		}
+		old, _ := args.Values["old_values"].(Values)
+		values, _ := args.Values["new_values"].(Values)
+		if len(old) != 1 || old["title"] != "Draft" {
+			t.Errorf("expected old title, got %v", old)
+		}
+		if len(values) != 1 || values["title"] != "Final" {
+			t.Errorf("expected new title, got %v", values)
+		}
+	})
+
+	t.Run("BulkUpdateReturning", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &postRows{number: 2}
+		mockedEngine.Results.UpdateRowsReturning.Rows = &postRows{number: 2}
+		qs := post.Objects.All()
+		instances, err := qs.UpdateReturning(Values{"title": "Final"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(instances) != 2 {
+			t.Errorf("expected 2 instances, got %d", len(instances))
+		}
+		if mockedEngine.Calls("InsertRow") != 2 {
+			t.Error("expected a history entry per row")
+		}
+	})
+
+	t.Run("BulkDelete", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &postRows{number: 2}
+		mockedEngine.Results.DeleteRows.Number = 2
+		if _, err := post.Objects.All().Delete(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 2 {
+			t.Fatal("expected a history entry per row")
+		}
+		args := mockedEngine.Args.InsertRow
+		if args.Values["action"] != HistoryDelete {
+			t.Errorf("expected delete action, got %v", args.Values["action"])
+		}
+		old, _ := args.Values["old_values"].(Values)
+		if old["title"] != "Draft" {
+			t.Errorf("expected old title, got %v", old)
+		}
+	})
+
+	t.Run("BulkNoRows", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &postRows{}
+		qs := post.Objects.All()
+		if _, err := qs.Update(Values{"title": "Final"}); err != nil {
+			t.Fatal(err)
+		}
+		instances, err := qs.DeleteReturning()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if instances == nil || len(instances) != 0 {
+			t.Errorf("expected empty slice, got %v", instances)
+		}
+		if mockedEngine.Calls("UpdateRows") != 0 {
+			t.Error("expected engine UpdateRows not to be called")
+		}
+		if mockedEngine.Calls("DeleteRowsReturning") != 0 {
+			t.Error("expected engine DeleteRowsReturning not to be called")
+		}
+	})
+
+	t.Run("BulkTransaction", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.TxSupport = true
+		mockedEngine.Results.GetRows.Rows = &postRows{number: 2}
+		mockedEngine.Results.InsertRow.Err = fmt.Errorf("db error")
+		if _, err := post.Objects.All().HardDelete(); err == nil {
+			t.Fatal("expected history error")
+		}
+		if mockedEngine.Calls("RollbackTx") != 1 {
+			t.Error("expected engine RollbackTx to be called")
+		}
+		if mockedEngine.Calls("CommitTx") != 0 {
+			t.Error("expected engine CommitTx not to be called")
+		}
+	})
+
+	t.Run("History", func(t *testing.T) {
+		qs, ok := loaded().History().(GenericQuerySet)
+		if !ok || qs.model != history {
+			t.Fatal("expected history model queryset")
+		}
+		if q, ok := qs.cond.(Q); !ok || q["object_id"] != "1" {
+			t.Errorf("expected object_id condition, got %v", qs.cond)
+		}
+	})
+
+	t.Run("AsOfNotFound", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		_, err := loaded().AsOf(time.Now())
+		if _, ok := err.(*ObjectNotFoundError); !ok {
+			t.Errorf("expected ObjectNotFoundError, got %T", err)
+		}
+		if mockedEngine.Calls("GetRows") != 1 {
+			t.Error("expected engine GetRows to be called")
+		}
+	})
+
+	t.Run("AsOfNoHistory", func(t *testing.T) {
+		model := &Model{name: "Tag", pk: "id", fields: Fields{}}
+		instance := newInstance(model, Values{"id": int32(1)})
+		if _, err := instance.AsOf(time.Now()); err == nil {
+			t.Error("expected model without history error")
+		}
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+		instance := loaded()
+		entry := func(id int32, action string, at time.Time) *Instance {
+			values, err := instance.historyValues("default", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Values are decoded as loaded from the JSON field.
+			data, _ := json.Marshal(values)
+			field := history.fields["new_values"]
+			doc := field.Value(NullJSON{JSON: data, Valid: true})
+			return newInstance(history, Values{
+				"id":         id,
+				"action":     action,
+				"new_values": doc,
+				"timestamp":  at,
+			})
+		}
+		created := entry(1, HistoryCreate, now.Add(-time.Hour))
+		instance.Set("views", int32(2000000))
+		instance.Set("published", now)
+		updated := entry(2, HistoryUpdate, now)
+		instance.Set("title", "Final")
+		last := entry(3, HistoryUpdate, now)
+		past, err := instance.replayHistory(
+			[]*Instance{last, updated, created},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if past.Get("title") != "Final" || past.Get("views") != int32(2000000) {
+			t.Errorf("expected replayed values, got %v", past.Container())
+		}
+		if published, ok := past.Get("published").(time.Time); !ok ||
+			!published.Equal(now) {
+			t.Errorf("expected published time, got %v", past.Get("published"))
+		}
+		deleted := newInstance(history, Values{
+			"id":        int32(4),
+			"action":    HistoryDelete,
+			"timestamp": now.Add(time.Minute),
+		})
+		_, err = instance.replayHistory(
+			[]*Instance{created, deleted, updated},
+		)
+		if _, ok := err.(*ObjectNotFoundError); !ok {
+			t.Errorf("expected ObjectNotFoundError, got %T", err)
+		}
+	})
+}
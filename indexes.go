@@ -0,0 +1,119 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Index represents an index definition supporting unique, partial, expression
+// and descending indexes. It's used as a value of the IndexDefinitions map.
+type Index struct {
+	// Fields is the list of indexed fields. A leading hyphen indicates
+	// descending order, e.g. "-created".
+	Fields []string
+	// Expressions is the list of indexed expressions, e.g. Lower("email").
+	Expressions []Expression
+	// Unique is true if the index values must be unique.
+	Unique bool
+	// Condition restricts the index to the matching rows if not nil.
+	Condition Conditioner
+	// Method is the postgres index method (e.g. gin or brin). It's ignored by
+	// other drivers.
+	Method string
+}
+
+// indexMarshaler is used to serialize indexes.
+type indexMarshaler struct {
+	Fields      []string        `json:",omitempty"`
+	Expressions []Expression    `json:",omitempty"`
+	Unique      bool            `json:",omitempty"`
+	Condition   json.RawMessage `json:",omitempty"`
+	Method      string          `json:",omitempty"`
+}
+
+// copy returns a deep copy of the index slices.
+func (idx Index) copy() Index {
+	fields := make([]string, len(idx.Fields))
+	copy(fields, idx.Fields)
+	idx.Fields = fields
+	if idx.Expressions != nil {
+		expressions := make([]Expression, len(idx.Expressions))
+		copy(expressions, idx.Expressions)
+		idx.Expressions = expressions
+	}
+	return idx
+}
+
+// IndexFields returns the names of the model fields involved in the index,
+// including those of the condition.
+func (idx Index) IndexFields() []string {
+	fields := make([]string, 0, len(idx.Fields))
+	for _, name := range idx.Fields {
+		fields = append(fields, strings.TrimPrefix(name, "-"))
+	}
+	if idx.Condition != nil {
+		fields = append(fields, conditionerFields(idx.Condition)...)
+	}
+	return fields
+}
+
+// Validate returns an error if the index definition is not valid for the
+// given model.
+func (idx Index) Validate(model *Model) error {
+	if len(idx.Fields) == 0 && len(idx.Expressions) == 0 {
+		return fmt.Errorf("index with no fields")
+	}
+	for _, name := range idx.Fields {
+		name = strings.TrimPrefix(name, "-")
+		if _, ok := model.fields[name]; !ok {
+			return fmt.Errorf("unknown indexed field: %s", name)
+		}
+		if model.isInherited(name) {
+			return fmt.Errorf("cannot index parent field: %s", name)
+		}
+	}
+	if idx.Condition != nil {
+		return validateConditioner(model, idx.Condition)
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (idx Index) MarshalJSON() ([]byte, error) {
+	result := indexMarshaler{
+		Fields:      idx.Fields,
+		Expressions: idx.Expressions,
+		Unique:      idx.Unique,
+		Method:      idx.Method,
+	}
+	if idx.Condition != nil {
+		data, err := MarshalConditioner(idx.Condition)
+		if err != nil {
+			return nil, err
+		}
+		result.Condition = data
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (idx *Index) UnmarshalJSON(data []byte) error {
+	r := indexMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	idx.Fields = r.Fields
+	idx.Expressions = r.Expressions
+	idx.Unique = r.Unique
+	idx.Method = r.Method
+	idx.Condition = nil
+	if len(r.Condition) > 0 {
+		cond, err := UnmarshalConditioner(r.Condition)
+		if err != nil {
+			return err
+		}
+		idx.Condition = cond
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package gomodel
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestIndexes tests the Index definition methods
+func TestIndexes(t *testing.T) {
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":      IntegerField{Auto: true},
+			"email":   CharField{MaxLength: 100},
+			"active":  BooleanField{},
+			"created": DateTimeField{},
+		},
+		meta: Options{Table: "users_user"},
+	}
+
+	t.Run("ValidateInvalidCondition", func(t *testing.T) {
+		idx := Index{
+			Fields:    []string{"email"},
+			Condition: Q{"deleted": false},
+		}
+		if err := idx.Validate(model); err == nil {
+			t.Error("expected unknown field error")
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		idx := Index{
+			Fields:    []string{"-created"},
+			Condition: Q{"active": true},
+		}
+		if err := idx.Validate(model); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("IndexFields", func(t *testing.T) {
+		idx := Index{
+			Fields:    []string{"email", "-created"},
+			Condition: Q{"active": true},
+		}
+		fields := idx.IndexFields()
+		expected := []string{"email", "created", "active"}
+		if len(fields) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, fields)
+		}
+		for i, name := range expected {
+			if fields[i] != name {
+				t.Errorf("expected %v, got %v", expected, fields)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		idx := Index{
+			Fields:      []string{"-created"},
+			Expressions: []Expression{Lower("email")},
+			Unique:      true,
+			Condition:   Q{"active": true},
+			Method:      "btree",
+		}
+		data, err := json.Marshal(idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := Index{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Unique || result.Method != "btree" {
+			t.Errorf("expected unique btree index, got %v", result)
+		}
+		if len(result.Expressions) != 1 || result.Condition == nil {
+			t.Errorf("expected expression and condition, got %v", result)
+		}
+		again, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("expected %s, got %s", data, again)
+		}
+	})
+
+	t.Run("ModelIndexesCopy", func(t *testing.T) {
+		model.meta.IndexDefinitions = IndexDefinitions{
+			"created_idx": {Fields: []string{"-created"}},
+		}
+		defer func() { model.meta.IndexDefinitions = nil }()
+		indexes := model.IndexDefinitions()
+		indexes["created_idx"].Fields[0] = "email"
+		idx := model.meta.IndexDefinitions["created_idx"]
+		if idx.Fields[0] != "-created" {
+			t.Errorf("expected model index to be unchanged")
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package gomodel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// mergeAbstract merges the fields, indexes, constraints, validation and soft
+// delete options of the given abstract model into the model definition.
+// Definitions of the model take precedence over the abstract ones, and the
+// "{model}" placeholder in index and constraint names is replaced by the
+// lowercase model name.
+func (m *Model) mergeAbstract(abstract *Model) {
+	if m.fields == nil {
+		m.fields = Fields{}
+	}
+	for name, field := range abstract.fields {
+		if _, ok := m.fields[name]; !ok {
+			m.fields[name] = field
+		}
+	}
+	lowerName := strings.ToLower(m.name)
+	for name, def := range abstract.meta.Indexes {
+		name = strings.Replace(name, "{model}", lowerName, -1)
+		if _, ok := m.meta.Indexes[name]; !ok {
+			m.meta.Indexes[name] = append([]string{}, def...)
+		}
+	}
+	for name, idx := range abstract.meta.IndexDefinitions {
+		name = strings.Replace(name, "{model}", lowerName, -1)
+		if _, ok := m.meta.IndexDefinitions[name]; !ok {
+			m.meta.IndexDefinitions[name] = idx.copy()
+		}
+	}
+	for name, c := range abstract.meta.Constraints {
+		name = strings.Replace(name, "{model}", lowerName, -1)
+		if _, ok := m.meta.Constraints[name]; !ok {
+			m.meta.Constraints[name] = c
+		}
+	}
+	if len(abstract.meta.Validators) > 0 {
+		validators := map[string][]ValidatorFunc{}
+		for name, funcs := range abstract.meta.Validators {
+			validators[name] = append(validators[name], funcs...)
+		}
+		for name, funcs := range m.meta.Validators {
+			validators[name] = append(validators[name], funcs...)
+		}
+		m.meta.Validators = validators
+	}
+	if m.meta.Container == nil {
+		m.meta.Container = abstract.meta.Container
+	}
+	if abstract.meta.ValidateOnSave {
+		m.meta.ValidateOnSave = true
+	}
+	if abstract.meta.SoftDelete {
+		m.meta.SoftDelete = true
+	}
+	if abstract.meta.History {
+		m.meta.History = true
+	}
+	if len(abstract.meta.GenericForeignKeys) > 0 {
+		gfks := map[string]GenericForeignKey{}
+		for name, gfk := range abstract.meta.GenericForeignKeys {
+			gfks[name] = gfk
+		}
+		for name, gfk := range m.meta.GenericForeignKeys {
+			gfks[name] = gfk
+		}
+		m.meta.GenericForeignKeys = gfks
+	}
+}
+
+// concreteParent returns the concrete model the model extends, nil if none.
+func (m Model) concreteParent() (*Model, error) {
+	var parent *Model
+	for _, base := range m.meta.Extends {
+		if base.meta.Abstract {
+			continue
+		}
+		if parent != nil {
+			return nil, fmt.Errorf("multiple concrete parents")
+		}
+		parent = base
+	}
+	return parent, nil
+}
+
+// setupProxy makes the model a proxy of the given concrete model, sharing its
+// table, fields, indexes and constraints.
+func (m *Model) setupProxy(target *Model) error {
+	if target == nil {
+		return fmt.Errorf("proxy model without concrete parent")
+	}
+	if target.proxyFor != nil {
+		target = target.proxyFor
+	}
+	if len(m.fields) > 0 {
+		return fmt.Errorf("proxy model cannot define fields")
+	}
+	hasIndexes := len(m.meta.Indexes) > 0 || len(m.meta.IndexDefinitions) > 0
+	if hasIndexes || len(m.meta.Constraints) > 0 {
+		return fmt.Errorf("proxy model cannot define indexes or constraints")
+	}
+	m.fields = target.fields
+	m.pk = target.pk
+	m.parent = target.parent
+	m.inherited = target.inherited
+	m.proxyFor = target
+	m.meta.Table = target.Table()
+	m.meta.Indexes = target.meta.Indexes
+	m.meta.IndexDefinitions = target.meta.IndexDefinitions
+	m.meta.Constraints = target.meta.Constraints
+	if m.meta.Container == nil {
+		m.meta.Container = target.meta.Container
+	}
+	m.meta.SoftDelete = target.meta.SoftDelete
+	m.meta.History = target.meta.History
+	m.meta.GenericForeignKeys = target.meta.GenericForeignKeys
+	m.history = target.history
+	return nil
+}
+
+// ProxyFor returns the concrete model proxied by the model, nil if the model
+// is not a proxy.
+func (m Model) ProxyFor() *Model {
+	return m.proxyFor
+}
+
+// Parent returns the concrete model the model inherits from, nil if none.
+func (m Model) Parent() *Model {
+	return m.parent
+}
+
+// ParentLink returns the name of the field holding the parent row pk, blank
+// if the model doesn't inherit from a concrete model.
+func (m Model) ParentLink() string {
+	if m.parent == nil {
+		return ""
+	}
+	return parentLinkName(m.parent)
+}
+
+// LocalFields returns the fields stored on the model table, excluding the ones
+// inherited from the concrete parent.
+func (m Model) LocalFields() Fields {
+	fields := Fields{}
+	for name, field := range m.fields {
+		if !m.inherited[name] {
+			fields[name] = field
+		}
+	}
+	return fields
+}
+
+// isInherited returns true if the named field is stored on a parent table.
+func (m Model) isInherited(name string) bool {
+	return m.inherited[name]
+}
+
+// pkName returns the name of the model pk field, which might not be set up yet
+// on migration states.
+func (m Model) pkName() string {
+	if m.pk != "" {
+		return m.pk
+	}
+	for name, field := range m.fields {
+		if field.IsPK() {
+			return name
+		}
+	}
+	return ""
+}
+
+// parentLinkName returns the name of the field linking a child model to the
+// given parent.
+func parentLinkName(parent *Model) string {
+	return fmt.Sprintf("%s_ptr", strings.ToLower(parent.name))
+}
+
+// parentLinkField returns the field holding the parent pk on the child table.
+func parentLinkField(pk Field) (Field, error) {
+	if rv := reflect.ValueOf(pk); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		pk = rv.Elem().Interface().(Field)
+	}
+	switch f := pk.(type) {
+	case IntegerField:
+		return IntegerField{Unique: true}, nil
+	case SmallIntegerField:
+		return SmallIntegerField{Unique: true}, nil
+	case BigIntegerField:
+		return BigIntegerField{Unique: true}, nil
+	case UUIDField:
+		return UUIDField{Unique: true}, nil
+	case CharField:
+		return CharField{MaxLength: f.MaxLength, Unique: true}, nil
+	}
+	return nil, fmt.Errorf("unsupported parent pk type: %T", pk)
+}
+
+// SetupParent makes the model inherit from the given concrete parent: the
+// parent fields are added to the model, stored on the parent table, and a
+// one-to-one link field named {parent}_ptr holds the parent row pk on the
+// model table. The parent pk becomes the model pk. If the model already
+// inherits from a parent, the inherited fields are refreshed.
+//
+// This method is automatically called when a model is registered and should
+// only be used to modify a model state during migration operations.
+func (m *Model) SetupParent(parent *Model) error {
+	if parent.meta.Abstract {
+		return fmt.Errorf("abstract parent: %s", parent.name)
+	}
+	pk := parent.pkName()
+	pkField, ok := parent.fields[pk]
+	if !ok {
+		return fmt.Errorf("parent without pk: %s", parent.name)
+	}
+	for name := range m.inherited {
+		delete(m.fields, name)
+	}
+	columns := map[string]bool{}
+	for name, field := range m.fields {
+		columns[field.DBColumn(name)] = true
+	}
+	inherited := map[string]bool{}
+	for name, field := range parent.fields {
+		if _, ok := m.fields[name]; ok {
+			return fmt.Errorf("field clashes with parent field: %s", name)
+		}
+		if columns[field.DBColumn(name)] {
+			return fmt.Errorf("column clashes with parent column: %s", name)
+		}
+		inherited[name] = true
+	}
+	link := parentLinkName(parent)
+	if _, ok := m.fields[link]; !ok {
+		field, err := parentLinkField(pkField)
+		if err != nil {
+			return err
+		}
+		m.fields[link] = field
+	}
+	for name := range inherited {
+		m.fields[name] = parent.fields[name]
+	}
+	m.inherited = inherited
+	m.parent = parent
+	m.pk = pk
+	return nil
+}
@@ -0,0 +1,299 @@
+package gomodel
+
+import (
+	"testing"
+)
+
+// TestAbstractModels tests the abstract model definitions merging
+func TestAbstractModels(t *testing.T) {
+	validator := func(val Value) error { return nil }
+	base := New(
+		"Timestamped",
+		Fields{
+			"created": DateTimeField{AutoNowAdd: true},
+			"name":    CharField{MaxLength: 50},
+		},
+		Options{
+			Abstract: true,
+			Indexes:  Indexes{"{model}_created_idx": []string{"created"}},
+			Constraints: Constraints{
+				"{model}_name_unique": UniqueConstraint{
+					Fields: []string{"name"},
+				},
+			},
+			Validators: map[string][]ValidatorFunc{
+				"name": {validator},
+			},
+			ValidateOnSave: true,
+		},
+	).Model
+
+	t.Run("Merge", func(t *testing.T) {
+		model := New(
+			"Post",
+			Fields{"name": CharField{MaxLength: 100}},
+			Options{
+				Extends:    []*Model{base},
+				Validators: map[string][]ValidatorFunc{"name": {validator}},
+			},
+		).Model
+		if _, ok := model.fields["created"]; !ok {
+			t.Error("expected created field to be inherited")
+		}
+		if f := model.fields["name"].(CharField); f.MaxLength != 100 {
+			t.Error("expected model field to take precedence")
+		}
+		if _, ok := model.meta.Indexes["post_created_idx"]; !ok {
+			t.Errorf("expected post_created_idx, got %v", model.meta.Indexes)
+		}
+		if _, ok := model.meta.Constraints["post_name_unique"]; !ok {
+			t.Error("expected post_name_unique constraint")
+		}
+		if n := len(model.meta.Validators["name"]); n != 2 {
+			t.Errorf("expected 2 name validators, got %d", n)
+		}
+		if !model.meta.ValidateOnSave {
+			t.Error("expected ValidateOnSave to be inherited")
+		}
+		if _, ok := base.meta.Indexes["post_created_idx"]; ok {
+			t.Error("abstract model indexes were modified")
+		}
+	})
+
+	t.Run("RegisterAbstract", func(t *testing.T) {
+		app := &Application{name: "blog", models: map[string]*Model{}}
+		if err := base.Register(app); err == nil {
+			t.Error("expected abstract model error")
+		}
+	})
+}
+
+// TestModelInheritance tests the multi-table inheritance setup
+func TestModelInheritance(t *testing.T) {
+	app := &Application{name: "users", models: map[string]*Model{}}
+	parent := &Model{
+		name: "User",
+		app:  app,
+		pk:   "id",
+		fields: Fields{
+			"id":    IntegerField{Auto: true},
+			"email": CharField{MaxLength: 100, Index: true},
+		},
+		meta: Options{Indexes: Indexes{}, Constraints: Constraints{}},
+	}
+	app.models["User"] = parent
+	newChild := func() *Model {
+		return New(
+			"Customer",
+			Fields{"address": CharField{MaxLength: 100}},
+			Options{Extends: []*Model{parent}},
+		).Model
+	}
+
+	t.Run("Register", func(t *testing.T) {
+		child := newChild()
+		if err := child.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		defer delete(app.models, "Customer")
+		if child.Parent() != parent {
+			t.Error("expected User parent")
+		}
+		if child.ParentLink() != "user_ptr" {
+			t.Errorf("expected user_ptr link, got %s", child.ParentLink())
+		}
+		if child.pk != "id" {
+			t.Errorf("expected id pk, got %s", child.pk)
+		}
+		link, ok := child.fields["user_ptr"].(IntegerField)
+		if !ok || !link.Unique {
+			t.Errorf("expected unique integer link, got %#v", link)
+		}
+		if _, ok := child.fields["email"]; !ok {
+			t.Error("expected email field to be inherited")
+		}
+		local := child.LocalFields()
+		if _, ok := local["email"]; ok {
+			t.Error("expected email not to be a local field")
+		}
+		if _, ok := local["user_ptr"]; !ok {
+			t.Error("expected user_ptr to be a local field")
+		}
+		if len(child.meta.Indexes) != 0 {
+			t.Errorf("expected no child indexes, got %v", child.meta.Indexes)
+		}
+	})
+
+	t.Run("UnregisteredParent", func(t *testing.T) {
+		other := &Application{name: "shop", models: map[string]*Model{}}
+		if err := newChild().Register(other); err == nil {
+			t.Error("expected parent not registered error")
+		}
+	})
+
+	t.Run("MultipleParents", func(t *testing.T) {
+		child := newChild()
+		child.meta.Extends = []*Model{parent, parent}
+		if err := child.Register(app); err == nil {
+			t.Error("expected multiple concrete parents error")
+		}
+	})
+
+	t.Run("FieldClash", func(t *testing.T) {
+		child := newChild()
+		child.fields["email"] = CharField{}
+		if err := child.SetupParent(parent); err == nil {
+			t.Error("expected field clash error")
+		}
+	})
+
+	t.Run("ColumnClash", func(t *testing.T) {
+		child := newChild()
+		child.fields["mail"] = CharField{Column: "email"}
+		if err := child.SetupParent(parent); err == nil {
+			t.Error("expected column clash error")
+		}
+	})
+
+	t.Run("DBAssignedField", func(t *testing.T) {
+		child := newChild()
+		child.fields["joined"] = DateTimeField{DBDefault: Now()}
+		if err := child.SetupParent(parent); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("RefreshParent", func(t *testing.T) {
+		child := newChild()
+		if err := child.SetupParent(parent); err != nil {
+			t.Fatal(err)
+		}
+		parent.fields["name"] = CharField{MaxLength: 50}
+		defer delete(parent.fields, "name")
+		if err := child.SetupParent(parent); err != nil {
+			t.Fatal(err)
+		}
+		if !child.isInherited("name") {
+			t.Error("expected name field to be inherited")
+		}
+	})
+
+	t.Run("RemoveParentField", func(t *testing.T) {
+		child := newChild()
+		if err := child.SetupParent(parent); err != nil {
+			t.Fatal(err)
+		}
+		if err := child.RemoveField("email"); err == nil {
+			t.Error("expected parent field error")
+		}
+	})
+
+	t.Run("IndexParentField", func(t *testing.T) {
+		child := newChild()
+		if err := child.SetupParent(parent); err != nil {
+			t.Fatal(err)
+		}
+		idx := Index{Fields: []string{"email"}}
+		if err := idx.Validate(child); err == nil {
+			t.Error("expected parent field index error")
+		}
+		c := UniqueConstraint{Fields: []string{"email"}}
+		if err := c.Validate(child); err == nil {
+			t.Error("expected parent field constraint error")
+		}
+	})
+}
+
+// TestProxyModels tests the proxy models setup
+func TestProxyModels(t *testing.T) {
+	app := &Application{name: "users", models: map[string]*Model{}}
+	user := New(
+		"User",
+		Fields{
+			"email":  CharField{MaxLength: 100, Index: true},
+			"active": BooleanField{},
+		},
+		Options{Container: Values{}},
+	).Model
+	if err := user.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	newProxy := func(fields Fields) *Dispatcher {
+		return New("ActiveUser", fields, Options{
+			Extends: []*Model{user},
+			Proxy:   true,
+			Filter:  Q{"active": true},
+		})
+	}
+
+	t.Run("Register", func(t *testing.T) {
+		proxy := newProxy(nil)
+		if err := proxy.Model.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		defer delete(app.models, "ActiveUser")
+		if proxy.Model.ProxyFor() != user {
+			t.Error("expected User proxied model")
+		}
+		if table := proxy.Model.Table(); table != user.Table() {
+			t.Errorf("expected %s table, got %s", user.Table(), table)
+		}
+		if proxy.Model.pk != "id" {
+			t.Errorf("expected id pk, got %s", proxy.Model.pk)
+		}
+		if _, ok := proxy.Model.fields["email"]; !ok {
+			t.Error("expected email field to be shared")
+		}
+		if len(proxy.Model.meta.Indexes) != len(user.meta.Indexes) {
+			t.Error("expected indexes to be shared")
+		}
+		if _, ok := proxy.Model.meta.Container.(Values); !ok {
+			t.Error("expected Values container to be inherited")
+		}
+		qs := proxy.Objects.All().(GenericQuerySet)
+		if _, ok := qs.cond.(Q); !ok {
+			t.Errorf("expected default filter, got %v", qs.cond)
+		}
+	})
+
+	t.Run("ProxyOfProxy", func(t *testing.T) {
+		proxy := newProxy(nil)
+		if err := proxy.Model.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		defer delete(app.models, "ActiveUser")
+		staff := New("StaffUser", nil, Options{
+			Extends: []*Model{proxy.Model},
+			Proxy:   true,
+		}).Model
+		if err := staff.Register(app); err != nil {
+			t.Fatal(err)
+		}
+		defer delete(app.models, "StaffUser")
+		if staff.ProxyFor() != user {
+			t.Error("expected User proxied model")
+		}
+	})
+
+	t.Run("NoParent", func(t *testing.T) {
+		proxy := New("ActiveUser", nil, Options{Proxy: true}).Model
+		if err := proxy.Register(app); err == nil {
+			t.Error("expected proxy without parent error")
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		proxy := newProxy(Fields{"name": CharField{}})
+		if err := proxy.Model.Register(app); err == nil {
+			t.Error("expected proxy with fields error")
+		}
+	})
+
+	t.Run("Indexes", func(t *testing.T) {
+		proxy := newProxy(nil)
+		proxy.Model.meta.Indexes = Indexes{"email_idx": []string{"email"}}
+		if err := proxy.Model.Register(app); err == nil {
+			t.Error("expected proxy with indexes error")
+		}
+	})
+}
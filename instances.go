@@ -12,6 +12,7 @@ import (
 type Instance struct {
 	model     *Model
 	container Container
+	tracker   *fieldTracker
 }
 
 // trace returns the ErrorTrace for the instance.
@@ -79,6 +80,11 @@ func (i Instance) Set(name string, val Value) error {
 	if !ok {
 		return &ContainerError{i.trace(fmt.Errorf("unknown field %s", name))}
 	}
+	if enum, ok := enumField(field); ok && val != nil {
+		if _, err := enum.DriverValue(val, ""); err != nil {
+			return &ContainerError{i.trace(err)}
+		}
+	}
 	if c, ok := i.container.(Setter); ok {
 		if err := c.Set(name, val, field); err != nil {
 			return &ContainerError{i.trace(err)}
@@ -93,6 +99,9 @@ func (i Instance) Set(name string, val Value) error {
 			return &ContainerError{i.trace(err)}
 		}
 	}
+	if i.tracker != nil {
+		i.tracker.set[name] = true
+	}
 	return nil
 }
 
@@ -117,7 +126,7 @@ func (i Instance) valueToSave(name string, creating bool) (Value, bool, error) {
 		err := fmt.Errorf("unknown field: %s", name)
 		return nil, false, err
 	}
-	if field.IsAuto() {
+	if field.IsAuto() || isGenerated(field) {
 		return nil, false, nil
 	}
 	if field.IsAutoNow() || creating && field.IsAutoNowAdd() {
@@ -127,7 +136,18 @@ func (i Instance) valueToSave(name string, creating bool) (Value, bool, error) {
 		}
 		return val, true, nil
 	}
-	if val, ok := getContainerField(i.container, name); ok {
+	val, ok := getContainerField(i.container, name)
+	if creating {
+		if gen, ok, err := generateValue(field, val); err != nil {
+			return nil, false, err
+		} else if ok {
+			if err := i.Set(name, gen); err != nil {
+				return nil, false, err
+			}
+			return gen, true, nil
+		}
+	}
+	if ok {
 		return val, true, nil
 	} else if val, hasDefault := field.DefaultValue(); creating && hasDefault {
 		if err := i.Set(name, val); err != nil {
@@ -152,6 +172,55 @@ func (i Instance) engine(target interface{}) (Engine, string) {
 	return nil, ""
 }
 
+// dbAssignedFields returns the names of the model fields whose values are
+// assigned by the database when the given values are inserted: generated
+// fields and missing fields with a DBDefault expression.
+func dbAssignedFields(model *Model, values Values) []string {
+	fields := []string{}
+	for name, field := range model.fields {
+		if isGenerated(field) {
+			fields = append(fields, name)
+		} else if _, ok := values[name]; !ok && dbDefault(field) != "" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// insertReturning inserts the given values on db and sets the instance fields
+// to the returned values.
+func (i Instance) insertReturning(
+	eng Engine,
+	values Values,
+	fields []string,
+) error {
+	rows, err := eng.InsertRowReturning(i.model, values, fields...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("insert returned no rows")
+	}
+	recipients := make([]interface{}, 0, len(fields))
+	for _, name := range fields {
+		recipients = append(recipients, i.model.fields[name].Recipient())
+	}
+	if err := rows.Scan(recipients...); err != nil {
+		return err
+	}
+	for k, name := range fields {
+		val := reflect.Indirect(reflect.ValueOf(recipients[k])).Interface()
+		if err := i.Set(name, i.model.fields[name].Value(val)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 // insertRow saves the given instance fields on db.
 func (i Instance) insertRow(
 	target interface{},
@@ -162,6 +231,15 @@ func (i Instance) insertRow(
 	if eng == nil {
 		return &DatabaseError{Trace: i.trace(fmt.Errorf("invalid target"))}
 	}
+	if isAutoGenerated(i.model.fields[i.model.pk]) {
+		hasPk := false
+		for _, name := range fields {
+			hasPk = hasPk || name == i.model.pk
+		}
+		if !hasPk {
+			fields = append(fields, i.model.pk)
+		}
+	}
 	dbValues := Values{}
 	for _, name := range fields {
 		if val, ok, err := i.valueToSave(name, true); err != nil {
@@ -170,27 +248,44 @@ func (i Instance) insertRow(
 			dbValues[name] = val
 		}
 	}
-	pk, err := eng.InsertRow(i.model, dbValues)
-	if err != nil {
-		return &DatabaseError{dbName, i.trace(err)}
-	}
-	if autoPk {
-		if err := i.Set("pk", pk); err != nil {
-			return err
+	if returning := dbAssignedFields(i.model, dbValues); len(returning) > 0 {
+		if autoPk {
+			returning = append([]string{i.model.pk}, returning...)
+		}
+		if err := i.insertReturning(eng, dbValues, returning); err != nil {
+			return &DatabaseError{dbName, i.trace(err)}
+		}
+	} else {
+		pk, err := eng.InsertRow(i.model, dbValues)
+		if err != nil {
+			return &DatabaseError{dbName, i.trace(err)}
+		}
+		if autoPk {
+			if err := i.Set("pk", pk); err != nil {
+				return err
+			}
 		}
 	}
+	if link := i.model.ParentLink(); link != "" {
+		return i.Set(link, i.Get("pk"))
+	}
 	return nil
 }
 
-// updateRow updates the given fields on db row matching pkVal.
+// updateRow updates the given fields on db row matching pkVal. The returned
+// boolean is true if no row was updated and a new one was inserted. For models
+// with a VersionField, the row must also match the instance version, which is
+// incremented, and a *StaleObjectError is returned if no row is updated for an
+// instance previously loaded or saved.
 func (i Instance) updateRow(
 	target interface{},
 	pkVal Value,
 	fields ...string,
-) error {
+) (bool, error) {
 	eng, dbName := i.engine(target)
 	if eng == nil {
-		return &DatabaseError{Trace: i.trace(fmt.Errorf("invalid target"))}
+		err := i.trace(fmt.Errorf("invalid target"))
+		return false, &DatabaseError{Trace: err}
 	}
 	dbValues := Values{}
 	for _, name := range fields {
@@ -199,48 +294,135 @@ func (i Instance) updateRow(
 		}
 		val, ok, err := i.valueToSave(name, false)
 		if err != nil {
-			return &ContainerError{i.trace(err)}
+			return false, &ContainerError{i.trace(err)}
 		} else if ok {
 			dbValues[name] = val
 		}
 	}
+	lock, version, err := i.nextVersion(pkVal)
+	if err != nil {
+		return false, &ContainerError{i.trace(err)}
+	}
 	options := QueryOptions{Conditioner: Q{"pk": pkVal}}
+	if lock != nil {
+		dbValues[versionField(i.model)] = version
+		options.Conditioner = lock
+	}
 	rows, err := eng.UpdateRows(i.model, dbValues, options)
 	if err != nil {
-		return &DatabaseError{dbName, i.trace(err)}
+		return false, &DatabaseError{dbName, i.trace(err)}
+	}
+	if rows == 0 && lock != nil && i.persisted() {
+		err := fmt.Errorf("object modified or deleted since loaded")
+		return false, &StaleObjectError{i.trace(err)}
+	} else if rows == 0 {
+		// The row may have been deleted, so every field must be inserted.
+		all := make([]string, 0, len(i.model.fields))
+		for name := range i.model.fields {
+			all = append(all, name)
+		}
+		return true, i.insertRow(target, false, all...)
 	}
-	if rows == 0 {
-		return i.insertRow(target, false, fields...)
+	if lock != nil {
+		name := versionField(i.model)
+		if err := i.Set(name, version); err != nil {
+			return false, err
+		}
+		i.snapshot(name)
 	}
-	return nil
+	return false, nil
 }
 
 // save propagates the values of the given fields to the given database target.
 func (i Instance) save(target interface{}, fields ...string) error {
-	if len(fields) == 0 {
+	pkField := i.model.fields[i.model.pk]
+	autoPk := pkField.IsAuto()
+	genPk := isAutoGenerated(pkField)
+	pkVal := i.Get("pk")
+	update := false
+	if pkVal != nil {
+		zero := reflect.Zero(reflect.TypeOf(pkVal)).Interface()
+		update = !((autoPk || genPk) && pkVal == zero)
+	}
+	if len(fields) == 0 && update && i.tracker != nil {
+		fields = i.ChangedFields()
+		if len(fields) == 0 {
+			return nil
+		}
+		for name, field := range i.model.fields {
+			if field.IsAutoNow() && !i.isChanged(name) {
+				fields = append(fields, name)
+			}
+		}
+	} else if len(fields) == 0 {
 		for name := range i.model.fields {
 			fields = append(fields, name)
 		}
 	}
-	autoPk := i.model.fields[i.model.pk].IsAuto()
-	pkVal := i.Get("pk")
-	if pkVal != nil {
-		zero := reflect.Zero(reflect.TypeOf(pkVal)).Interface()
-		if !(autoPk && pkVal == zero) {
-			return i.updateRow(target, pkVal, fields...)
+	if i.model.meta.ValidateOnSave {
+		if err := i.Validate(fields...); err != nil {
+			return err
+		}
+	}
+	_, dbName := i.engine(target)
+	event := Event{
+		Signal:   PreSave,
+		Instance: &i,
+		Created:  !update,
+		Database: dbName,
+		Fields:   fields,
+	}
+	if err := send(event); err != nil {
+		return err
+	}
+	logged := fields
+	if name := versionField(i.model); name != "" {
+		logged = append(logged[:len(logged):len(logged)], name)
+	}
+	old, err := i.historyValues(dbName, true, logged...)
+	if err != nil {
+		return &ContainerError{i.trace(err)}
+	}
+	err = i.atomicHistory(target, func(target interface{}) error {
+		if update {
+			created, err := i.updateRow(target, pkVal, fields...)
+			if err != nil {
+				return err
+			}
+			event.Created = created
+		} else if err := i.insertRow(target, autoPk, fields...); err != nil {
+			return err
 		}
+		eng, _ := i.engine(target)
+		return i.saveHistory(eng, dbName, event.Created, old, logged...)
+	})
+	if err != nil {
+		return err
+	}
+	if event.Created {
+		i.snapshot()
+	} else {
+		i.snapshot(fields...)
 	}
-	return i.insertRow(target, autoPk, fields...)
+	event.Signal = PostSave
+	return send(event)
 }
 
 // Save propagates the instance field values to the database. If no field names
-// are provided, all fields will be saved.
+// are provided, only the changed fields (see ChangedFields) and the AutoNow
+// ones are updated, and nothing is saved if no field has changed. New objects
+// are saved with all fields.
 //
 // The method will try to update the row matching the instance pk. If no row is
 // updated, a new one will be inserted.
 //
-// If the pk field is auto incremented and the pk has the zero value, a new
-// row will be inserted.
+// If the pk field is auto incremented or auto generated and the pk has the zero
+// value, a new row will be inserted.
+//
+// If the model ValidateOnSave option is true, the fields are validated first
+// and a *ValidationError is returned if any value is not valid.
+//
+// The PreSave and PostSave signals are sent before and after saving.
 func (i Instance) Save(fields ...string) error {
 	return i.save("default", fields...)
 }
@@ -251,8 +433,9 @@ func (i Instance) SaveOn(target interface{}, fields ...string) error {
 	return i.save(target, fields...)
 }
 
-// delete removes the object from the given database target.
-func (i Instance) delete(target interface{}) error {
+// delete removes the object from the given database target, or sets its
+// deletion time if the model is soft deleted and hard is false.
+func (i Instance) delete(target interface{}, hard bool) error {
 	eng, dbName := i.engine(target)
 	if eng == nil {
 		return &DatabaseError{dbName, i.trace(fmt.Errorf("invalid target"))}
@@ -261,17 +444,124 @@ func (i Instance) delete(target interface{}) error {
 	if !ok {
 		return &ContainerError{Trace: i.trace(fmt.Errorf("pk not found"))}
 	}
-	_, err := eng.DeleteRows(i.model, QueryOptions{Conditioner: Q{"pk": pkVal}})
-	return err
+	event := Event{Signal: PreDelete, Instance: &i, Database: dbName}
+	if err := send(event); err != nil {
+		return err
+	}
+	err := i.atomicHistory(target, func(target interface{}) error {
+		eng, _ := i.engine(target)
+		if i.model.meta.SoftDelete && !hard {
+			return i.softDelete(eng, dbName, pkVal)
+		}
+		old, err := i.historyValues(dbName, false)
+		if err != nil {
+			return &ContainerError{i.trace(err)}
+		}
+		options := QueryOptions{Conditioner: Q{"pk": pkVal}}
+		if _, err := eng.DeleteRows(i.model, options); err != nil {
+			return err
+		}
+		return i.recordHistory(eng, dbName, HistoryDelete, old, nil)
+	})
+	if err != nil {
+		return err
+	}
+	event.Signal = PostDelete
+	return send(event)
 }
 
-// Delete removes the object from the table on the default database.
+// Delete removes the object from the table on the default database. The
+// PreDelete and PostDelete signals are sent before and after deleting.
+//
+// If the model SoftDelete option is true, the row is kept and the deleted_at
+// field is set to the current time instead.
 func (i Instance) Delete() error {
-	return i.delete("default")
+	return i.delete("default", false)
 }
 
 // Delete removes the object from the table on the given target, that can be a
 // *Transaction or a string representing a database identifier.
 func (i Instance) DeleteOn(target interface{}) error {
-	return i.delete(target)
+	return i.delete(target, false)
+}
+
+// refresh reloads the given fields from the given database target into the
+// instance container.
+func (i Instance) refresh(target interface{}, fields ...string) error {
+	eng, dbName := i.engine(target)
+	if eng == nil {
+		return &DatabaseError{dbName, i.trace(fmt.Errorf("invalid target"))}
+	}
+	pkVal, ok := i.GetIf("pk")
+	if !ok {
+		return &ContainerError{Trace: i.trace(fmt.Errorf("pk not found"))}
+	}
+	if len(fields) == 0 {
+		for name := range i.model.fields {
+			fields = append(fields, name)
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for _, name := range fields {
+		if name == "pk" {
+			name = i.model.pk
+		}
+		if _, ok := i.model.fields[name]; !ok {
+			err := fmt.Errorf("unknown field: %s", name)
+			return &ContainerError{i.trace(err)}
+		}
+		names = append(names, name)
+	}
+	recipients := getRecipients(i.container, names, i.model)
+	if len(recipients) != len(names) {
+		err := fmt.Errorf("invalid container recipients")
+		return &ContainerError{i.trace(err)}
+	}
+	options := QueryOptions{
+		Conditioner: Q{"pk": pkVal},
+		Fields:      names,
+		Start:       0,
+		End:         1,
+	}
+	rows, err := eng.GetRows(i.model, options)
+	if err != nil {
+		return &DatabaseError{dbName, i.trace(err)}
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return &DatabaseError{dbName, i.trace(err)}
+		}
+		err := fmt.Errorf("object does not exist")
+		return &ObjectNotFoundError{i.trace(err)}
+	}
+	if err := rows.Scan(recipients...); err != nil {
+		return &ContainerError{i.trace(err)}
+	}
+	if _, ok := i.container.(Setter); ok {
+		for k, name := range names {
+			val := reflect.Indirect(reflect.ValueOf(recipients[k])).Interface()
+			if err := i.Set(name, i.model.fields[name].Value(val)); err != nil {
+				return err
+			}
+		}
+	}
+	i.snapshot(names...)
+	return nil
+}
+
+// Refresh reloads the values of the given fields, or all of them if none is
+// provided, from the object row on the default database. Unsaved changes of
+// the refreshed fields are discarded.
+//
+// If the row doesn't exist, *ObjectNotFoundError is returned.
+func (i Instance) Refresh(fields ...string) error {
+	return i.refresh("default", fields...)
+}
+
+// RefreshOn works as Refresh, but the values are reloaded from the given
+// target, that can be a *Transaction or a string representing a database
+// identifier.
+func (i Instance) RefreshOn(target interface{}, fields ...string) error {
+	return i.refresh(target, fields...)
 }
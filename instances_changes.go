@@ -0,0 +1,162 @@
+package gomodel
+
+import (
+	"reflect"
+	"sort"
+)
+
+// fieldTracker holds the field values of an instance when it was loaded or
+// last saved, and the fields set since then.
+type fieldTracker struct {
+	original Values
+	set      map[string]bool
+}
+
+// newInstance returns an instance of the given model tracking the changes of
+// its field values.
+func newInstance(model *Model, container Container) *Instance {
+	tracker := &fieldTracker{original: Values{}, set: map[string]bool{}}
+	return &Instance{model: model, container: container, tracker: tracker}
+}
+
+// snapshot records the current values of the given fields as the original
+// ones, or all the model fields if none is provided.
+func (i Instance) snapshot(fields ...string) {
+	if i.tracker == nil {
+		return
+	}
+	if len(fields) == 0 {
+		for name := range i.model.fields {
+			fields = append(fields, name)
+		}
+	}
+	for _, name := range fields {
+		if name == "pk" {
+			name = i.model.pk
+		}
+		if val, ok := i.GetIf(name); ok {
+			i.tracker.original[name] = copyValue(val)
+		}
+		delete(i.tracker.set, name)
+	}
+}
+
+// copyValue returns a deep copy of the given value, so that in place changes
+// of the original maps and slices are detected.
+func copyValue(val Value) Value {
+	if val == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(val)).Interface()
+}
+
+// deepCopy returns a copy of the given value, recursively copying maps, slices,
+// pointers and exported struct fields.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			c.SetMapIndex(key, deepCopy(v.MapIndex(key)))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for k := 0; k < v.Len(); k++ {
+			c.Index(k).Set(deepCopy(v.Index(k)))
+		}
+		return c
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		if v.Kind() == reflect.Ptr {
+			c = reflect.New(v.Type().Elem())
+			c.Elem().Set(deepCopy(v.Elem()))
+		} else {
+			c.Set(deepCopy(v.Elem()))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for k := 0; k < v.NumField(); k++ {
+			if c.Field(k).CanSet() {
+				c.Field(k).Set(deepCopy(v.Field(k)))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// persisted returns true if the instance was loaded from or saved to the
+// database, according to its tracked original values.
+func (i Instance) persisted() bool {
+	if i.tracker == nil {
+		return false
+	}
+	_, ok := i.tracker.original[i.model.pk]
+	return ok
+}
+
+// isChanged returns true if the named field value has changed since the
+// instance was loaded or last saved.
+func (i Instance) isChanged(name string) bool {
+	val, ok := i.GetIf(name)
+	if !ok {
+		return false
+	}
+	if i.tracker == nil {
+		return true
+	}
+	original, ok := i.tracker.original[name]
+	if !ok {
+		return i.tracker.set[name]
+	}
+	return !reflect.DeepEqual(original, val)
+}
+
+// ChangedFields returns the sorted names of the fields whose values have
+// changed since the instance was loaded or last saved. Fields not loaded from
+// the database are only considered changed if set with the Set method.
+func (i Instance) ChangedFields() []string {
+	fields := []string{}
+	for name := range i.model.fields {
+		if i.isChanged(name) {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Changed returns true if any field value has changed since the instance was
+// loaded or last saved.
+func (i Instance) Changed() bool {
+	for name := range i.model.fields {
+		if i.isChanged(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Original returns the value of the named field when the instance was loaded
+// or last saved, nil if not found.
+func (i Instance) Original(name string) Value {
+	if i.tracker == nil {
+		return nil
+	}
+	if name == "pk" {
+		name = i.model.pk
+	}
+	return i.tracker.original[name]
+}
@@ -0,0 +1,196 @@
+package gomodel
+
+import (
+	"testing"
+)
+
+// TestInstanceChanges tests the tracking of instance field changes
+func TestInstanceChanges(t *testing.T) {
+	// Model setup
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":      IntegerField{Auto: true},
+			"email":   CharField{MaxLength: 100},
+			"name":    CharField{MaxLength: 100, Null: true},
+			"updated": DateTimeField{AutoNow: true},
+		},
+		meta: Options{Container: Values{}},
+	}
+	loaded := func() *Instance {
+		instance := newInstance(model, Values{
+			"id":    int32(1),
+			"email": "user@test.com",
+			"name":  "User",
+		})
+		instance.snapshot("id", "email", "name")
+		return instance
+	}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+
+	t.Run("Loaded", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{1}
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: Values{},
+			fields:    []string{"id", "email"},
+		}
+		instance, err := qs.Get(Q{"id": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if instance.Changed() {
+			t.Errorf("expected no changes, got %v", instance.ChangedFields())
+		}
+		if email := instance.Original("email"); email != "user@test.com" {
+			t.Errorf("expected original email, got %v", email)
+		}
+	})
+
+	t.Run("New", func(t *testing.T) {
+		instance := newInstance(model, Values{})
+		if err := instance.Set("email", "user@test.com"); err != nil {
+			t.Fatal(err)
+		}
+		if !instance.Changed() {
+			t.Error("expected new instance to be changed")
+		}
+		if instance.Original("email") != nil {
+			t.Error("expected no original email")
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		instance := loaded()
+		if err := instance.Set("email", "new@test.com"); err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Set("name", "User"); err != nil {
+			t.Fatal(err)
+		}
+		fields := instance.ChangedFields()
+		if len(fields) != 1 || fields[0] != "email" {
+			t.Errorf("expected email change, got %v", fields)
+		}
+		if email := instance.Original("email"); email != "user@test.com" {
+			t.Errorf("expected original email, got %v", email)
+		}
+	})
+
+	t.Run("SetNotLoaded", func(t *testing.T) {
+		instance := newInstance(model, Values{"id": int32(1)})
+		instance.snapshot("id")
+		if instance.Changed() {
+			t.Errorf("expected no changes, got %v", instance.ChangedFields())
+		}
+		if err := instance.Set("name", nil); err != nil {
+			t.Fatal(err)
+		}
+		fields := instance.ChangedFields()
+		if len(fields) != 1 || fields[0] != "name" {
+			t.Errorf("expected name change, got %v", fields)
+		}
+	})
+
+	t.Run("SaveUnchanged", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := loaded().Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("UpdateRows") != 0 {
+			t.Error("expected engine UpdateRows not to be called")
+		}
+	})
+
+	t.Run("SaveChanged", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		instance := loaded()
+		if err := instance.Set("email", "new@test.com"); err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		values := mockedEngine.Args.UpdateRows.Values
+		if len(values) != 2 {
+			t.Errorf("expected email and updated values, got %v", values)
+		}
+		if _, ok := values["name"]; ok {
+			t.Error("expected unchanged name not to be saved")
+		}
+		if instance.Changed() {
+			t.Errorf("expected no changes, got %v", instance.ChangedFields())
+		}
+		if email := instance.Original("email"); email != "new@test.com" {
+			t.Errorf("expected saved email as original, got %v", email)
+		}
+	})
+
+	t.Run("SaveInsertFallback", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := loaded()
+		if err := instance.Set("email", "new@test.com"); err != nil {
+			t.Fatal(err)
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		values := mockedEngine.Args.InsertRow.Values
+		if values["email"] != "new@test.com" || values["name"] != "User" {
+			t.Errorf("expected all field values, got %v", values)
+		}
+	})
+
+	t.Run("SaveFields", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		if err := loaded().Save("name"); err != nil {
+			t.Fatal(err)
+		}
+		values := mockedEngine.Args.UpdateRows.Values
+		if _, ok := values["name"]; !ok || len(values) != 1 {
+			t.Errorf("expected name value, got %v", values)
+		}
+	})
+
+	t.Run("ChangedInPlace", func(t *testing.T) {
+		docModel := &Model{
+			name: "Doc",
+			pk:   "id",
+			fields: Fields{
+				"id":   IntegerField{Auto: true},
+				"meta": JSONField{},
+				"tags": ArrayField{Base: CharField{}},
+			},
+			meta: Options{Container: Values{}},
+		}
+		instance := newInstance(docModel, Values{
+			"id":   int32(1),
+			"meta": map[string]interface{}{"plan": "free"},
+			"tags": []string{"go"},
+		})
+		instance.snapshot()
+		instance.Get("meta").(map[string]interface{})["plan"] = "pro"
+		instance.Get("tags").([]string)[0] = "sql"
+		fields := instance.ChangedFields()
+		if len(fields) != 2 || fields[0] != "meta" || fields[1] != "tags" {
+			t.Errorf("expected meta and tags changes, got %v", fields)
+		}
+	})
+
+	t.Run("NotTracked", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{"id": 1}}
+		fields := instance.ChangedFields()
+		if len(fields) != 1 || fields[0] != "id" {
+			t.Errorf("expected id change, got %v", fields)
+		}
+	})
+}
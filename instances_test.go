@@ -22,7 +22,10 @@ func TestInstance(t *testing.T) {
 		Time:  time.Date(1942, 11, 27, 0, 0, 0, 0, time.UTC),
 		Valid: true,
 	}
-	instance := Instance{model, Values{"email": "user@test.com", "dob": dob}}
+	instance := Instance{
+		model:     model,
+		container: Values{"email": "user@test.com", "dob": dob},
+	}
 
 	t.Run("Model", func(t *testing.T) {
 		if instance.Model() != model {
@@ -169,6 +172,29 @@ func TestInstance(t *testing.T) {
 		}
 	})
 
+	t.Run("SetInvalidEnumValue", func(t *testing.T) {
+		model.fields["status"] = EnumField{
+			Values: []Value{testStatusActive, testStatusInactive},
+		}
+		defer delete(model.fields, "status")
+		if err := instance.Set("status", "deleted"); err == nil {
+			t.Error("expected invalid enum value error")
+		}
+	})
+
+	t.Run("SetEnumValue", func(t *testing.T) {
+		model.fields["status"] = EnumField{
+			Values: []Value{testStatusActive, testStatusInactive},
+		}
+		defer delete(model.fields, "status")
+		if err := instance.Set("status", testStatusInactive); err != nil {
+			t.Fatal(err)
+		}
+		if val := instance.Get("status"); val != testStatusInactive {
+			t.Errorf("expected testStatusInactive, got %#v", val)
+		}
+	})
+
 	t.Run("SetInvalidValues", func(t *testing.T) {
 		instance.container = Values{"email": "user@test.com", "dob": dob}
 		err := instance.SetValues(Values{"active": 42})
@@ -226,7 +252,7 @@ func TestInstanceSave(t *testing.T) {
 			"updated": DateTimeField{AutoNow: true},
 		},
 	}
-	instance := Instance{model, Values{}}
+	instance := Instance{model: model, container: Values{}}
 	// DB Setup
 	engine, _ := enginesRegistry["mocker"].Start(Database{})
 	mockedEngine := engine.(MockedEngine)
@@ -491,4 +517,244 @@ func TestInstanceSave(t *testing.T) {
 			t.Errorf("expected DatabaseError, got %T", err)
 		}
 	})
+
+	t.Run("InsertDBDefault", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRowReturning.Rows = &rowsMocker{1}
+		dbModel := &Model{
+			name: "User",
+			pk:   "id",
+			fields: Fields{
+				"id": IntegerField{Auto: true, PrimaryKey: true},
+				"email": CharField{
+					MaxLength: 100, DBDefault: Literal("user@test.com"),
+				},
+			},
+		}
+		instance := Instance{model: dbModel, container: Values{}}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected engine InsertRow method not to be called")
+		}
+		args := mockedEngine.Args.InsertRowReturning
+		if len(args.Fields) != 2 || args.Fields[0] != "id" {
+			t.Fatalf("expected id and email fields, got %v", args.Fields)
+		}
+		if _, ok := args.Values["email"]; ok {
+			t.Error("expected email not to be inserted")
+		}
+		if email := instance.Get("email"); email != "user@test.com" {
+			t.Errorf("expected user@test.com, got %v", email)
+		}
+		if id := instance.Get("id"); id != int32(1) {
+			t.Errorf("expected id to be 1, got %v", id)
+		}
+	})
+
+	t.Run("InsertDBDefaultError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRowReturning.Err = fmt.Errorf("db error")
+		dbModel := &Model{
+			name: "User",
+			pk:   "id",
+			fields: Fields{
+				"id":    IntegerField{Auto: true, PrimaryKey: true},
+				"email": CharField{MaxLength: 100, DBDefault: Literal("")},
+			},
+		}
+		instance := Instance{model: dbModel, container: Values{}}
+		if _, ok := instance.Save().(*DatabaseError); !ok {
+			t.Error("expected DatabaseError")
+		}
+	})
+
+	t.Run("InsertGeneratedPK", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRow.Id = 23
+		uuidModel := &Model{
+			name: "Token",
+			pk:   "id",
+			fields: Fields{
+				"id":    UUIDField{PrimaryKey: true, AutoGenerate: true},
+				"email": CharField{MaxLength: 100},
+			},
+		}
+		container := &struct {
+			Id    string
+			Email string
+		}{Email: "user@test.com"}
+		instance := Instance{model: uuidModel, container: container}
+		if err := instance.Save("email"); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 1 {
+			t.Fatal("expected engine InsertRow method to be called")
+		}
+		if mockedEngine.Calls("UpdateRows") != 0 {
+			t.Error("expected engine UpdateRows method not to be called")
+		}
+		id, ok := mockedEngine.Args.InsertRow.Values["id"].(string)
+		if !ok || len(id) != 36 {
+			t.Fatalf("expected generated uuid, got %v", id)
+		}
+		if container.Id != id {
+			t.Errorf("expected instance id to be %s, got %s", id, container.Id)
+		}
+	})
+
+	t.Run("UpdateGeneratedPK", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		uuidModel := &Model{
+			name: "Token",
+			pk:   "id",
+			fields: Fields{
+				"id":    UUIDField{PrimaryKey: true, AutoGenerate: true},
+				"email": CharField{MaxLength: 100},
+			},
+		}
+		id := "2b1c9b3e-5d3a-4f5e-9a43-0d2a4d6f8c11"
+		instance := Instance{
+			model:     uuidModel,
+			container: Values{"id": id, "email": "a@b.c"},
+		}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("UpdateRows") != 1 {
+			t.Fatal("expected engine UpdateRows method to be called")
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected engine InsertRow method not to be called")
+		}
+		if instance.Get("id") != id {
+			t.Errorf("expected id to be %s, got %s", id, instance.Get("id"))
+		}
+	})
+
+	t.Run("ValidateOnSave", func(t *testing.T) {
+		mockedEngine.Reset()
+		validModel := &Model{
+			name: "User",
+			pk:   "id",
+			fields: Fields{
+				"id":    IntegerField{Auto: true, PrimaryKey: true},
+				"email": CharField{MaxLength: 10},
+			},
+			meta: Options{ValidateOnSave: true},
+		}
+		instance := Instance{
+			model:     validModel,
+			container: Values{"email": "user@test.com"},
+		}
+		err := instance.Save()
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected engine InsertRow method not to be called")
+		}
+		instance.container = Values{"email": "user@a.co"}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("InsertRow") != 1 {
+			t.Error("expected engine InsertRow method to be called")
+		}
+	})
+}
+
+// TestInstanceRefresh tests the Instance refresh methods
+func TestInstanceRefresh(t *testing.T) {
+	// Model setup
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":    IntegerField{Auto: true},
+			"email": CharField{MaxLength: 100},
+		},
+		meta: Options{Container: Values{}},
+	}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{"id": 1}}
+		err := instance.RefreshOn("slave")
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("NoPK", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{}}
+		err := instance.Refresh()
+		if _, ok := err.(*ContainerError); !ok {
+			t.Errorf("expected ContainerError, got %T", err)
+		}
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{"id": 1}}
+		err := instance.Refresh("name")
+		if _, ok := err.(*ContainerError); !ok {
+			t.Errorf("expected ContainerError, got %T", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{}
+		instance := Instance{model: model, container: Values{"id": 1}}
+		err := instance.Refresh()
+		if _, ok := err.(*ObjectNotFoundError); !ok {
+			t.Errorf("expected ObjectNotFoundError, got %T", err)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{1}
+		instance := newInstance(model, Values{"id": 1, "email": "old@test.com"})
+		if err := instance.Refresh("id", "email"); err != nil {
+			t.Fatal(err)
+		}
+		opt := mockedEngine.Args.GetRows.Options
+		if _, ok := opt.Conditioner.Conditions()["pk"]; !ok {
+			t.Error("query is missing pk condition")
+		}
+		if email := instance.Get("email"); email != "user@test.com" {
+			t.Errorf("expected user@test.com, got %v", email)
+		}
+		if instance.Changed() {
+			t.Errorf("expected no changes, got %v", instance.ChangedFields())
+		}
+	})
+
+	t.Run("StructOnTx", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.GetRows.Rows = &rowsMocker{1}
+		type userContainer struct {
+			Id    int32
+			Email string
+		}
+		user := &userContainer{Id: 1}
+		instance := Instance{model: model, container: user}
+		tx := &Transaction{Engine: mockedEngine, DB: Database{id: "default"}}
+		if err := instance.RefreshOn(tx, "id", "email"); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("GetRows") != 1 {
+			t.Fatal("expected engine GetRows method to be called")
+		}
+		if user.Email != "user@test.com" {
+			t.Errorf("expected user@test.com, got %s", user.Email)
+		}
+	})
 }
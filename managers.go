@@ -2,6 +2,8 @@ package gomodel
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"time"
 )
 
@@ -28,10 +30,56 @@ func (m Manager) engine(target interface{}) (Engine, string) {
 	return nil, ""
 }
 
+// valuesNames returns the sorted names of the given values.
+func valuesNames(values Values) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// insert inserts the given values of the new instance on the given engine and
+// records its creation on the model history.
+func (m Manager) insert(
+	engine Engine,
+	dbName string,
+	instance *Instance,
+	dbValues Values,
+) error {
+	returning := dbAssignedFields(m.Model, dbValues)
+	if len(returning) > 0 {
+		if m.Model.fields[m.Model.pk].IsAuto() {
+			returning = append([]string{m.Model.pk}, returning...)
+		}
+		err := instance.insertReturning(engine, dbValues, returning)
+		if err != nil {
+			return &DatabaseError{dbName, instance.trace(err)}
+		}
+	} else {
+		pk, err := engine.InsertRow(m.Model, dbValues)
+		if err != nil {
+			return &DatabaseError{dbName, instance.trace(err)}
+		}
+		if m.Model.fields[m.Model.pk].IsAuto() {
+			if err := instance.Set(m.Model.pk, pk); err != nil {
+				return err
+			}
+		}
+	}
+	if link := m.Model.ParentLink(); link != "" {
+		if err := instance.Set(link, instance.Get("pk")); err != nil {
+			return err
+		}
+	}
+	return instance.saveHistory(engine, dbName, true, nil)
+}
+
 // create adds a new object on the given target.
 func (m Manager) create(tar interface{}, values Container) (*Instance, error) {
 	container := m.Model.Container()
-	instance := &Instance{m.Model, container}
+	instance := newInstance(m.Model, container)
 	if !isValidContainer(values) {
 		err := fmt.Errorf("invalid values container")
 		return nil, &ContainerError{instance.trace(err)}
@@ -43,13 +91,20 @@ func (m Manager) create(tar interface{}, values Container) (*Instance, error) {
 	}
 	dbValues := Values{}
 	for name, field := range m.Model.fields {
-		if field.IsAuto() {
+		if field.IsAuto() || isGenerated(field) {
 			continue
 		}
 		var dbVal Value
+		val, ok := getContainerField(values, name)
+		gen, isGen, err := generateValue(field, val)
+		if err != nil {
+			return nil, &ContainerError{instance.trace(err)}
+		}
 		if field.IsAutoNowAdd() {
 			dbVal = time.Now()
-		} else if val, ok := getContainerField(values, name); ok {
+		} else if isGen {
+			dbVal = gen
+		} else if ok {
 			dbVal = val
 		} else if val, hasDefault := field.DefaultValue(); hasDefault {
 			dbVal = val
@@ -61,20 +116,62 @@ func (m Manager) create(tar interface{}, values Container) (*Instance, error) {
 			}
 		}
 	}
-	pk, err := engine.InsertRow(m.Model, dbValues)
-	if err != nil {
-		return instance, &DatabaseError{dbName, instance.trace(err)}
-	}
-	if m.Model.fields[m.Model.pk].IsAuto() {
-		if err := instance.Set(m.Model.pk, pk); err != nil {
+	if m.Model.meta.ValidateOnSave {
+		if err := instance.FullClean(); err != nil {
 			return nil, err
 		}
 	}
+	before := Values{}
+	for name := range m.Model.fields {
+		if val, ok := getContainerField(container, name); ok {
+			before[name] = copyValue(val)
+		}
+	}
+	event := Event{
+		Signal:   PreSave,
+		Instance: instance,
+		Created:  true,
+		Database: dbName,
+		Fields:   valuesNames(dbValues),
+	}
+	if err := send(event); err != nil {
+		return nil, err
+	}
+	// The values changed by PreSave receivers are read from the instance.
+	for name, field := range m.Model.fields {
+		if field.IsAuto() || isGenerated(field) {
+			continue
+		}
+		val, _ := getContainerField(container, name)
+		if reflect.DeepEqual(val, before[name]) {
+			continue
+		}
+		if val != nil {
+			dbValues[name] = val
+		} else {
+			delete(dbValues, name)
+		}
+	}
+	event.Fields = valuesNames(dbValues)
+	err := instance.atomicHistory(tar, func(tar interface{}) error {
+		engine, _ := m.engine(tar)
+		return m.insert(engine, dbName, instance, dbValues)
+	})
+	if err != nil {
+		return instance, err
+	}
+	instance.snapshot()
+	event.Signal = PostSave
+	if err := send(event); err != nil {
+		return instance, err
+	}
 	return instance, nil
 }
 
 // Create makes a new object with the given values, saves it to the default
-// database and returns the instance representing the object.
+// database and returns the instance representing the object. If the model
+// ValidateOnSave option is true, a *ValidationError is returned for invalid
+// values. The PreSave and PostSave signals are sent with the Created flag.
 func (m Manager) Create(values Container) (*Instance, error) {
 	return m.create("default", values)
 }
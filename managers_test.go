@@ -124,6 +124,129 @@ func TestManager(t *testing.T) {
 		}
 	})
 
+	t.Run("CreatePreSaveChanges", func(t *testing.T) {
+		mockedEngine.Reset()
+		disconnect := Connect(PreSave, model, func(e Event) error {
+			return e.Instance.Set("email", "changed@test.com")
+		})
+		defer disconnect()
+		_, err := manager.Create(Values{"email": "user@test.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		email := mockedEngine.Args.InsertRow.Values["email"]
+		if email != "changed@test.com" {
+			t.Errorf("expected changed@test.com, got %v", email)
+		}
+	})
+
+	t.Run("CreateGeneratedPK", func(t *testing.T) {
+		mockedEngine.Reset()
+		uuidManager := Manager{
+			Model: &Model{
+				name: "Token",
+				pk:   "id",
+				fields: Fields{
+					"id":    UUIDField{PrimaryKey: true, AutoGenerate: true},
+					"email": CharField{MaxLength: 100},
+				},
+				meta: Options{Container: Values{}},
+			},
+			QuerySet: mockedQuerySet{calls: map[string]int{}},
+		}
+		instance, err := uuidManager.Create(Values{"email": "user@test.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, ok := mockedEngine.Args.InsertRow.Values["id"].(string)
+		if !ok || len(id) != 36 {
+			t.Fatalf("expected generated uuid, got %v", id)
+		}
+		if instance.Get("id") != id {
+			t.Errorf("expected id to be %s, got %s", id, instance.Get("id"))
+		}
+	})
+
+	t.Run("CreateGeneratedField", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRowReturning.Rows = &rowsMocker{1}
+		genManager := Manager{
+			Model: &Model{
+				name: "User",
+				pk:   "id",
+				fields: Fields{
+					"id": IntegerField{Auto: true},
+					"domain": GeneratedField{
+						Base:       CharField{MaxLength: 100},
+						Expression: `lower("email")`,
+					},
+				},
+				meta: Options{Container: Values{}},
+			},
+			QuerySet: mockedQuerySet{calls: map[string]int{}},
+		}
+		instance, err := genManager.Create(Values{"domain": "test.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.InsertRowReturning
+		if _, ok := args.Values["domain"]; ok {
+			t.Error("expected generated field not to be inserted")
+		}
+		if domain := instance.Get("domain"); domain != "user@test.com" {
+			t.Errorf("expected returned domain value, got %v", domain)
+		}
+	})
+
+	t.Run("CreateGivenPK", func(t *testing.T) {
+		mockedEngine.Reset()
+		uuidManager := Manager{
+			Model: &Model{
+				name: "Token",
+				pk:   "id",
+				fields: Fields{
+					"id": UUIDField{PrimaryKey: true, AutoGenerate: true},
+				},
+				meta: Options{Container: Values{}},
+			},
+			QuerySet: mockedQuerySet{calls: map[string]int{}},
+		}
+		id := "2b1c9b3e-5d3a-4f5e-9a43-0d2a4d6f8c11"
+		if _, err := uuidManager.Create(Values{"id": id}); err != nil {
+			t.Fatal(err)
+		}
+		if val := mockedEngine.Args.InsertRow.Values["id"]; val != id {
+			t.Errorf("expected id to be %s, got %v", id, val)
+		}
+	})
+
+	t.Run("CreateValidateOnSave", func(t *testing.T) {
+		mockedEngine.Reset()
+		validManager := Manager{
+			Model: &Model{
+				name: "User",
+				pk:   "id",
+				fields: Fields{
+					"id":    IntegerField{Auto: true},
+					"email": CharField{MaxLength: 100},
+				},
+				meta: Options{Container: Values{}, ValidateOnSave: true},
+			},
+			QuerySet: mockedQuerySet{calls: map[string]int{}},
+		}
+		_, err := validManager.Create(Values{"email": ""})
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if _, ok := verr.Errors["email"]; !ok {
+			t.Error("expected email validation error")
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Error("expected engine InsertRow method not to be called")
+		}
+	})
+
 	t.Run("CreateOnMissingDB", func(t *testing.T) {
 		mockedEngine.Reset()
 		_, err := manager.CreateOn("slave", Values{"email": "user@test.com"})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/moiseshiraldo/gomodel"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ func (state AppState) nextNode() *Node {
 		path:         state.app.FullPath(),
 		Dependencies: [][]string{},
 		Operations:   OperationList{},
+		UseTZ:        gomodel.UseTZ,
 	}
 	node.number = len(state.migrations) + 1
 	if node.number == 1 {
@@ -58,43 +60,75 @@ func (state *AppState) makeMigrations(stash map[string]bool) ([]*Node, error) {
 	app := state.app
 	stash[app.Name()] = true
 	migrations := []*Node{}
+	for _, node := range state.migrations {
+		if node.applied {
+			continue
+		}
+		if op, err := node.checkUseTZ(); err != nil {
+			trace := ErrorTrace{Node: node, Operation: op, Err: err}
+			return migrations, fmt.Errorf("%s", trace)
+		}
+	}
 	node := state.nextNode()
-	for name := range state.Models {
-		// Checks for deleted models.
-		if _, ok := app.Models()[name]; !ok {
+	stateModels := byInheritance(state.Models)
+	for i := len(stateModels) - 1; i >= 0; i-- {
+		// Checks for deleted models, children first. Proxy models share the
+		// table of the model they proxy, so they're not part of the state.
+		name := stateModels[i].Name()
+		if model, ok := app.Models()[name]; !ok || model.ProxyFor() != nil {
 			node.Operations = append(node.Operations, DeleteModel{Name: name})
 		}
 	}
-	for _, model := range app.Models() {
+	for _, model := range byInheritance(app.Models()) {
+		if model.ProxyFor() != nil {
+			continue
+		}
 		modelState, ok := state.Models[model.Name()]
 		if !ok {
 			// New model.
 			operation := CreateModel{
 				Name:   model.Name(),
-				Fields: model.Fields(),
+				Fields: model.LocalFields(),
+			}
+			if parent := model.Parent(); parent != nil {
+				operation.Extends = parent.Name()
 			}
 			defaultTable := fmt.Sprintf("%s__%s", app.Name(), model.Name())
 			if model.Table() != defaultTable {
 				operation.Table = model.Table()
 			}
 			node.Operations = append(node.Operations, operation)
-			for idxName, fields := range model.Indexes() {
-				operation := AddIndex{model.Name(), idxName, fields}
+			for idxName, idx := range modelIndexes(model) {
+				operation := newAddIndex(model.Name(), idxName, idx)
+				node.Operations = append(node.Operations, operation)
+			}
+			for name, constraint := range model.Constraints() {
+				operation := AddConstraint{model.Name(), name, constraint}
 				node.Operations = append(node.Operations, operation)
 			}
 		} else {
-			for idxName := range modelState.Indexes() {
-				// Checks for removed indexes.
-				if _, ok := model.Indexes()[idxName]; !ok {
+			indexes := modelIndexes(model)
+			for idxName, old := range modelIndexes(modelState) {
+				// Checks for removed or changed indexes.
+				idx, ok := indexes[idxName]
+				if !ok || indexChanged(old, idx) {
 					operation := RemoveIndex{model.Name(), idxName}
 					node.Operations = append(node.Operations, operation)
 				}
 			}
+			for name, old := range modelState.Constraints() {
+				// Checks for removed or changed constraints.
+				constraint, ok := model.Constraints()[name]
+				if !ok || constraintChanged(old, constraint) {
+					operation := RemoveConstraint{model.Name(), name}
+					node.Operations = append(node.Operations, operation)
+				}
+			}
 			newFields := gomodel.Fields{}
 			removedFields := []string{}
-			for name := range modelState.Fields() {
+			for name := range modelState.LocalFields() {
 				// Checks for removed fields.
-				if _, ok := model.Fields()[name]; !ok {
+				if _, ok := model.LocalFields()[name]; !ok {
 					removedFields = append(removedFields, name)
 				}
 			}
@@ -102,9 +136,9 @@ func (state *AppState) makeMigrations(stash map[string]bool) ([]*Node, error) {
 				operation := RemoveFields{model.Name(), removedFields}
 				node.Operations = append(node.Operations, operation)
 			}
-			for name, field := range model.Fields() {
+			for name, field := range model.LocalFields() {
 				// Chekcs for new fields.
-				if _, ok := modelState.Fields()[name]; !ok {
+				if _, ok := modelState.LocalFields()[name]; !ok {
 					newFields[name] = field
 				}
 			}
@@ -112,10 +146,52 @@ func (state *AppState) makeMigrations(stash map[string]bool) ([]*Node, error) {
 				operation := AddFields{Model: model.Name(), Fields: newFields}
 				node.Operations = append(node.Operations, operation)
 			}
-			for idxName, fields := range model.Indexes() {
-				// Checks for new indexes.
-				if _, ok := modelState.Indexes()[idxName]; !ok {
-					operation := AddIndex{model.Name(), idxName, fields}
+			for name, field := range model.LocalFields() {
+				// Checks for changed choices.
+				oldField, ok := modelState.LocalFields()[name]
+				if ok && choicesChanged(oldField, field) {
+					cf := field.(gomodel.ChoicesField)
+					choices, strict := cf.FieldChoices()
+					operation := AlterChoices{
+						Model:   model.Name(),
+						Field:   name,
+						Choices: choices,
+						Strict:  strict,
+					}
+					node.Operations = append(node.Operations, operation)
+				}
+			}
+			for name, field := range model.LocalFields() {
+				// Checks for new and removed enum values. Values are added
+				// first, so renamed ones can be migrated in between.
+				oldField, ok := modelState.LocalFields()[name]
+				if !ok {
+					continue
+				}
+				if values := addedEnumValues(oldField, field); len(values) > 0 {
+					operation := AddEnumValues{model.Name(), name, values}
+					node.Operations = append(node.Operations, operation)
+				}
+				removed := removedEnumValues(oldField, field)
+				if len(removed) > 0 {
+					operation := DropEnumValues{model.Name(), name, removed}
+					node.Operations = append(node.Operations, operation)
+				}
+			}
+			oldIndexes := modelIndexes(modelState)
+			for idxName, idx := range indexes {
+				// Checks for new or changed indexes.
+				old, ok := oldIndexes[idxName]
+				if !ok || indexChanged(old, idx) {
+					operation := newAddIndex(model.Name(), idxName, idx)
+					node.Operations = append(node.Operations, operation)
+				}
+			}
+			for name, constraint := range model.Constraints() {
+				// Checks for new or changed constraints.
+				old, ok := modelState.Constraints()[name]
+				if !ok || constraintChanged(old, constraint) {
+					operation := AddConstraint{model.Name(), name, constraint}
 					node.Operations = append(node.Operations, operation)
 				}
 			}
@@ -137,6 +213,30 @@ func (state *AppState) makeMigrations(stash map[string]bool) ([]*Node, error) {
 	return migrations, nil
 }
 
+// byInheritance returns the given models sorted by name, with the parent
+// models before their children.
+func byInheritance(models map[string]*gomodel.Model) []*gomodel.Model {
+	depth := func(model *gomodel.Model) int {
+		n := 0
+		for p := model.Parent(); p != nil; p = p.Parent() {
+			n += 1
+		}
+		return n
+	}
+	sorted := make([]*gomodel.Model, 0, len(models))
+	for _, model := range models {
+		sorted = append(sorted, model)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		di, dj := depth(sorted[i]), depth(sorted[j])
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	return sorted
+}
+
 // Migrate applies the changes up to and including the node named by nodeName,
 // using the db schema named by database.
 //
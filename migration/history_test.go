@@ -585,6 +585,90 @@ func TestAppMakeMigrations(t *testing.T) {
 		history["customers"].migrations = []*Node{node}
 	})
 
+	t.Run("ChangeIndex", func(t *testing.T) {
+		customerState := gomodel.New(
+			"Customer",
+			customer.Model.Fields(),
+			gomodel.Options{
+				IndexDefinitions: gomodel.IndexDefinitions{
+					"initial_idx": {Fields: []string{"name"}, Unique: true},
+				},
+				Table: customer.Model.Table(),
+			},
+		)
+		history["customers"].Models["Customer"] = customerState.Model
+		migrations, err := history["customers"].MakeMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != 1 {
+			t.Fatalf("expected 1 migration, got %d", len(migrations))
+		}
+		ops := migrations[0].Operations
+		if len(ops) != 2 {
+			t.Fatal("expected migration to contain two operations")
+		}
+		if ops[0].OpName() != "RemoveIndex" {
+			t.Errorf("expected RemoveIndex operation, got %s", ops[0].OpName())
+		}
+		if ops[1].OpName() != "AddIndex" {
+			t.Errorf("expected AddIndex operation, got %s", ops[1].OpName())
+		}
+		modelState := history["customers"].Models["Customer"]
+		idx, ok := modelIndexes(modelState)["initial_idx"]
+		if !ok || idx.Unique {
+			t.Errorf("index change was not applied to state")
+		}
+		history["customers"].Models["Customer"] = customer.Model
+		history["customers"].migrations = []*Node{node}
+	})
+
+	t.Run("AlterChoices", func(t *testing.T) {
+		fields := customer.Model.Fields()
+		fields["name"] = gomodel.CharField{
+			MaxLength:     100,
+			Choices:       []gomodel.Choice{{Value: "a", Label: "A"}},
+			StrictChoices: true,
+		}
+		customerState := gomodel.New(
+			"Customer",
+			fields,
+			gomodel.Options{
+				Table:   customer.Model.Table(),
+				Indexes: customer.Model.Indexes(),
+			},
+		)
+		history["customers"].Models["Customer"] = customerState.Model
+		migrations, err := history["customers"].MakeMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != 1 {
+			t.Fatalf("expected 1 migration, got %d", len(migrations))
+		}
+		if len(migrations[0].Operations) != 1 {
+			t.Fatal("expected migration to contain one operation")
+		}
+		if migrations[0].Operations[0].OpName() != "AlterChoices" {
+			name := migrations[0].Operations[0].OpName()
+			t.Fatalf("expected AlterChoices operation, got %s", name)
+		}
+		choicesOp := migrations[0].Operations[0].(AlterChoices)
+		if choicesOp.Model != "Customer" || choicesOp.Field != "name" {
+			t.Errorf("operation AlterChoices has wrong details")
+		}
+		if choicesOp.Strict || len(choicesOp.Choices) != 0 {
+			t.Errorf("operation AlterChoices has wrong choices")
+		}
+		modelState := history["customers"].Models["Customer"]
+		field := modelState.Fields()["name"].(gomodel.CharField)
+		if field.StrictChoices || len(field.Choices) != 0 {
+			t.Errorf("operation AlterChoices was not applied to state")
+		}
+		history["customers"].Models["Customer"] = customer.Model
+		history["customers"].migrations = []*Node{node}
+	})
+
 	t.Run("DeleteModel", func(t *testing.T) {
 		transaction := gomodel.New(
 			"Transaction",
@@ -621,6 +705,435 @@ func TestAppMakeMigrations(t *testing.T) {
 	})
 }
 
+// TestAppMakeMigrationsConstraints tests the detection of constraint changes
+func TestAppMakeMigrationsConstraints(t *testing.T) {
+	// Models setup
+	fields := gomodel.Fields{
+		"email":  gomodel.CharField{MaxLength: 100},
+		"active": gomodel.BooleanField{},
+	}
+	user := gomodel.New(
+		"User",
+		fields,
+		gomodel.Options{
+			Constraints: gomodel.Constraints{
+				"unique_email": gomodel.UniqueConstraint{
+					Fields: []string{"email"},
+				},
+			},
+		},
+	)
+	// App setup
+	app := gomodel.NewApp("users", "", user.Model)
+	gomodel.Register(app)
+	defer gomodel.ClearRegistry()
+	// App state setup
+	operation := CreateModel{Name: "User", Fields: user.Model.Fields()}
+	node := &Node{
+		App:        "users",
+		name:       "initial",
+		number:     1,
+		Operations: OperationList{operation},
+		processed:  true,
+	}
+	history["users"] = &AppState{
+		app:        gomodel.Registry()["users"],
+		Models:     map[string]*gomodel.Model{"User": user.Model},
+		migrations: []*Node{node},
+	}
+	defer clearHistory()
+
+	t.Run("AddConstraint", func(t *testing.T) {
+		userState := gomodel.New("User", fields, gomodel.Options{})
+		history["users"].Models["User"] = userState.Model
+		migrations, err := history["users"].MakeMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != 1 {
+			t.Fatalf("expected 1 migration, got %d", len(migrations))
+		}
+		if len(migrations[0].Operations) != 1 {
+			t.Fatal("expected migration to contain one operation")
+		}
+		if migrations[0].Operations[0].OpName() != "AddConstraint" {
+			name := migrations[0].Operations[0].OpName()
+			t.Fatalf("expected AddConstraint operation, got %s", name)
+		}
+		op := migrations[0].Operations[0].(AddConstraint)
+		if op.Model != "User" || op.Name != "unique_email" {
+			t.Errorf("operation AddConstraint has wrong details")
+		}
+		modelState := history["users"].Models["User"]
+		if _, ok := modelState.Constraints()["unique_email"]; !ok {
+			t.Errorf("operation AddConstraint was not applied to state")
+		}
+		history["users"].Models["User"] = user.Model
+		history["users"].migrations = []*Node{node}
+	})
+
+	t.Run("RemoveConstraint", func(t *testing.T) {
+		userState := gomodel.New(
+			"User",
+			fields,
+			gomodel.Options{
+				Constraints: gomodel.Constraints{
+					"unique_email": gomodel.UniqueConstraint{
+						Fields: []string{"email"},
+					},
+					"check_active": gomodel.CheckConstraint{
+						Check: gomodel.Q{"active": true},
+					},
+				},
+			},
+		)
+		history["users"].Models["User"] = userState.Model
+		migrations, err := history["users"].MakeMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != 1 {
+			t.Fatalf("expected 1 migration, got %d", len(migrations))
+		}
+		if len(migrations[0].Operations) != 1 {
+			t.Fatal("expected migration to contain one operation")
+		}
+		if migrations[0].Operations[0].OpName() != "RemoveConstraint" {
+			name := migrations[0].Operations[0].OpName()
+			t.Fatalf("expected RemoveConstraint operation, got %s", name)
+		}
+		op := migrations[0].Operations[0].(RemoveConstraint)
+		if op.Model != "User" || op.Name != "check_active" {
+			t.Errorf("operation RemoveConstraint has wrong details")
+		}
+		modelState := history["users"].Models["User"]
+		if _, found := modelState.Constraints()["check_active"]; found {
+			t.Errorf("operation RemoveConstraint was not applied to state")
+		}
+		history["users"].Models["User"] = user.Model
+		history["users"].migrations = []*Node{node}
+	})
+
+	t.Run("ChangeConstraint", func(t *testing.T) {
+		userState := gomodel.New(
+			"User",
+			fields,
+			gomodel.Options{
+				Constraints: gomodel.Constraints{
+					"unique_email": gomodel.UniqueConstraint{
+						Fields: []string{"email", "active"},
+					},
+				},
+			},
+		)
+		history["users"].Models["User"] = userState.Model
+		migrations, err := history["users"].MakeMigrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(migrations) != 1 {
+			t.Fatalf("expected 1 migration, got %d", len(migrations))
+		}
+		ops := migrations[0].Operations
+		if len(ops) != 2 {
+			t.Fatal("expected migration to contain two operations")
+		}
+		if ops[0].OpName() != "RemoveConstraint" {
+			name := ops[0].OpName()
+			t.Errorf("expected RemoveConstraint operation, got %s", name)
+		}
+		if ops[1].OpName() != "AddConstraint" {
+			name := ops[1].OpName()
+			t.Errorf("expected AddConstraint operation, got %s", name)
+		}
+		modelState := history["users"].Models["User"]
+		constraint := modelState.Constraints()["unique_email"]
+		unique, ok := constraint.(gomodel.UniqueConstraint)
+		if !ok || len(unique.Fields) != 1 {
+			t.Errorf("constraint change was not applied to state")
+		}
+		history["users"].Models["User"] = user.Model
+		history["users"].migrations = []*Node{node}
+	})
+}
+
+// TestAppMakeMigrationsEnums tests the detection of new enum values
+func TestAppMakeMigrationsEnums(t *testing.T) {
+	// Models setup
+	user := gomodel.New(
+		"User",
+		gomodel.Fields{
+			"role": gomodel.EnumField{
+				Values:   []gomodel.Value{"admin", "user", "guest"},
+				TypeName: "role",
+			},
+		},
+		gomodel.Options{},
+	)
+	// App setup
+	app := gomodel.NewApp("users", "", user.Model)
+	gomodel.Register(app)
+	defer gomodel.ClearRegistry()
+	// App state setup
+	fields := user.Model.Fields()
+	fields["role"] = gomodel.EnumField{
+		Values:   []gomodel.Value{"admin", "user"},
+		TypeName: "role",
+	}
+	userState := gomodel.New("User", fields, gomodel.Options{})
+	operation := CreateModel{Name: "User", Fields: userState.Model.Fields()}
+	node := &Node{
+		App:        "users",
+		name:       "initial",
+		number:     1,
+		Operations: OperationList{operation},
+		processed:  true,
+	}
+	history["users"] = &AppState{
+		app:        gomodel.Registry()["users"],
+		Models:     map[string]*gomodel.Model{"User": userState.Model},
+		migrations: []*Node{node},
+	}
+	defer clearHistory()
+
+	migrations, err := history["users"].MakeMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected 1 migration, got %d", len(migrations))
+	}
+	if len(migrations[0].Operations) != 1 {
+		t.Fatalf("expected one operation, got %v", migrations[0].Operations)
+	}
+	if migrations[0].Operations[0].OpName() != "AddEnumValues" {
+		name := migrations[0].Operations[0].OpName()
+		t.Fatalf("expected AddEnumValues operation, got %s", name)
+	}
+	op := migrations[0].Operations[0].(AddEnumValues)
+	if op.Model != "User" || op.Field != "role" {
+		t.Errorf("operation AddEnumValues has wrong details")
+	}
+	if len(op.Values) != 1 || op.Values[0] != "guest" {
+		t.Errorf("operation AddEnumValues has wrong values: %v", op.Values)
+	}
+	field := history["users"].Models["User"].Fields()["role"]
+	if _, err := field.DriverValue("guest", ""); err != nil {
+		t.Errorf("operation AddEnumValues was not applied to state")
+	}
+}
+
+// TestAppMakeMigrationsRemovedEnums tests the detection of removed enum values
+func TestAppMakeMigrationsRemovedEnums(t *testing.T) {
+	// Models setup
+	user := gomodel.New(
+		"User",
+		gomodel.Fields{
+			"role": gomodel.EnumField{
+				Values:   []gomodel.Value{"admin", "staff"},
+				TypeName: "role",
+			},
+		},
+		gomodel.Options{},
+	)
+	// App setup
+	app := gomodel.NewApp("users", "", user.Model)
+	gomodel.Register(app)
+	defer gomodel.ClearRegistry()
+	// App state setup
+	fields := user.Model.Fields()
+	fields["role"] = gomodel.EnumField{
+		Values:   []gomodel.Value{"admin", "user"},
+		TypeName: "role",
+	}
+	userState := gomodel.New("User", fields, gomodel.Options{})
+	operation := CreateModel{Name: "User", Fields: userState.Model.Fields()}
+	node := &Node{
+		App:        "users",
+		name:       "initial",
+		number:     1,
+		Operations: OperationList{operation},
+		processed:  true,
+	}
+	history["users"] = &AppState{
+		app:        gomodel.Registry()["users"],
+		Models:     map[string]*gomodel.Model{"User": userState.Model},
+		migrations: []*Node{node},
+	}
+	defer clearHistory()
+
+	migrations, err := history["users"].MakeMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 || len(migrations[0].Operations) != 2 {
+		t.Fatalf("expected two operations, got %v", migrations)
+	}
+	added, ok := migrations[0].Operations[0].(AddEnumValues)
+	if !ok || len(added.Values) != 1 || added.Values[0] != "staff" {
+		t.Errorf("expected AddEnumValues operation, got %v", added)
+	}
+	op, ok := migrations[0].Operations[1].(DropEnumValues)
+	if !ok {
+		name := migrations[0].Operations[1].OpName()
+		t.Fatalf("expected DropEnumValues operation, got %s", name)
+	}
+	if op.Model != "User" || op.Field != "role" {
+		t.Errorf("operation DropEnumValues has wrong details")
+	}
+	if len(op.Values) != 1 || op.Values[0] != "user" {
+		t.Errorf("operation DropEnumValues has wrong values: %v", op.Values)
+	}
+	field := history["users"].Models["User"].Fields()["role"]
+	if _, err := field.DriverValue("user", ""); err == nil {
+		t.Errorf("operation DropEnumValues was not applied to state")
+	}
+}
+
+// TestAppMakeMigrationsUseTZ tests the detection of UseTZ changes
+func TestAppMakeMigrationsUseTZ(t *testing.T) {
+	// Models setup
+	event := gomodel.New(
+		"Event",
+		gomodel.Fields{"at": gomodel.DateTimeField{}},
+		gomodel.Options{},
+	)
+	// App setup
+	app := gomodel.NewApp("events", "", event.Model)
+	gomodel.Register(app)
+	defer gomodel.ClearRegistry()
+	// App state setup
+	operation := CreateModel{Name: "Event", Fields: event.Model.Fields()}
+	node := &Node{
+		App:        "events",
+		name:       "initial",
+		number:     1,
+		Operations: OperationList{operation},
+		processed:  true,
+	}
+	history["events"] = &AppState{
+		app:        gomodel.Registry()["events"],
+		Models:     map[string]*gomodel.Model{"Event": event.Model},
+		migrations: []*Node{node},
+	}
+	defer clearHistory()
+
+	if _, err := history["events"].MakeMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	gomodel.UseTZ = true
+	defer func() { gomodel.UseTZ = false }()
+	if _, err := history["events"].MakeMigrations(); err == nil {
+		t.Error("expected UseTZ changed error")
+	}
+	node.applied = true
+	if _, err := history["events"].MakeMigrations(); err != nil {
+		t.Errorf("expected applied nodes to be skipped: %s", err)
+	}
+}
+
+// TestAppMakeMigrationsInheritance tests the creation of child models
+func TestAppMakeMigrationsInheritance(t *testing.T) {
+	// Models setup
+	person := gomodel.New(
+		"Person",
+		gomodel.Fields{"name": gomodel.CharField{MaxLength: 100}},
+		gomodel.Options{},
+	)
+	employee := gomodel.New(
+		"Employee",
+		gomodel.Fields{"salary": gomodel.IntegerField{}},
+		gomodel.Options{Extends: []*gomodel.Model{person.Model}},
+	)
+	active := gomodel.New(
+		"ActivePerson",
+		gomodel.Fields{},
+		gomodel.Options{
+			Extends: []*gomodel.Model{person.Model},
+			Proxy:   true,
+			Filter:  gomodel.Q{"active": true},
+		},
+	)
+	// App setup
+	app := gomodel.NewApp(
+		"staff", "", person.Model, employee.Model, active.Model,
+	)
+	gomodel.Register(app)
+	defer gomodel.ClearRegistry()
+	// App state setup
+	history["staff"] = &AppState{
+		app:    gomodel.Registry()["staff"],
+		Models: make(map[string]*gomodel.Model),
+	}
+	defer clearHistory()
+
+	migrations, err := history["staff"].MakeMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected 1 migration, got %d", len(migrations))
+	}
+	ops := migrations[0].Operations
+	if len(ops) != 2 {
+		// The proxy model must be skipped.
+		t.Fatalf("expected two operations, got %v", ops)
+	}
+	if op := ops[0].(CreateModel); op.Name != "Person" || op.Extends != "" {
+		t.Errorf("expected Person to be created first, got %s", op.Name)
+	}
+	op := ops[1].(CreateModel)
+	if op.Name != "Employee" || op.Extends != "Person" {
+		t.Errorf("operation CreateModel has wrong details: %+v", op)
+	}
+	if _, ok := op.Fields["name"]; ok {
+		t.Errorf("expected parent fields to be excluded")
+	}
+	if _, ok := op.Fields["person_ptr"]; !ok {
+		t.Errorf("expected parent link field to be included")
+	}
+	model := history["staff"].Models["Employee"]
+	if model.Parent() == nil || model.Parent().Name() != "Person" {
+		t.Errorf("operation CreateModel was not applied to state")
+	}
+}
+
+// TestAppMakeMigrationsHistory tests the migrations of models with history
+func TestAppMakeMigrationsHistory(t *testing.T) {
+	// Models setup
+	post := gomodel.New(
+		"Post",
+		gomodel.Fields{"title": gomodel.CharField{MaxLength: 100}},
+		gomodel.Options{History: true},
+	)
+	// App setup
+	gomodel.Register(gomodel.NewApp("blog", "", post.Model))
+	defer gomodel.ClearRegistry()
+	// App state setup
+	history["blog"] = &AppState{
+		app:    gomodel.Registry()["blog"],
+		Models: make(map[string]*gomodel.Model),
+	}
+	defer clearHistory()
+
+	migrations, err := history["blog"].MakeMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected 1 migration, got %d", len(migrations))
+	}
+	names := map[string]bool{}
+	for _, op := range migrations[0].Operations {
+		if op, ok := op.(CreateModel); ok {
+			names[op.Name] = true
+		}
+	}
+	if !names["Post"] || !names["PostHistory"] {
+		t.Errorf("expected Post and PostHistory models, got %v", names)
+	}
+}
+
 // TestLoadHistory tests the loadHistory function
 func TestLoadHistory(t *testing.T) {
 	// App setup
@@ -47,11 +47,14 @@ type Node struct {
 	Dependencies [][]string
 	// Operations is the list of operations describing the changes.
 	Operations OperationList
-	path       string // Full path of the migrations folder.
-	name       string // Migration name.
-	number     int    // Node number.
-	processed  bool   // True if node changes has been added to app state.
-	applied    bool   // True if node has been applied to database schema.
+	// UseTZ is the gomodel UseTZ setting when the node was created, which
+	// determines the storage of the datetime columns.
+	UseTZ     bool   `json:",omitempty"`
+	path      string // Full path of the migrations folder.
+	name      string // Migration name.
+	number    int    // Node number.
+	processed bool   // True if node changes has been added to app state.
+	applied   bool   // True if node has been applied to database schema.
 }
 
 // Name returns the node name (e.g. 0001_initial)
@@ -115,6 +118,9 @@ func (n *Node) Run(db gomodel.Database) error {
 	if err := n.runDependencies(db, false); err != nil {
 		return err
 	}
+	if op, err := n.checkUseTZ(); err != nil {
+		return &OperationRunError{ErrorTrace{n, op, err}}
+	}
 	if err := n.runOperations(db); err != nil {
 		return err
 	}
@@ -139,6 +145,52 @@ func (n *Node) Fake(db gomodel.Database) error {
 	return nil
 }
 
+// checkUseTZ returns an error and the operation adding datetime columns if the
+// node was created with a different UseTZ setting, since it can't be changed
+// once the columns exist.
+func (n Node) checkUseTZ() (Operation, error) {
+	if n.UseTZ == gomodel.UseTZ {
+		return nil, nil
+	}
+	for _, op := range n.Operations {
+		var fields gomodel.Fields
+		switch o := op.(type) {
+		case CreateModel:
+			fields = o.Fields
+		case AddFields:
+			fields = o.Fields
+		}
+		for name, field := range fields {
+			if hasDateTime(field) {
+				err := fmt.Errorf(
+					"%s: UseTZ changed since the datetime column was created",
+					name,
+				)
+				return op, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// hasDateTime returns true if the given field is a datetime one, or a
+// generated or array field of datetimes.
+func hasDateTime(field gomodel.Field) bool {
+	switch f := field.(type) {
+	case gomodel.DateTimeField, *gomodel.DateTimeField:
+		return true
+	case gomodel.GeneratedField:
+		return hasDateTime(f.Base)
+	case *gomodel.GeneratedField:
+		return f != nil && hasDateTime(f.Base)
+	case gomodel.ArrayField:
+		return hasDateTime(f.Base)
+	case *gomodel.ArrayField:
+		return f != nil && hasDateTime(f.Base)
+	}
+	return false
+}
+
 // runDependencies run all the unapplied dependencies of the node.
 func (n Node) runDependencies(db gomodel.Database, fake bool) error {
 	for _, dep := range n.Dependencies {
@@ -287,6 +287,40 @@ func testNodeRun(t *testing.T, db gomodel.Database) {
 		}
 	})
 
+	t.Run("UseTZChanged", func(t *testing.T) {
+		node := setup()
+		node.UseTZ = true
+		node.Operations = OperationList{CreateModel{
+			Name:   "Event",
+			Fields: gomodel.Fields{"at": gomodel.DateTimeField{}},
+		}}
+		err := node.Run(db)
+		if _, ok := err.(*OperationRunError); !ok {
+			t.Errorf("expected OperationRunError, got %T", err)
+		}
+		if mockedEngine.Calls("CreateTable") != 0 {
+			t.Error("expected engine CreateTable not to be called")
+		}
+	})
+
+	t.Run("UseTZChangedWrapped", func(t *testing.T) {
+		node := setup()
+		node.UseTZ = true
+		node.Operations = OperationList{AddFields{
+			Model: "User",
+			Fields: gomodel.Fields{"dates": gomodel.ArrayField{
+				Base: gomodel.DateTimeField{},
+			}},
+		}}
+		err := node.Run(db)
+		if _, ok := err.(*OperationRunError); !ok {
+			t.Errorf("expected OperationRunError, got %T", err)
+		}
+		if mockedEngine.Calls("AddColumns") != 0 {
+			t.Error("expected engine AddColumns not to be called")
+		}
+	})
+
 	t.Run("TxRollbackError", func(t *testing.T) {
 		node := setup()
 		op.RunErr = true
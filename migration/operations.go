@@ -83,12 +83,17 @@ func (opList *OperationList) UnmarshalJSON(data []byte) error {
 
 // operationsRegistry holds a global registry of available operations.
 var operationsRegistry = map[string]Operation{
-	"CreateModel":  CreateModel{},
-	"DeleteModel":  DeleteModel{},
-	"AddFields":    AddFields{},
-	"RemoveFields": RemoveFields{},
-	"AddIndex":     AddIndex{},
-	"RemoveIndex":  RemoveIndex{},
+	"CreateModel":      CreateModel{},
+	"DeleteModel":      DeleteModel{},
+	"AddFields":        AddFields{},
+	"RemoveFields":     RemoveFields{},
+	"AlterChoices":     AlterChoices{},
+	"AddEnumValues":    AddEnumValues{},
+	"DropEnumValues":   DropEnumValues{},
+	"AddIndex":         AddIndex{},
+	"RemoveIndex":      RemoveIndex{},
+	"AddConstraint":    AddConstraint{},
+	"RemoveConstraint": RemoveConstraint{},
 }
 
 // RegisterOperation registers a custom operation. Returns an error if the
@@ -1,6 +1,7 @@
 package migration
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/moiseshiraldo/gomodel"
 )
@@ -27,7 +28,8 @@ func (op AddFields) SetState(state *AppState) error {
 			return err
 		}
 	}
-	return nil
+	model.SetupEncryptedFields()
+	return refreshChildren(state, op.Model)
 }
 
 // Run adds the new columns to the table on the database.
@@ -74,7 +76,7 @@ func (op RemoveFields) SetState(state *AppState) error {
 			return err
 		}
 	}
-	return nil
+	return refreshChildren(state, op.Model)
 }
 
 // Run removes the columns from the table on the database.
@@ -99,3 +101,243 @@ func (op RemoveFields) Backwards(
 	}
 	return engine.AddColumns(prevState.Models[op.Model], newFields)
 }
+
+// AlterChoices implements the Operation interface to change the choices of a
+// field and the database constraint enforcing them.
+type AlterChoices struct {
+	Model   string
+	Field   string
+	Choices []gomodel.Choice `json:",omitempty"`
+	// Strict is true if the choices must be enforced by a database constraint.
+	Strict bool `json:",omitempty"`
+}
+
+// OpName returns the operation name.
+func (op AlterChoices) OpName() string {
+	return "AlterChoices"
+}
+
+// SetState updates the field choices in the given application state.
+func (op AlterChoices) SetState(state *AppState) error {
+	if _, ok := state.Models[op.Model]; !ok {
+		return fmt.Errorf("model not found: %s", op.Model)
+	}
+	model := state.Models[op.Model]
+	if err := model.AlterChoices(op.Field, op.Choices, op.Strict); err != nil {
+		return err
+	}
+	return refreshChildren(state, op.Model)
+}
+
+// Run updates the constraint enforcing the field choices on the database.
+func (op AlterChoices) Run(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	model := state.Models[op.Model]
+	if !strictChoices(prevState.Models[op.Model], op.Field) && !op.Strict {
+		return nil
+	}
+	return engine.AlterChoices(model, op.Field)
+}
+
+// Backwards restores the previous constraint enforcing the field choices on
+// the database.
+func (op AlterChoices) Backwards(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	model := prevState.Models[op.Model]
+	if !strictChoices(model, op.Field) && !op.Strict {
+		return nil
+	}
+	return engine.AlterChoices(model, op.Field)
+}
+
+// strictChoices returns true if the choices of the named model field are
+// enforced by a database constraint.
+func strictChoices(model *gomodel.Model, name string) bool {
+	if model == nil {
+		return false
+	}
+	field, ok := model.Fields()[name].(gomodel.ChoicesField)
+	if !ok {
+		return false
+	}
+	_, strict := field.FieldChoices()
+	return strict
+}
+
+// choicesChanged returns true if the choices of the given fields are different.
+func choicesChanged(old gomodel.Field, new gomodel.Field) bool {
+	oldField, ok := old.(gomodel.ChoicesField)
+	if !ok {
+		return false
+	}
+	newField, ok := new.(gomodel.ChoicesField)
+	if !ok {
+		return false
+	}
+	oldChoices, oldStrict := oldField.FieldChoices()
+	newChoices, newStrict := newField.FieldChoices()
+	if oldStrict != newStrict || len(oldChoices) != len(newChoices) {
+		return true
+	}
+	// Choices are compared by their JSON encoding, as the values loaded from
+	// migration files don't keep the original types.
+	oldData, _ := json.Marshal(oldChoices)
+	newData, _ := json.Marshal(newChoices)
+	return string(oldData) != string(newData)
+}
+
+// AddEnumValues implements the Operation interface to add values to an enum
+// field.
+type AddEnumValues struct {
+	Model  string
+	Field  string
+	Values []gomodel.Value
+}
+
+// OpName returns the operation name.
+func (op AddEnumValues) OpName() string {
+	return "AddEnumValues"
+}
+
+// SetState adds the values to the enum field in the given application state.
+func (op AddEnumValues) SetState(state *AppState) error {
+	model, ok := state.Models[op.Model]
+	if !ok {
+		return fmt.Errorf("model not found: %s", op.Model)
+	}
+	if err := model.AddEnumValues(op.Field, op.Values...); err != nil {
+		return err
+	}
+	return refreshChildren(state, op.Model)
+}
+
+// Run adds the values to the enum type on the database.
+func (op AddEnumValues) Run(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	return engine.AddEnumValues(state.Models[op.Model], op.Field, op.Values...)
+}
+
+// Backwards removes the values from the enum type on the database.
+func (op AddEnumValues) Backwards(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	model := prevState.Models[op.Model]
+	return engine.DropEnumValues(model, op.Field, op.Values...)
+}
+
+// DropEnumValues implements the Operation interface to remove values from an
+// enum field. Rows holding the removed values must be updated first.
+type DropEnumValues struct {
+	Model  string
+	Field  string
+	Values []gomodel.Value
+}
+
+// OpName returns the operation name.
+func (op DropEnumValues) OpName() string {
+	return "DropEnumValues"
+}
+
+// SetState removes the values from the enum field in the given application
+// state.
+func (op DropEnumValues) SetState(state *AppState) error {
+	model, ok := state.Models[op.Model]
+	if !ok {
+		return fmt.Errorf("model not found: %s", op.Model)
+	}
+	if err := model.DropEnumValues(op.Field, op.Values...); err != nil {
+		return err
+	}
+	return refreshChildren(state, op.Model)
+}
+
+// Run removes the values from the enum type on the database.
+func (op DropEnumValues) Run(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	model := state.Models[op.Model]
+	return engine.DropEnumValues(model, op.Field, op.Values...)
+}
+
+// Backwards adds the values back to the enum type on the database.
+func (op DropEnumValues) Backwards(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	model := prevState.Models[op.Model]
+	return engine.AddEnumValues(model, op.Field, op.Values...)
+}
+
+// addedEnumValues returns the values of the new enum field that are missing
+// from the old one.
+func addedEnumValues(old gomodel.Field, new gomodel.Field) []gomodel.Value {
+	if !isEnumField(old) {
+		return nil
+	}
+	var values []gomodel.Value
+	switch f := new.(type) {
+	case gomodel.EnumField:
+		values = f.Values
+	case *gomodel.EnumField:
+		values = f.Values
+	}
+	added := []gomodel.Value{}
+	for _, val := range values {
+		// Values are checked against the old field, as the ones loaded from
+		// migration files don't keep the original types.
+		if _, err := old.DriverValue(val, ""); err != nil {
+			added = append(added, val)
+		}
+	}
+	return added
+}
+
+// removedEnumValues returns the values of the old enum field that are not
+// accepted by the new one.
+func removedEnumValues(old gomodel.Field, new gomodel.Field) []gomodel.Value {
+	if !isEnumField(new) {
+		return nil
+	}
+	return addedEnumValues(new, old)
+}
+
+// isEnumField returns true if the given field is an enum one.
+func isEnumField(field gomodel.Field) bool {
+	switch field.(type) {
+	case gomodel.EnumField, *gomodel.EnumField:
+		return true
+	}
+	return false
+}
+
+// refreshChildren updates the inherited fields of the models extending the
+// named one in the given application state.
+func refreshChildren(state *AppState, name string) error {
+	for _, model := range state.Models {
+		parent := model.Parent()
+		if parent == nil || parent.Name() != name {
+			continue
+		}
+		if err := model.SetupParent(state.Models[name]); err != nil {
+			return err
+		}
+		if err := refreshChildren(state, model.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,6 +14,9 @@ func TestFieldOperationsState(t *testing.T) {
 		gomodel.Fields{
 			"email":         gomodel.CharField{MaxLength: 100, Index: true},
 			"loginAttempts": gomodel.IntegerField{DefaultZero: true},
+			"role": gomodel.EnumField{
+				Values: []gomodel.Value{"admin", "user"}, TypeName: "role",
+			},
 		},
 		gomodel.Options{},
 	)
@@ -75,6 +78,110 @@ func TestFieldOperationsState(t *testing.T) {
 		}
 	})
 
+	t.Run("AlterChoicesNoModel", func(t *testing.T) {
+		op := AlterChoices{Model: "Customer", Field: "name"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model not found error")
+		}
+	})
+
+	t.Run("AlterChoicesUnknownField", func(t *testing.T) {
+		op := AlterChoices{Model: "User", Field: "name"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected field not found error")
+		}
+	})
+
+	t.Run("AlterChoicesNotSupported", func(t *testing.T) {
+		op := AlterChoices{Model: "User", Field: "dob"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected choices not supported error")
+		}
+	})
+
+	t.Run("AlterChoices", func(t *testing.T) {
+		op := AlterChoices{
+			Model:   "User",
+			Field:   "loginAttempts",
+			Choices: []gomodel.Choice{{Value: 0, Label: "None"}},
+			Strict:  true,
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		field := appState.Models["User"].Fields()["loginAttempts"]
+		choices, strict := field.(gomodel.ChoicesField).FieldChoices()
+		if !strict || len(choices) != 1 {
+			t.Errorf("field choices were not updated on model state")
+		}
+	})
+
+	t.Run("AddEnumValuesNoModel", func(t *testing.T) {
+		op := AddEnumValues{Model: "Customer", Field: "role"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model not found error")
+		}
+	})
+
+	t.Run("AddEnumValuesNotEnum", func(t *testing.T) {
+		op := AddEnumValues{
+			Model:  "User",
+			Field:  "email",
+			Values: []gomodel.Value{"guest"},
+		}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected not an enum field error")
+		}
+	})
+
+	t.Run("AddEnumValues", func(t *testing.T) {
+		op := AddEnumValues{
+			Model:  "User",
+			Field:  "role",
+			Values: []gomodel.Value{"guest"},
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		field := appState.Models["User"].Fields()["role"]
+		if _, err := field.DriverValue("guest", ""); err != nil {
+			t.Errorf("enum values were not updated on model state")
+		}
+	})
+
+	t.Run("DropEnumValuesNoModel", func(t *testing.T) {
+		op := DropEnumValues{Model: "Customer", Field: "role"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model not found error")
+		}
+	})
+
+	t.Run("DropEnumValuesUnknown", func(t *testing.T) {
+		op := DropEnumValues{
+			Model:  "User",
+			Field:  "role",
+			Values: []gomodel.Value{"owner"},
+		}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected unknown enum value error")
+		}
+	})
+
+	t.Run("DropEnumValues", func(t *testing.T) {
+		op := DropEnumValues{
+			Model:  "User",
+			Field:  "role",
+			Values: []gomodel.Value{"guest"},
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		field := appState.Models["User"].Fields()["role"]
+		if _, err := field.DriverValue("guest", ""); err == nil {
+			t.Errorf("enum values were not updated on model state")
+		}
+	})
+
 	t.Run("RemoveFieldNoModel", func(t *testing.T) {
 		op := RemoveFields{
 			Model:  "Customer",
@@ -159,6 +266,15 @@ func TestFieldOperations(t *testing.T) {
 	t.Run("RemoveField", func(t *testing.T) {
 		testRemoveFieldOperation(t, engine, appState)
 	})
+	t.Run("AlterChoices", func(t *testing.T) {
+		testAlterChoicesOperation(t, engine, appState)
+	})
+	t.Run("AddEnumValues", func(t *testing.T) {
+		testAddEnumValuesOperation(t, engine, appState)
+	})
+	t.Run("DropEnumValues", func(t *testing.T) {
+		testDropEnumValuesOperation(t, engine, appState)
+	})
 }
 
 func testAddFieldOperation(
@@ -276,3 +392,222 @@ func testRemoveFieldOperation(
 		}
 	})
 }
+
+func testAlterChoicesOperation(
+	t *testing.T,
+	mockedEngine gomodel.MockedEngine,
+	prevState *AppState,
+) {
+	choices := []gomodel.Choice{{Value: "user@test.com", Label: "Test"}}
+	op := AlterChoices{Model: "User", Field: "email", Choices: choices}
+	fields := prevState.Models["User"].Fields()
+	fields["email"] = gomodel.CharField{MaxLength: 100, Choices: choices}
+	state := &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": gomodel.New("User", fields, gomodel.Options{}).Model,
+		},
+	}
+
+	t.Run("RunNotStrict", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AlterChoices") != 0 {
+			t.Errorf("expected engine AlterChoices not to be called")
+		}
+	})
+
+	op.Strict = true
+	fields["email"] = gomodel.CharField{
+		MaxLength: 100, Choices: choices, StrictChoices: true,
+	}
+	state.Models["User"] = gomodel.New("User", fields, gomodel.Options{}).Model
+
+	t.Run("RunError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.AlterChoices = fmt.Errorf("db error")
+		if err := op.Run(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("RunSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AlterChoices") != 1 {
+			t.Fatalf("expected engine AlterChoices to be called")
+		}
+		if mockedEngine.Args.AlterChoices.Model != state.Models["User"] {
+			t.Errorf("expected engine AlterChoices to be called with new model")
+		}
+	})
+
+	t.Run("BackwardsSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Backwards(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AlterChoices") != 1 {
+			t.Fatalf("expected engine AlterChoices to be called")
+		}
+		if mockedEngine.Args.AlterChoices.Model != prevState.Models["User"] {
+			t.Errorf("expected engine AlterChoices to be called with old model")
+		}
+	})
+}
+
+func testAddEnumValuesOperation(
+	t *testing.T,
+	mockedEngine gomodel.MockedEngine,
+	prevState *AppState,
+) {
+	op := AddEnumValues{
+		Model:  "User",
+		Field:  "role",
+		Values: []gomodel.Value{"guest"},
+	}
+	fields := prevState.Models["User"].Fields()
+	fields["role"] = gomodel.EnumField{
+		Values: []gomodel.Value{"admin", "user"}, TypeName: "role",
+	}
+	prevState = &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": gomodel.New("User", fields, gomodel.Options{}).Model,
+		},
+	}
+	fields = prevState.Models["User"].Fields()
+	fields["role"] = gomodel.EnumField{
+		Values: []gomodel.Value{"admin", "user", "guest"}, TypeName: "role",
+	}
+	state := &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": gomodel.New("User", fields, gomodel.Options{}).Model,
+		},
+	}
+
+	t.Run("RunError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.AddEnumValues = fmt.Errorf("db error")
+		if err := op.Run(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("RunSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AddEnumValues") != 1 {
+			t.Fatalf("expected engine AddEnumValues to be called")
+		}
+		args := mockedEngine.Args.AddEnumValues
+		if args.Model != state.Models["User"] || args.Field != "role" {
+			t.Errorf("expected engine AddEnumValues to be called with new model")
+		}
+	})
+
+	t.Run("BackwardsError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DropEnumValues = fmt.Errorf("db error")
+		if err := op.Backwards(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("BackwardsSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Backwards(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DropEnumValues") != 1 {
+			t.Fatalf("expected engine DropEnumValues to be called")
+		}
+		args := mockedEngine.Args.DropEnumValues
+		if args.Model != prevState.Models["User"] || len(args.Values) != 1 {
+			t.Errorf("expected DropEnumValues to be called with old model")
+		}
+	})
+}
+
+func testDropEnumValuesOperation(
+	t *testing.T,
+	mockedEngine gomodel.MockedEngine,
+	prevState *AppState,
+) {
+	op := DropEnumValues{
+		Model:  "User",
+		Field:  "role",
+		Values: []gomodel.Value{"guest"},
+	}
+	fields := prevState.Models["User"].Fields()
+	fields["role"] = gomodel.EnumField{
+		Values: []gomodel.Value{"admin", "user", "guest"}, TypeName: "role",
+	}
+	prevState = &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": gomodel.New("User", fields, gomodel.Options{}).Model,
+		},
+	}
+	fields = prevState.Models["User"].Fields()
+	fields["role"] = gomodel.EnumField{
+		Values: []gomodel.Value{"admin", "user"}, TypeName: "role",
+	}
+	state := &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": gomodel.New("User", fields, gomodel.Options{}).Model,
+		},
+	}
+
+	t.Run("RunError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DropEnumValues = fmt.Errorf("db error")
+		if err := op.Run(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("RunSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DropEnumValues") != 1 {
+			t.Fatalf("expected engine DropEnumValues to be called")
+		}
+		args := mockedEngine.Args.DropEnumValues
+		if args.Model != state.Models["User"] || args.Field != "role" {
+			t.Errorf("expected DropEnumValues to be called with new model")
+		}
+	})
+
+	t.Run("BackwardsError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.AddEnumValues = fmt.Errorf("db error")
+		if err := op.Backwards(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("BackwardsSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Backwards(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AddEnumValues") != 1 {
+			t.Fatalf("expected engine AddEnumValues to be called")
+		}
+		args := mockedEngine.Args.AddEnumValues
+		if args.Model != prevState.Models["User"] || len(args.Values) != 1 {
+			t.Errorf("expected AddEnumValues to be called with old model")
+		}
+	})
+}
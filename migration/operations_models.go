@@ -1,6 +1,7 @@
 package migration
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/moiseshiraldo/gomodel"
 )
@@ -12,6 +13,10 @@ type CreateModel struct {
 	// the table will be created as {app_name}_{model_name} all lowercase.
 	Table  string `json:",omitempty"`
 	Fields gomodel.Fields
+	// Extends is the name of the concrete parent model, if any. The parent
+	// fields are inherited and the Fields only include the ones stored on the
+	// model table.
+	Extends string `json:",omitempty"`
 }
 
 // OpName returns the operation name.
@@ -32,9 +37,18 @@ func (op CreateModel) SetState(state *AppState) error {
 	for name, field := range op.Fields {
 		fields[name] = field
 	}
-	state.Models[op.Name] = gomodel.New(
-		op.Name, fields, gomodel.Options{Table: table},
-	).Model
+	model := gomodel.New(op.Name, fields, gomodel.Options{Table: table}).Model
+	if op.Extends != "" {
+		parent, ok := state.Models[op.Extends]
+		if !ok {
+			return fmt.Errorf("parent model not found: %s", op.Extends)
+		}
+		if err := model.SetupParent(parent); err != nil {
+			return err
+		}
+	}
+	model.SetupEncryptedFields()
+	state.Models[op.Name] = model
 	return nil
 }
 
@@ -71,6 +85,11 @@ func (op DeleteModel) SetState(state *AppState) error {
 	if _, ok := state.Models[op.Name]; !ok {
 		return fmt.Errorf("model not found: %s", op.Name)
 	}
+	for _, model := range state.Models {
+		if parent := model.Parent(); parent != nil && parent.Name() == op.Name {
+			return fmt.Errorf("model has children: %s", op.Name)
+		}
+	}
 	delete(state.Models, op.Name)
 	return nil
 }
@@ -93,11 +112,62 @@ func (op DeleteModel) Backwards(
 	return engine.CreateTable(prevState.Models[op.Name], true)
 }
 
-// AddIndex implements the Operation interface to add an index.
+// AddIndex implements the Operation interface to add an index. Besides the
+// indexed fields, it takes the gomodel.Index options for unique, partial,
+// expression and method indexes.
 type AddIndex struct {
-	Model  string
-	Name   string
-	Fields []string
+	Model       string
+	Name        string
+	Fields      []string
+	Expressions []gomodel.Expression
+	Unique      bool
+	Condition   gomodel.Conditioner
+	Method      string
+}
+
+// addIndexMarshaler is used to serialize the AddIndex condition.
+type addIndexMarshaler struct {
+	Model       string
+	Name        string
+	Fields      []string
+	Expressions []gomodel.Expression `json:",omitempty"`
+	Unique      bool                 `json:",omitempty"`
+	Condition   json.RawMessage      `json:",omitempty"`
+	Method      string               `json:",omitempty"`
+}
+
+// modelIndexes returns all the indexes of the given model, including the
+// plain ones from the Indexes map.
+func modelIndexes(model *gomodel.Model) gomodel.IndexDefinitions {
+	indexes := model.IndexDefinitions()
+	for name, fields := range model.Indexes() {
+		indexes[name] = gomodel.Index{Fields: fields}
+	}
+	return indexes
+}
+
+// newAddIndex returns the AddIndex operation for the given index definition.
+func newAddIndex(model string, name string, idx gomodel.Index) AddIndex {
+	return AddIndex{
+		Model:       model,
+		Name:        name,
+		Fields:      idx.Fields,
+		Expressions: idx.Expressions,
+		Unique:      idx.Unique,
+		Condition:   idx.Condition,
+		Method:      idx.Method,
+	}
+}
+
+// index returns the index definition of the operation.
+func (op AddIndex) index() gomodel.Index {
+	return gomodel.Index{
+		Fields:      op.Fields,
+		Expressions: op.Expressions,
+		Unique:      op.Unique,
+		Condition:   op.Condition,
+		Method:      op.Method,
+	}
 }
 
 // OpName returns the operation name.
@@ -111,7 +181,11 @@ func (op AddIndex) SetState(state *AppState) error {
 	if !ok {
 		return fmt.Errorf("model not found: %s", op.Model)
 	}
-	return model.AddIndex(op.Name, op.Fields...)
+	plain := len(op.Expressions) == 0 && !op.Unique && op.Method == ""
+	if plain && op.Condition == nil {
+		return model.AddIndex(op.Name, op.Fields...)
+	}
+	return model.AddIndexDefinition(op.Name, op.index())
 }
 
 // Run creates the index on the database.
@@ -132,6 +206,49 @@ func (op AddIndex) Backwards(
 	return engine.DropIndex(state.Models[op.Model], op.Name)
 }
 
+// MarshalJSON implements the json.Marshaler interface.
+func (op AddIndex) MarshalJSON() ([]byte, error) {
+	result := addIndexMarshaler{
+		Model:       op.Model,
+		Name:        op.Name,
+		Fields:      op.Fields,
+		Expressions: op.Expressions,
+		Unique:      op.Unique,
+		Method:      op.Method,
+	}
+	if op.Condition != nil {
+		condition, err := gomodel.MarshalConditioner(op.Condition)
+		if err != nil {
+			return nil, err
+		}
+		result.Condition = condition
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (op *AddIndex) UnmarshalJSON(data []byte) error {
+	r := addIndexMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	op.Model = r.Model
+	op.Name = r.Name
+	op.Fields = r.Fields
+	op.Expressions = r.Expressions
+	op.Unique = r.Unique
+	op.Method = r.Method
+	op.Condition = nil
+	if len(r.Condition) > 0 {
+		condition, err := gomodel.UnmarshalConditioner(r.Condition)
+		if err != nil {
+			return err
+		}
+		op.Condition = condition
+	}
+	return nil
+}
+
 // RemoveIndex implements the Operation interface to remove an index.
 type RemoveIndex struct {
 	Model string
@@ -167,6 +284,134 @@ func (op RemoveIndex) Backwards(
 	state *AppState,
 	prevState *AppState,
 ) error {
-	model := prevState.Models[op.Model]
-	return engine.AddIndex(model, op.Name, model.Indexes()[op.Name]...)
+	return engine.AddIndex(prevState.Models[op.Model], op.Name)
+}
+
+// AddConstraint implements the Operation interface to add a constraint.
+type AddConstraint struct {
+	Model      string
+	Name       string
+	Constraint gomodel.Constraint
+}
+
+// addConstraintMarshaler is used to serialize the AddConstraint constraint.
+type addConstraintMarshaler struct {
+	Model      string
+	Name       string
+	Constraint json.RawMessage
+}
+
+// OpName returns the operation name.
+func (op AddConstraint) OpName() string {
+	return "AddConstraint"
+}
+
+// SetState adds the constraint to the model in the given application state.
+func (op AddConstraint) SetState(state *AppState) error {
+	model, ok := state.Models[op.Model]
+	if !ok {
+		return fmt.Errorf("model not found: %s", op.Model)
+	}
+	return model.AddConstraint(op.Name, op.Constraint)
+}
+
+// Run creates the constraint on the database.
+func (op AddConstraint) Run(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	return engine.AddConstraint(state.Models[op.Model], op.Name)
+}
+
+// Backwards drops the constraint from the database.
+func (op AddConstraint) Backwards(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	return engine.DropConstraint(state.Models[op.Model], op.Name)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (op AddConstraint) MarshalJSON() ([]byte, error) {
+	constraint, err := gomodel.MarshalConstraint(op.Constraint)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(addConstraintMarshaler{op.Model, op.Name, constraint})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (op *AddConstraint) UnmarshalJSON(data []byte) error {
+	r := addConstraintMarshaler{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	constraint, err := gomodel.UnmarshalConstraint(r.Constraint)
+	if err != nil {
+		return err
+	}
+	op.Model = r.Model
+	op.Name = r.Name
+	op.Constraint = constraint
+	return nil
+}
+
+// RemoveConstraint implements the Operation interface to remove a constraint.
+type RemoveConstraint struct {
+	Model string
+	Name  string
+}
+
+// OpName returns the operation name.
+func (op RemoveConstraint) OpName() string {
+	return "RemoveConstraint"
+}
+
+// SetState removes the constraint from the model in the given application
+// state.
+func (op RemoveConstraint) SetState(state *AppState) error {
+	model, ok := state.Models[op.Model]
+	if !ok {
+		return fmt.Errorf("model not found: %s", op.Model)
+	}
+	return model.RemoveConstraint(op.Name)
+}
+
+// Run drops the constraint from the database.
+func (op RemoveConstraint) Run(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	return engine.DropConstraint(prevState.Models[op.Model], op.Name)
+}
+
+// Backwards creates the constraint on the database.
+func (op RemoveConstraint) Backwards(
+	engine gomodel.Engine,
+	state *AppState,
+	prevState *AppState,
+) error {
+	return engine.AddConstraint(prevState.Models[op.Model], op.Name)
+}
+
+// indexChanged returns true if the given index definitions are different.
+func indexChanged(old gomodel.Index, new gomodel.Index) bool {
+	// Indexes are compared by their JSON encoding, as the values loaded from
+	// migration files don't keep the original types.
+	oldData, _ := json.Marshal(old)
+	newData, _ := json.Marshal(new)
+	return string(oldData) != string(newData)
+}
+
+// constraintChanged returns true if the given constraints have a different
+// definition.
+func constraintChanged(old gomodel.Constraint, new gomodel.Constraint) bool {
+	// Constraints are compared by their JSON encoding, as the values loaded
+	// from migration files don't keep the original types.
+	oldData, _ := gomodel.MarshalConstraint(old)
+	newData, _ := gomodel.MarshalConstraint(new)
+	return string(oldData) != string(newData)
 }
@@ -97,6 +97,23 @@ func TestModelOperationsState(t *testing.T) {
 		}
 	})
 
+	t.Run("AddIndexDefinition", func(t *testing.T) {
+		op := AddIndex{
+			Model:  "User",
+			Name:   "test_unique_index",
+			Fields: []string{"email"},
+			Unique: true,
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		model := appState.Models["User"]
+		idx, ok := model.IndexDefinitions()["test_unique_index"]
+		if !ok || !idx.Unique {
+			t.Errorf("index definition was not added to model state")
+		}
+	})
+
 	t.Run("RemoveIndexNoModel", func(t *testing.T) {
 		op := RemoveIndex{Model: "Transaction", Name: "test_index"}
 		if err := op.SetState(appState); err == nil {
@@ -122,6 +139,146 @@ func TestModelOperationsState(t *testing.T) {
 		}
 	})
 
+	t.Run("AddConstraintNoModel", func(t *testing.T) {
+		op := AddConstraint{
+			Model:      "Transaction",
+			Name:       "test_unique",
+			Constraint: gomodel.UniqueConstraint{Fields: []string{"email"}},
+		}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model not found error")
+		}
+	})
+
+	t.Run("AddConstraintNoField", func(t *testing.T) {
+		op := AddConstraint{
+			Model:      "User",
+			Name:       "test_unique",
+			Constraint: gomodel.UniqueConstraint{Fields: []string{"username"}},
+		}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected unknown field error")
+		}
+	})
+
+	t.Run("AddConstraint", func(t *testing.T) {
+		op := AddConstraint{
+			Model:      "User",
+			Name:       "test_unique",
+			Constraint: gomodel.UniqueConstraint{Fields: []string{"email"}},
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		model := appState.Models["User"]
+		if _, ok := model.Constraints()["test_unique"]; !ok {
+			t.Errorf("constraint was not added to model state")
+		}
+	})
+
+	t.Run("AddDuplicateConstraint", func(t *testing.T) {
+		op := AddConstraint{
+			Model:      "User",
+			Name:       "test_unique",
+			Constraint: gomodel.UniqueConstraint{Fields: []string{"email"}},
+		}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected duplicate constraint error")
+		}
+	})
+
+	t.Run("RemoveConstraintNoModel", func(t *testing.T) {
+		op := RemoveConstraint{Model: "Transaction", Name: "test_unique"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model not found error")
+		}
+	})
+
+	t.Run("RemoveMissingConstraint", func(t *testing.T) {
+		op := RemoveConstraint{Model: "User", Name: "missing_constraint"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected constraint not found error")
+		}
+	})
+
+	t.Run("RemoveConstraint", func(t *testing.T) {
+		op := RemoveConstraint{Model: "User", Name: "test_unique"}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		model := appState.Models["User"]
+		if _, found := model.Constraints()["test_unique"]; found {
+			t.Errorf("constraint was not removed from model state")
+		}
+	})
+
+	t.Run("AddChildModelMissingParent", func(t *testing.T) {
+		op := CreateModel{Name: "Admin", Extends: "Member"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected parent model not found error")
+		}
+	})
+
+	t.Run("AddChildModel", func(t *testing.T) {
+		parentOp := CreateModel{
+			Name: "Member",
+			Fields: gomodel.Fields{
+				"id":   gomodel.IntegerField{PrimaryKey: true, Auto: true},
+				"name": gomodel.CharField{MaxLength: 100},
+			},
+		}
+		if err := parentOp.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		op := CreateModel{
+			Name:    "Admin",
+			Extends: "Member",
+			Fields: gomodel.Fields{
+				"member_ptr": gomodel.IntegerField{Unique: true},
+				"level":      gomodel.IntegerField{},
+			},
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		model := appState.Models["Admin"]
+		if model.Parent() != appState.Models["Member"] {
+			t.Fatal("expected Member parent")
+		}
+		if _, ok := model.Fields()["name"]; !ok {
+			t.Errorf("model state missing inherited name field")
+		}
+		if _, ok := model.LocalFields()["name"]; ok {
+			t.Errorf("expected name not to be a local field")
+		}
+	})
+
+	t.Run("AddParentFields", func(t *testing.T) {
+		op := AddFields{
+			Model:  "Member",
+			Fields: gomodel.Fields{"bio": gomodel.TextField{Null: true}},
+		}
+		if err := op.SetState(appState); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := appState.Models["Admin"].Fields()["bio"]; !ok {
+			t.Errorf("child model state missing inherited bio field")
+		}
+	})
+
+	t.Run("RemoveParentModel", func(t *testing.T) {
+		op := DeleteModel{Name: "Member"}
+		if err := op.SetState(appState); err == nil {
+			t.Errorf("expected model has children error")
+		}
+		for _, name := range []string{"Admin", "Member"} {
+			op := DeleteModel{Name: name}
+			if err := op.SetState(appState); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
 	t.Run("RemoveMissingModel", func(t *testing.T) {
 		op := DeleteModel{Name: "Transaction"}
 		if err := op.SetState(appState); err == nil {
@@ -185,6 +342,12 @@ func TestModelOperations(t *testing.T) {
 	t.Run("RemoveIndex", func(t *testing.T) {
 		testRemoveIndexOperation(t, engine, appState)
 	})
+	t.Run("AddConstraint", func(t *testing.T) {
+		testAddConstraintOperation(t, engine, appState)
+	})
+	t.Run("RemoveConstraint", func(t *testing.T) {
+		testRemoveConstraintOperation(t, engine, appState)
+	})
 }
 
 func testAddModelOperation(
@@ -409,3 +572,135 @@ func testRemoveIndexOperation(
 		}
 	})
 }
+
+func testAddConstraintOperation(
+	t *testing.T,
+	mockedEngine gomodel.MockedEngine,
+	prevState *AppState,
+) {
+	constraint := gomodel.UniqueConstraint{Fields: []string{"email"}}
+	op := AddConstraint{
+		Model:      "User",
+		Name:       "test_unique",
+		Constraint: constraint,
+	}
+	model := gomodel.New(
+		"User",
+		prevState.Models["User"].Fields(),
+		gomodel.Options{
+			Constraints: gomodel.Constraints{"test_unique": constraint},
+		},
+	).Model
+	state := &AppState{
+		app: prevState.app,
+		Models: map[string]*gomodel.Model{
+			"User": model,
+		},
+	}
+
+	t.Run("RunError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.AddConstraint = fmt.Errorf("db error")
+		if err := op.Run(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("RunSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AddConstraint") != 1 {
+			t.Errorf("expected engine AddConstraint to be called")
+		}
+		if mockedEngine.Args.AddConstraint.Model != model {
+			t.Errorf("expected constraint to be added to the new state model")
+		}
+	})
+
+	t.Run("BackwardsError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DropConstraint = fmt.Errorf("db error")
+		if err := op.Backwards(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("BackwardsSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Backwards(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DropConstraint") != 1 {
+			t.Errorf("expected engine DropConstraint to be called")
+		}
+	})
+}
+
+func testRemoveConstraintOperation(
+	t *testing.T,
+	mockedEngine gomodel.MockedEngine,
+	state *AppState,
+) {
+	op := RemoveConstraint{
+		Model: "User",
+		Name:  "test_unique",
+	}
+	model := gomodel.New(
+		"User",
+		state.Models["User"].Fields(),
+		gomodel.Options{
+			Constraints: gomodel.Constraints{
+				"test_unique": gomodel.UniqueConstraint{
+					Fields: []string{"email"},
+				},
+			},
+		},
+	).Model
+	prevState := &AppState{
+		app: state.app,
+		Models: map[string]*gomodel.Model{
+			"User": model,
+		},
+	}
+
+	t.Run("RunError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DropConstraint = fmt.Errorf("db error")
+		if err := op.Run(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("RunSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Run(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DropConstraint") != 1 {
+			t.Errorf("expected engine DropConstraint to be called")
+		}
+		if mockedEngine.Args.DropConstraint.Model != model {
+			t.Errorf("expected constraint to be dropped from previous model")
+		}
+	})
+
+	t.Run("BackwardsError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.AddConstraint = fmt.Errorf("db error")
+		if err := op.Backwards(mockedEngine, state, prevState); err == nil {
+			t.Errorf("expected db error")
+		}
+	})
+
+	t.Run("BackwardsSuccess", func(t *testing.T) {
+		mockedEngine.Reset()
+		if err := op.Backwards(mockedEngine, state, prevState); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("AddConstraint") != 1 {
+			t.Errorf("expected engine AddConstraint to be called")
+		}
+	})
+}
@@ -108,6 +108,91 @@ func TestOperationList(t *testing.T) {
 		}
 	})
 
+	t.Run("ConstraintOperation", func(t *testing.T) {
+		opList := OperationList{
+			AddConstraint{
+				Model: "User",
+				Name:  "unique_active_email",
+				Constraint: gomodel.UniqueConstraint{
+					Fields:    []string{"email"},
+					Condition: gomodel.Q{"active": true},
+				},
+			},
+		}
+		data, err := opList.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := OperationList{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 {
+			t.Fatal("expected operation list to contain one operation")
+		}
+		op, ok := result[0].(*AddConstraint)
+		if !ok || op.Model != "User" || op.Name != "unique_active_email" {
+			t.Fatalf("expected AddConstraint operation, got %v", result[0])
+		}
+		unique, ok := op.Constraint.(gomodel.UniqueConstraint)
+		if !ok || len(unique.Fields) != 1 || unique.Condition == nil {
+			t.Errorf("expected unique constraint, got %v", op.Constraint)
+		}
+	})
+
+	t.Run("PlainIndexOperation", func(t *testing.T) {
+		opList := OperationList{
+			AddIndex{
+				Model:  "User",
+				Name:   "email_idx",
+				Fields: []string{"email"},
+			},
+		}
+		data, err := opList.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `[{"AddIndex":{"Model":"User","Name":"email_idx",` +
+			`"Fields":["email"]}}]`
+		if string(data) != expected {
+			t.Fatalf("expected %s, got %s", expected, string(data))
+		}
+	})
+
+	t.Run("IndexOperation", func(t *testing.T) {
+		opList := OperationList{
+			AddIndex{
+				Model:       "User",
+				Name:        "email_idx",
+				Expressions: []gomodel.Expression{gomodel.Lower("email")},
+				Unique:      true,
+				Condition:   gomodel.Q{"active": true},
+				Method:      "btree",
+			},
+		}
+		data, err := opList.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := OperationList{}
+		if err := result.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != 1 {
+			t.Fatal("expected operation list to contain one operation")
+		}
+		op, ok := result[0].(*AddIndex)
+		if !ok || op.Model != "User" || op.Name != "email_idx" {
+			t.Fatalf("expected AddIndex operation, got %v", result[0])
+		}
+		if !op.Unique || op.Method != "btree" || op.Condition == nil {
+			t.Errorf("expected unique partial btree index, got %v", op)
+		}
+		if len(op.Expressions) != 1 || op.Expressions[0] != `LOWER("email")` {
+			t.Errorf("expected LOWER expression, got %v", op.Expressions)
+		}
+	})
+
 	t.Run("Marshal", func(t *testing.T) {
 		opList := OperationList{&mockedOperation{}}
 		data, err := opList.MarshalJSON()
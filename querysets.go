@@ -71,15 +71,29 @@ type QuerySet interface {
 	// Update modifies the database rows matching the collection of objects
 	// represented by the QuerySet with the given values.
 	Update(values Container) (int64, error)
+	// UpdateReturning works as Update, but returns the list of instances
+	// holding the updated values of the modified objects.
+	UpdateReturning(values Container) ([]*Instance, error)
 	// Delete removes the database rows matching the collection of objects
-	// represented by the QuerySet.
+	// represented by the QuerySet. If the model is soft deleted, the rows are
+	// kept and the deletion time is set instead.
 	Delete() (int64, error)
+	// DeleteReturning works as Delete, but returns the list of instances
+	// representing the removed objects.
+	DeleteReturning() ([]*Instance, error)
+	// HardDelete removes the database rows matching the collection of objects
+	// represented by the QuerySet, even if the model is soft deleted.
+	HardDelete() (int64, error)
+	// Restore clears the deletion time of the soft deleted objects represented
+	// by the QuerySet.
+	Restore() (int64, error)
 }
 
 // GenericQuerySet implements the QuerySet interface.
 type GenericQuerySet struct {
 	model     *Model
 	container Container
+	deleted   deletedScope
 	base      QuerySet
 	database  string
 	tx        *Transaction
@@ -98,6 +112,8 @@ func (qs GenericQuerySet) New(m *Model, parent QuerySet) QuerySet {
 	qs.base = parent
 	qs.database = "default"
 	qs.fields = fields
+	qs.cond = m.meta.Filter
+	qs = qs.scopeDeleted(qs.deleted)
 	return parent.Wrap(qs)
 }
 
@@ -121,6 +137,14 @@ func (qs GenericQuerySet) containerError(err error) error {
 	return &ContainerError{qs.trace(err)}
 }
 
+// dbName returns the identifier of the queryset target database.
+func (qs GenericQuerySet) dbName() string {
+	if qs.tx != nil {
+		return qs.tx.DB.id
+	}
+	return qs.database
+}
+
 func (qs GenericQuerySet) engine() (Engine, error) {
 	if qs.tx != nil {
 		return qs.tx.Engine, nil
@@ -207,46 +231,36 @@ func (qs GenericQuerySet) Query() (Query, error) {
 	return eng.SelectQuery(qs.model, options)
 }
 
-func (qs GenericQuerySet) load(start int64, end int64) ([]*Instance, error) {
-	if start < 0 || end != -1 && start >= end || end < -1 {
-		err := fmt.Errorf("invalid slice indexes: %d %d", start, end)
-		return nil, &QuerySetError{qs.trace(err)}
-	}
-	result := []*Instance{}
-	eng, err := qs.engine()
-	if err != nil {
-		return nil, err
-	}
+// checkContainer returns an error if the QuerySet container is not valid to
+// hold the selected fields.
+func (qs GenericQuerySet) checkContainer() error {
 	if !isValidContainer(qs.container) {
-		return nil, qs.containerError(fmt.Errorf("invalid container"))
+		return qs.containerError(fmt.Errorf("invalid container"))
 	}
 	container := newContainer(qs.container)
 	recipients := getRecipients(container, qs.fields, qs.model)
 	if len(recipients) != len(qs.fields) {
 		err := fmt.Errorf("invalid container recipients")
-		return nil, qs.containerError(err)
-	}
-	options := QueryOptions{
-		Conditioner: qs.cond,
-		Fields:      qs.fields,
-		Start:       start,
-		End:         end,
-	}
-	rows, err := eng.GetRows(qs.model, options)
-	if err != nil {
-		return nil, qs.dbError(err)
+		return qs.containerError(err)
 	}
+	return nil
+}
+
+// instances scans the given rows and returns the list of instances.
+func (qs GenericQuerySet) instances(rows Rows) ([]*Instance, error) {
 	defer rows.Close()
+	result := []*Instance{}
+	var recipients []interface{}
 	for rows.Next() {
-		container = newContainer(qs.container)
-		if _, ok := container.(Setter); !ok {
+		container := newContainer(qs.container)
+		if _, ok := container.(Setter); !ok || recipients == nil {
 			recipients = getRecipients(container, qs.fields, qs.model)
 		}
 		err := rows.Scan(recipients...)
 		if err != nil {
 			return nil, qs.containerError(err)
 		}
-		instance := &Instance{qs.model, container}
+		instance := newInstance(qs.model, container)
 		if _, ok := container.(Setter); ok {
 			for i, name := range qs.fields {
 				val := reflect.Indirect(
@@ -255,15 +269,48 @@ func (qs GenericQuerySet) load(start int64, end int64) ([]*Instance, error) {
 				instance.Set(name, qs.model.fields[name].Value(val))
 			}
 		}
+		instance.snapshot(qs.fields...)
+		event := Event{
+			Signal:   PostInit,
+			Instance: instance,
+			Database: qs.dbName(),
+		}
+		if err := send(event); err != nil {
+			return nil, err
+		}
 		result = append(result, instance)
 	}
-	err = rows.Err()
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, qs.dbError(err)
 	}
 	return result, nil
 }
 
+func (qs GenericQuerySet) load(start int64, end int64) ([]*Instance, error) {
+	if start < 0 || end != -1 && start >= end || end < -1 {
+		err := fmt.Errorf("invalid slice indexes: %d %d", start, end)
+		return nil, &QuerySetError{qs.trace(err)}
+	}
+	eng, err := qs.engine()
+	if err != nil {
+		return nil, err
+	}
+	if err := qs.checkContainer(); err != nil {
+		return nil, err
+	}
+	options := QueryOptions{
+		Conditioner: qs.cond,
+		Fields:      qs.fields,
+		Start:       start,
+		End:         end,
+	}
+	rows, err := eng.GetRows(qs.model, options)
+	if err != nil {
+		return nil, qs.dbError(err)
+	}
+	return qs.instances(rows)
+}
+
 // Load implements the Load method of the QuerySet interface.
 func (qs GenericQuerySet) Load() ([]*Instance, error) {
 	return qs.load(0, -1)
@@ -317,13 +364,18 @@ func (qs GenericQuerySet) Get(c Conditioner) (*Instance, error) {
 		err := fmt.Errorf("object does not exist")
 		return nil, &ObjectNotFoundError{qs.trace(err)}
 	}
-	instance := &Instance{qs.model, container}
+	instance := newInstance(qs.model, container)
 	if _, ok := container.(Setter); ok {
 		for i, name := range qs.fields {
 			val := reflect.Indirect(reflect.ValueOf(recipients[i])).Interface()
 			instance.Set(name, qs.model.fields[name].Value(val))
 		}
 	}
+	instance.snapshot(qs.fields...)
+	event := Event{Signal: PostInit, Instance: instance, Database: qs.dbName()}
+	if err := send(event); err != nil {
+		return nil, err
+	}
 	return instance, nil
 }
 
@@ -353,15 +405,11 @@ func (qs GenericQuerySet) Count() (int64, error) {
 	return count, nil
 }
 
-// Update implements the Update method of the QuerySet interface.
-func (qs GenericQuerySet) Update(container Container) (int64, error) {
-	eng, err := qs.engine()
-	if err != nil {
-		return 0, err
-	}
+// updateValues returns the values to update from the given container.
+func (qs GenericQuerySet) updateValues(container Container) (Values, error) {
 	if !isValidContainer(container) {
 		err := fmt.Errorf("invalid values container")
-		return 0, qs.containerError(err)
+		return nil, qs.containerError(err)
 	}
 	dbValues := Values{}
 	for name, field := range qs.model.fields {
@@ -371,23 +419,112 @@ func (qs GenericQuerySet) Update(container Container) (int64, error) {
 			dbValues[name] = val
 		}
 	}
-	options := QueryOptions{Conditioner: qs.cond}
-	rows, err := eng.UpdateRows(qs.model, dbValues, options)
+	return dbValues, nil
+}
+
+// updateRows sets the given values on the rows represented by the queryset
+// and returns the number of affected rows.
+func (qs GenericQuerySet) updateRows(values Values) (int64, error) {
+	var rows int64
+	err := qs.recordChanges(values, func(qs GenericQuerySet) error {
+		eng, err := qs.engine()
+		if err != nil {
+			return err
+		}
+		options := QueryOptions{Conditioner: qs.cond}
+		rows, err = eng.UpdateRows(qs.model, values, options)
+		if err != nil {
+			return qs.dbError(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return 0, qs.dbError(err)
+		return 0, err
 	}
 	return rows, nil
 }
 
-// Delete implements the Delete method of the QuerySet interface.
-func (qs GenericQuerySet) Delete() (int64, error) {
-	eng, err := qs.engine()
+// Update implements the Update method of the QuerySet interface.
+func (qs GenericQuerySet) Update(container Container) (int64, error) {
+	dbValues, err := qs.updateValues(container)
 	if err != nil {
 		return 0, err
 	}
-	rows, err := eng.DeleteRows(qs.model, QueryOptions{Conditioner: qs.cond})
+	return qs.updateRows(dbValues)
+}
+
+// UpdateReturning implements the UpdateReturning method of the QuerySet
+// interface.
+func (qs GenericQuerySet) UpdateReturning(
+	container Container,
+) ([]*Instance, error) {
+	dbValues, err := qs.updateValues(container)
 	if err != nil {
-		return 0, qs.dbError(err)
+		return nil, err
 	}
-	return rows, nil
+	if err := qs.checkContainer(); err != nil {
+		return nil, err
+	}
+	result := []*Instance{}
+	err = qs.recordChanges(dbValues, func(qs GenericQuerySet) error {
+		eng, err := qs.engine()
+		if err != nil {
+			return err
+		}
+		options := QueryOptions{Conditioner: qs.cond, Fields: qs.fields}
+		rows, err := eng.UpdateRowsReturning(qs.model, dbValues, options)
+		if err != nil {
+			return qs.dbError(err)
+		}
+		result, err = qs.instances(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete implements the Delete method of the QuerySet interface.
+func (qs GenericQuerySet) Delete() (int64, error) {
+	if qs.model.meta.SoftDelete {
+		values := Values{softDeleteField: time.Now()}
+		return qs.updateRows(values)
+	}
+	return qs.HardDelete()
+}
+
+// DeleteReturning implements the DeleteReturning method of the QuerySet
+// interface.
+func (qs GenericQuerySet) DeleteReturning() ([]*Instance, error) {
+	if err := qs.checkContainer(); err != nil {
+		return nil, err
+	}
+	var values Values
+	if qs.model.meta.SoftDelete {
+		values = Values{softDeleteField: time.Now()}
+	}
+	result := []*Instance{}
+	err := qs.recordChanges(values, func(qs GenericQuerySet) error {
+		eng, err := qs.engine()
+		if err != nil {
+			return err
+		}
+		options := QueryOptions{Conditioner: qs.cond, Fields: qs.fields}
+		var rows Rows
+		if values != nil {
+			rows, err = eng.UpdateRowsReturning(qs.model, values, options)
+		} else {
+			rows, err = eng.DeleteRowsReturning(qs.model, options)
+		}
+		if err != nil {
+			return qs.dbError(err)
+		}
+		result, err = qs.instances(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
@@ -663,6 +663,61 @@ func TestGenericQuerySet(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateReturningInvalidValues", func(t *testing.T) {
+		mockedEngine.Reset()
+		qs := GenericQuerySet{model: model, database: "default"}
+		_, err := qs.UpdateReturning("invalid")
+		if _, ok := err.(*ContainerError); !ok {
+			t.Errorf("expected ContainerError, got %T", err)
+		}
+	})
+
+	t.Run("UpdateReturningDBError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRowsReturning.Err = fmt.Errorf("db error")
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: Values{},
+			fields:    []string{"id", "email"},
+		}
+		_, err := qs.UpdateReturning(Values{"active": true})
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("UpdateReturning", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRowsReturning.Rows = &rowsMocker{2}
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: Values{},
+			fields:    []string{"id", "email"},
+		}
+		result, err := qs.UpdateReturning(Values{"active": true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("UpdateRowsReturning") != 1 {
+			t.Fatal("expected engine UpdateRowsReturning method to be called")
+		}
+		args := mockedEngine.Args.UpdateRowsReturning
+		if _, ok := args.Values["updated"]; !ok {
+			t.Error("expected UpdateRowsReturning values to contain updated")
+		}
+		if len(args.Options.Fields) != 2 {
+			t.Errorf("expected 2 returning fields, got %d", len(args.Options.Fields))
+		}
+		if len(result) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(result))
+		}
+		if id := result[1].Get("id"); id != int32(1) {
+			t.Errorf("expected id to be 1, got %v", id)
+		}
+	})
+
 	t.Run("DeleteInvalidDB", func(t *testing.T) {
 		mockedEngine.Reset()
 		qs := GenericQuerySet{model: model, database: "slave"}
@@ -700,4 +755,54 @@ func TestGenericQuerySet(t *testing.T) {
 			t.Errorf("expected 4, got %d", result)
 		}
 	})
+
+	t.Run("DeleteReturningInvalidContainer", func(t *testing.T) {
+		mockedEngine.Reset()
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: "invalid",
+			fields:    []string{"id", "email"},
+		}
+		_, err := qs.DeleteReturning()
+		if _, ok := err.(*ContainerError); !ok {
+			t.Errorf("expected ContainerError, got %T", err)
+		}
+	})
+
+	t.Run("DeleteReturningDBError", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DeleteRowsReturning.Err = fmt.Errorf("db error")
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: Values{},
+			fields:    []string{"id", "email"},
+		}
+		_, err := qs.DeleteReturning()
+		if _, ok := err.(*DatabaseError); !ok {
+			t.Errorf("expected DatabaseError, got %T", err)
+		}
+	})
+
+	t.Run("DeleteReturning", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.DeleteRowsReturning.Rows = &rowsMocker{3}
+		qs := GenericQuerySet{
+			model:     model,
+			database:  "default",
+			container: Values{},
+			fields:    []string{"id", "email"},
+		}
+		result, err := qs.DeleteReturning()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DeleteRowsReturning") != 1 {
+			t.Fatal("expected engine DeleteRowsReturning method to be called")
+		}
+		if len(result) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(result))
+		}
+	})
 }
@@ -0,0 +1,109 @@
+package gomodel
+
+import (
+	"sync"
+)
+
+// A Signal identifies an instance lifecycle event.
+type Signal int
+
+const (
+	// PreSave is sent before an instance is saved or created. Receivers can
+	// abort the operation by returning an error.
+	PreSave Signal = iota
+	// PostSave is sent after an instance is saved or created.
+	PostSave
+	// PreDelete is sent before an instance is deleted. Receivers can abort the
+	// operation by returning an error.
+	PreDelete
+	// PostDelete is sent after an instance is deleted.
+	PostDelete
+	// PostInit is sent after an instance is built by the Dispatcher New method
+	// or loaded from the database.
+	PostInit
+)
+
+// Event holds the details of a sent signal.
+type Event struct {
+	// Signal is the sent signal.
+	Signal Signal
+	// Instance is the instance the signal is sent for.
+	Instance *Instance
+	// Created is true if the saved instance is a new row.
+	Created bool
+	// Database is the identifier of the target database, blank for instances
+	// not related to any database.
+	Database string
+	// Fields is the list of saved fields. Empty for other signals.
+	Fields []string
+}
+
+// A Receiver is a function connected to a signal. An error returned by a
+// receiver stops the propagation of the signal and is returned by the method
+// sending it.
+type Receiver func(event Event) error
+
+// connection holds a receiver connected to a signal.
+type connection struct {
+	model    *Model
+	receiver Receiver
+}
+
+// signals holds the receivers connected to each signal.
+var signals = struct {
+	sync.RWMutex
+	receivers map[Signal][]*connection
+}{receivers: map[Signal][]*connection{}}
+
+// Connect connects the receiver to the given signal sent for instances of the
+// given model, or any model if nil. Receivers are called in the order they are
+// connected. The returned function disconnects the receiver.
+func Connect(signal Signal, model *Model, receiver Receiver) func() {
+	conn := &connection{model, receiver}
+	signals.Lock()
+	defer signals.Unlock()
+	signals.receivers[signal] = append(signals.receivers[signal], conn)
+	return func() {
+		signals.Lock()
+		defer signals.Unlock()
+		conns := signals.receivers[signal]
+		for i, c := range conns {
+			if c == conn {
+				signals.receivers[signal] = append(
+					conns[:i:i], conns[i+1:]...,
+				)
+				break
+			}
+		}
+	}
+}
+
+// DisconnectAll disconnects all the receivers of the given signals, or all
+// signals if none is provided.
+func DisconnectAll(sigs ...Signal) {
+	signals.Lock()
+	defer signals.Unlock()
+	if len(sigs) == 0 {
+		signals.receivers = map[Signal][]*connection{}
+	}
+	for _, signal := range sigs {
+		delete(signals.receivers, signal)
+	}
+}
+
+// send calls the receivers connected to the event signal for the instance
+// model, returning the first error.
+func send(event Event) error {
+	signals.RLock()
+	conns := signals.receivers[event.Signal]
+	signals.RUnlock()
+	for _, conn := range conns {
+		if conn.model != nil && conn.model != event.Instance.model {
+			continue
+		}
+		if err := conn.receiver(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package gomodel
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestSignals tests the signal receivers
+func TestSignals(t *testing.T) {
+	// Model setup
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":    IntegerField{Auto: true},
+			"email": CharField{MaxLength: 100},
+		},
+		meta: Options{Container: Values{}},
+	}
+	other := &Model{
+		name:   "Group",
+		pk:     "id",
+		fields: Fields{"id": IntegerField{Auto: true}},
+		meta:   Options{Container: Values{}},
+	}
+	values := Values{"id": 1, "email": "user@test.com"}
+	manager := Manager{Model: model}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+	defer DisconnectAll()
+
+	t.Run("ModelReceivers", func(t *testing.T) {
+		defer DisconnectAll()
+		events := []string{}
+		Connect(PostInit, nil, func(e Event) error {
+			events = append(events, "global:"+e.Instance.model.name)
+			return nil
+		})
+		Connect(PostInit, model, func(e Event) error {
+			events = append(events, "model:"+e.Instance.model.name)
+			return nil
+		})
+		if _, err := (Dispatcher{Model: model}).New(values); err != nil {
+			t.Fatal(err)
+		}
+		_, err := (Dispatcher{Model: other}).New(Values{"id": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"global:User", "model:User", "global:Group"}
+		if fmt.Sprint(events) != fmt.Sprint(expected) {
+			t.Errorf("expected %v, got %v", expected, events)
+		}
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		defer DisconnectAll()
+		calls := 0
+		disconnect := Connect(PostInit, model, func(e Event) error {
+			calls += 1
+			return nil
+		})
+		disconnect()
+		if _, err := (Dispatcher{Model: model}).New(values); err != nil {
+			t.Fatal(err)
+		}
+		if calls != 0 {
+			t.Errorf("expected disconnected receiver not to be called")
+		}
+	})
+
+	t.Run("SaveCreated", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		mockedEngine.Results.InsertRow.Id = 1
+		events := []Event{}
+		receiver := func(e Event) error {
+			events = append(events, e)
+			return nil
+		}
+		Connect(PreSave, model, receiver)
+		Connect(PostSave, model, receiver)
+		instance := &Instance{
+			model:     model,
+			container: Values{"email": "user@test.com"},
+		}
+		if err := instance.Save("email"); err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("expected two events, got %d", len(events))
+		}
+		if events[0].Signal != PreSave || events[1].Signal != PostSave {
+			t.Errorf("expected PreSave and PostSave events")
+		}
+		for _, e := range events {
+			if !e.Created || e.Database != "default" {
+				t.Errorf("unexpected event details: %+v", e)
+			}
+			if len(e.Fields) != 1 || e.Fields[0] != "email" {
+				t.Errorf("expected email field, got %v", e.Fields)
+			}
+		}
+		if id := events[1].Instance.Get("id"); id != int32(1) {
+			t.Errorf("expected PostSave instance with pk, got %v", id)
+		}
+	})
+
+	t.Run("SaveUpdated", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 1
+		created := true
+		Connect(PostSave, model, func(e Event) error {
+			created = e.Created
+			return nil
+		})
+		instance := &Instance{model: model, container: Values{"id": int32(1)}}
+		if err := instance.Save(); err != nil {
+			t.Fatal(err)
+		}
+		if created {
+			t.Errorf("expected Created flag to be false")
+		}
+	})
+
+	t.Run("SaveAborted", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		Connect(PreSave, nil, func(e Event) error {
+			return fmt.Errorf("read only")
+		})
+		instance := &Instance{
+			model:     model,
+			container: Values{"email": "user@test.com"},
+		}
+		if err := instance.Save(); err == nil {
+			t.Fatal("expected receiver error")
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Errorf("expected engine InsertRow not to be called")
+		}
+	})
+
+	t.Run("CreateAborted", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		var fields []string
+		Connect(PreSave, model, func(e Event) error {
+			fields = e.Fields
+			return fmt.Errorf("read only")
+		})
+		_, err := manager.Create(Values{"email": "user@test.com"})
+		if err == nil {
+			t.Fatal("expected receiver error")
+		}
+		if mockedEngine.Calls("InsertRow") != 0 {
+			t.Errorf("expected engine InsertRow not to be called")
+		}
+		if len(fields) != 1 || fields[0] != "email" {
+			t.Errorf("expected email field, got %v", fields)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		signals := []Signal{}
+		receiver := func(e Event) error {
+			signals = append(signals, e.Signal)
+			return nil
+		}
+		Connect(PreDelete, model, receiver)
+		Connect(PostDelete, model, receiver)
+		instance := &Instance{model: model, container: Values{"id": int32(1)}}
+		if err := instance.Delete(); err != nil {
+			t.Fatal(err)
+		}
+		if len(signals) != 2 || signals[1] != PostDelete {
+			t.Errorf("expected PreDelete and PostDelete, got %v", signals)
+		}
+	})
+
+	t.Run("DeleteAborted", func(t *testing.T) {
+		defer DisconnectAll()
+		mockedEngine.Reset()
+		Connect(PreDelete, model, func(e Event) error {
+			return fmt.Errorf("protected")
+		})
+		instance := &Instance{model: model, container: Values{"id": int32(1)}}
+		if err := instance.Delete(); err == nil {
+			t.Fatal("expected receiver error")
+		}
+		if mockedEngine.Calls("DeleteRows") != 0 {
+			t.Errorf("expected engine DeleteRows not to be called")
+		}
+	})
+}
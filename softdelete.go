@@ -0,0 +1,162 @@
+package gomodel
+
+import (
+	"fmt"
+	"time"
+)
+
+// softDeleteField is the name of the field holding the deletion time of soft
+// deleted objects.
+const softDeleteField = "deleted_at"
+
+// deletedScope sets which soft deleted objects a queryset represents.
+type deletedScope int
+
+const (
+	excludeDeleted deletedScope = iota
+	includeDeleted
+	onlyDeleted
+)
+
+// setupSoftDelete adds the deleted_at field to soft delete models, unless
+// already defined or inherited.
+func (m *Model) setupSoftDelete() error {
+	if !m.meta.SoftDelete {
+		return nil
+	}
+	field, ok := m.fields[softDeleteField]
+	if !ok {
+		m.fields[softDeleteField] = DateTimeField{Null: true}
+	} else if !field.IsNull() {
+		return fmt.Errorf("%s field must be nullable", softDeleteField)
+	}
+	return nil
+}
+
+// scopeDeleted returns the queryset filtered by the given deleted scope.
+func (qs GenericQuerySet) scopeDeleted(scope deletedScope) GenericQuerySet {
+	if !qs.model.meta.SoftDelete {
+		if scope == onlyDeleted {
+			// No object is soft deleted, and the pk is never null.
+			return qs.addConditioner(Q{"pk": nil})
+		}
+		return qs
+	}
+	switch scope {
+	case excludeDeleted:
+		return qs.addConditioner(Q{softDeleteField: nil})
+	case onlyDeleted:
+		if qs.cond == nil {
+			qs.cond = Q{}
+		}
+		qs.cond = qs.cond.AndNot(Q{softDeleteField: nil})
+	}
+	return qs
+}
+
+// softDelete sets the deletion time of the object on the given engine.
+func (i Instance) softDelete(eng Engine, dbName string, pkVal Value) error {
+	old, err := i.historyValues(dbName, false, softDeleteField)
+	if err != nil {
+		return &ContainerError{i.trace(err)}
+	}
+	now := time.Now()
+	values := Values{softDeleteField: now}
+	options := QueryOptions{Conditioner: Q{"pk": pkVal}}
+	if _, err := eng.UpdateRows(i.model, values, options); err != nil {
+		return err
+	}
+	if err := i.Set(softDeleteField, now); err != nil {
+		return err
+	}
+	i.snapshot(softDeleteField)
+	return i.saveHistory(eng, dbName, false, old, softDeleteField)
+}
+
+// restore clears the deletion time of the object on the given target.
+func (i Instance) restore(target interface{}) error {
+	eng, dbName := i.engine(target)
+	if eng == nil {
+		return &DatabaseError{dbName, i.trace(fmt.Errorf("invalid target"))}
+	}
+	if !i.model.meta.SoftDelete {
+		err := fmt.Errorf("model not soft deleted")
+		return &DatabaseError{dbName, i.trace(err)}
+	}
+	pkVal, ok := i.GetIf("pk")
+	if !ok {
+		return &ContainerError{Trace: i.trace(fmt.Errorf("pk not found"))}
+	}
+	old, err := i.historyValues(dbName, false, softDeleteField)
+	if err != nil {
+		return &ContainerError{i.trace(err)}
+	}
+	return i.atomicHistory(target, func(target interface{}) error {
+		eng, _ := i.engine(target)
+		values := Values{softDeleteField: nil}
+		options := QueryOptions{Conditioner: Q{"pk": pkVal}}
+		if _, err := eng.UpdateRows(i.model, values, options); err != nil {
+			return &DatabaseError{dbName, i.trace(err)}
+		}
+		if err := i.Set(softDeleteField, nil); err != nil {
+			return err
+		}
+		i.snapshot(softDeleteField)
+		return i.saveHistory(eng, dbName, false, old, softDeleteField)
+	})
+}
+
+// HardDelete works as Delete, but the row is always removed from the table,
+// even if the model is soft deleted.
+func (i Instance) HardDelete() error {
+	return i.delete("default", true)
+}
+
+// HardDeleteOn works as HardDelete, but the row is removed from the given
+// target, that can be a *Transaction or a string representing a database
+// identifier.
+func (i Instance) HardDeleteOn(target interface{}) error {
+	return i.delete(target, true)
+}
+
+// Restore clears the deletion time of a soft deleted object on the default
+// database.
+func (i Instance) Restore() error {
+	return i.restore("default")
+}
+
+// RestoreOn works as Restore, but the object is restored on the given target,
+// that can be a *Transaction or a string representing a database identifier.
+func (i Instance) RestoreOn(target interface{}) error {
+	return i.restore(target)
+}
+
+// HardDelete implements the HardDelete method of the QuerySet interface.
+func (qs GenericQuerySet) HardDelete() (int64, error) {
+	var rows int64
+	err := qs.recordChanges(nil, func(qs GenericQuerySet) error {
+		eng, err := qs.engine()
+		if err != nil {
+			return err
+		}
+		options := QueryOptions{Conditioner: qs.cond}
+		rows, err = eng.DeleteRows(qs.model, options)
+		if err != nil {
+			return qs.dbError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// Restore implements the Restore method of the QuerySet interface.
+func (qs GenericQuerySet) Restore() (int64, error) {
+	if !qs.model.meta.SoftDelete {
+		err := fmt.Errorf("model not soft deleted")
+		return 0, &QuerySetError{qs.trace(err)}
+	}
+	return qs.updateRows(Values{softDeleteField: nil})
+}
@@ -0,0 +1,190 @@
+package gomodel
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSoftDelete tests the soft delete models
+func TestSoftDelete(t *testing.T) {
+	// Models setup
+	app := &Application{name: "blog", models: map[string]*Model{}}
+	post := New(
+		"Post",
+		Fields{"title": CharField{MaxLength: 100}},
+		Options{SoftDelete: true},
+	)
+	if err := post.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	tag := New("Tag", Fields{"name": CharField{MaxLength: 50}}, Options{})
+	if err := tag.Model.Register(app); err != nil {
+		t.Fatal(err)
+	}
+	// DB setup
+	engine, _ := enginesRegistry["mocker"].Start(Database{})
+	mockedEngine := engine.(MockedEngine)
+	dbRegistry["default"] = Database{id: "default", Engine: engine}
+	defer func() { dbRegistry = map[string]Database{} }()
+
+	t.Run("Setup", func(t *testing.T) {
+		field, ok := post.Model.fields["deleted_at"].(DateTimeField)
+		if !ok || !field.Null {
+			t.Errorf("expected nullable deleted_at field, got %#v", field)
+		}
+	})
+
+	t.Run("SetupNotNull", func(t *testing.T) {
+		model := New(
+			"Comment",
+			Fields{"deleted_at": DateTimeField{}},
+			Options{SoftDelete: true},
+		).Model
+		if err := model.Register(app); err == nil {
+			t.Error("expected not nullable field error")
+		}
+	})
+
+	t.Run("Managers", func(t *testing.T) {
+		qs := post.Objects.All().(GenericQuerySet)
+		if q, ok := qs.cond.(Q); !ok || len(q) != 1 || q["deleted_at"] != nil {
+			t.Errorf("expected deleted_at null condition, got %v", qs.cond)
+		}
+		if qs := post.AllObjects.All().(GenericQuerySet); qs.cond != nil {
+			t.Errorf("expected no condition, got %v", qs.cond)
+		}
+		qs = post.Deleted.All().(GenericQuerySet)
+		next, _, isNot := qs.cond.Next()
+		if _, ok := next.(Q); !ok || !isNot {
+			t.Errorf("expected deleted_at not null condition, got %v", qs.cond)
+		}
+	})
+
+	t.Run("NotSoftDeleteManagers", func(t *testing.T) {
+		if qs := tag.Objects.All().(GenericQuerySet); qs.cond != nil {
+			t.Errorf("expected no condition, got %v", qs.cond)
+		}
+		qs := tag.Deleted.All().(GenericQuerySet)
+		if q, ok := qs.cond.(Q); !ok || len(q) != 1 || q["pk"] != nil {
+			t.Errorf("expected pk null condition, got %v", qs.cond)
+		}
+	})
+
+	t.Run("InstanceDelete", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := &Instance{
+			model:     post.Model,
+			container: Values{"id": int32(1)},
+		}
+		if err := instance.Delete(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DeleteRows") != 0 {
+			t.Error("expected engine DeleteRows not to be called")
+		}
+		if mockedEngine.Calls("UpdateRows") != 1 {
+			t.Fatal("expected engine UpdateRows to be called")
+		}
+		args := mockedEngine.Args.UpdateRows
+		if _, ok := args.Values["deleted_at"].(time.Time); !ok {
+			t.Errorf("expected deleted_at time, got %v", args.Values)
+		}
+		if _, ok := instance.Get("deleted_at").(time.Time); !ok {
+			t.Error("expected instance deleted_at to be set")
+		}
+	})
+
+	t.Run("InstanceHardDelete", func(t *testing.T) {
+		mockedEngine.Reset()
+		instance := &Instance{
+			model:     post.Model,
+			container: Values{"id": int32(1)},
+		}
+		if err := instance.HardDelete(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DeleteRows") != 1 {
+			t.Error("expected engine DeleteRows to be called")
+		}
+	})
+
+	t.Run("InstanceRestore", func(t *testing.T) {
+		mockedEngine.Reset()
+		values := Values{"id": int32(1), "deleted_at": time.Now()}
+		instance := &Instance{model: post.Model, container: values}
+		if err := instance.Restore(); err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.UpdateRows
+		if val, ok := args.Values["deleted_at"]; !ok || val != nil {
+			t.Errorf("expected null deleted_at, got %v", args.Values)
+		}
+		if instance.Get("deleted_at") != nil {
+			t.Error("expected instance deleted_at to be cleared")
+		}
+	})
+
+	t.Run("InstanceRestoreNotSoftDelete", func(t *testing.T) {
+		instance := &Instance{
+			model:     tag.Model,
+			container: Values{"id": int32(1)},
+		}
+		if err := instance.Restore(); err == nil {
+			t.Error("expected model not soft deleted error")
+		}
+	})
+
+	t.Run("QuerySetDelete", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRows.Number = 2
+		rows, err := post.Objects.Filter(Q{"title": "Draft"}).Delete()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DeleteRows") != 0 {
+			t.Error("expected engine DeleteRows not to be called")
+		}
+		if rows != 2 {
+			t.Errorf("expected 2 rows, got %d", rows)
+		}
+		args := mockedEngine.Args.UpdateRows
+		if _, ok := args.Values["deleted_at"].(time.Time); !ok {
+			t.Errorf("expected deleted_at time, got %v", args.Values)
+		}
+	})
+
+	t.Run("QuerySetDeleteReturning", func(t *testing.T) {
+		mockedEngine.Reset()
+		mockedEngine.Results.UpdateRowsReturning.Rows = &rowsMocker{}
+		if _, err := post.Objects.All().DeleteReturning(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("UpdateRowsReturning") != 1 {
+			t.Error("expected engine UpdateRowsReturning to be called")
+		}
+	})
+
+	t.Run("QuerySetHardDelete", func(t *testing.T) {
+		mockedEngine.Reset()
+		if _, err := post.Deleted.All().HardDelete(); err != nil {
+			t.Fatal(err)
+		}
+		if mockedEngine.Calls("DeleteRows") != 1 {
+			t.Error("expected engine DeleteRows to be called")
+		}
+	})
+
+	t.Run("QuerySetRestore", func(t *testing.T) {
+		mockedEngine.Reset()
+		if _, err := post.Deleted.All().Restore(); err != nil {
+			t.Fatal(err)
+		}
+		args := mockedEngine.Args.UpdateRows
+		if val, ok := args.Values["deleted_at"]; !ok || val != nil {
+			t.Errorf("expected null deleted_at, got %v", args.Values)
+		}
+		if _, err := tag.Objects.All().Restore(); err == nil {
+			t.Error("expected model not soft deleted error")
+		}
+	})
+}
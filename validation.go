@@ -0,0 +1,194 @@
+package gomodel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidatorFunc is a custom validation function for field values, returning an
+// error if the given value is not valid. The value is nil for null values.
+type ValidatorFunc func(val Value) error
+
+// Validator is the interface implemented by fields with custom validation
+// rules, called after the common ones (null, blank, max length and choices).
+type Validator interface {
+	// Validate returns an error if the given value is not valid for the field.
+	Validate(val Value) error
+}
+
+// BlankField is the interface implemented by fields that can be blank.
+type BlankField interface {
+	// IsBlank returns true if the field is not required.
+	IsBlank() bool
+}
+
+// MaxLengthField is the interface implemented by fields with a maximum length.
+type MaxLengthField interface {
+	// FieldMaxLength returns the maximum length of the field values, or zero
+	// if there's no limit.
+	FieldMaxLength() int
+}
+
+// validationOptions returns the blank, max length and choices options of the
+// given field, if it implements the related interfaces.
+func validationOptions(field Field) (blank bool, maxLength int, ch []Choice) {
+	if f, ok := field.(BlankField); ok {
+		blank = f.IsBlank()
+	}
+	if f, ok := field.(MaxLengthField); ok {
+		maxLength = f.FieldMaxLength()
+	}
+	if f, ok := field.(ChoicesLister); ok {
+		ch, _ = f.FieldChoices()
+	}
+	return
+}
+
+// isEmpty returns true if the given value is an empty string, slice or map.
+func isEmpty(val Value) bool {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// validateField returns the list of validation messages for the given field
+// value, where present indicates if the value was found on the container.
+func validateField(
+	field Field,
+	val Value,
+	present bool,
+	validators []ValidatorFunc,
+) []string {
+	if field.IsAuto() || isGenerated(field) {
+		return nil
+	}
+	if field.IsAutoNow() || field.IsAutoNowAdd() {
+		return nil
+	}
+	if enc, ok := encryptedField(field); ok && enc.Base != nil {
+		// Encrypted values are validated as plaintext by the base field.
+		field = enc.Base
+	}
+	if !present {
+		if def, hasDefault := field.DefaultValue(); hasDefault {
+			val = def
+		}
+	}
+	if val == nil || isAutoGenerated(field) && isEmpty(val) {
+		if isAutoGenerated(field) || field.IsNull() || !present &&
+			dbDefault(field) != "" {
+			return nil
+		}
+		return []string{"this field cannot be null"}
+	}
+	errors := []string{}
+	blank, maxLength, choices := validationOptions(field)
+	if !blank && isEmpty(val) {
+		errors = append(errors, "this field cannot be blank")
+	}
+	if s, ok := val.(string); ok && maxLength > 0 {
+		if utf8.RuneCountInString(s) > maxLength {
+			errors = append(errors, fmt.Sprintf(
+				"ensure this value has at most %d characters", maxLength,
+			))
+		}
+	}
+	if len(choices) > 0 && !(blank && isEmpty(val)) {
+		valid := false
+		for _, choice := range choices {
+			valid = valid || sameChoice(val, choice.Value)
+		}
+		if !valid {
+			errors = append(errors, fmt.Sprintf("invalid choice: %v", val))
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	if !(blank && isEmpty(val)) {
+		if err := setRecipient(field.Recipient(), val); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	if v, ok := field.(Validator); ok {
+		if err := v.Validate(val); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	for _, validator := range validators {
+		if err := validator(val); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	return errors
+}
+
+// validate returns the validation messages of the named instance fields.
+func (i Instance) validate(fields ...string) (map[string][]string, error) {
+	if len(fields) == 0 {
+		for name := range i.model.fields {
+			fields = append(fields, name)
+		}
+	}
+	result := map[string][]string{}
+	for _, name := range fields {
+		if name == "pk" {
+			name = i.model.pk
+		}
+		field, ok := i.model.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+		val, present := i.GetIf(name)
+		validators := i.model.meta.Validators[name]
+		errors := validateField(field, val, present, validators)
+		if len(errors) > 0 {
+			result[name] = errors
+		}
+	}
+	return result, nil
+}
+
+// validationError returns a *ValidationError for the given field messages.
+func (i Instance) validationError(errors map[string][]string) error {
+	names := make([]string, 0, len(errors))
+	for name := range errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf(
+			"%s: %s", name, strings.Join(errors[name], ", "),
+		))
+	}
+	err := fmt.Errorf("invalid values: %s", strings.Join(msgs, "; "))
+	return &ValidationError{Trace: i.trace(err), Errors: errors}
+}
+
+// Validate checks the values of the named instance fields (all fields if none
+// provided), returning a *ValidationError if any of them is not valid.
+//
+// Fields are checked against the null, blank, max length and choices options,
+// the field DriverValue method, the Validate method if the field implements
+// the Validator interface and the model Validators.
+func (i Instance) Validate(fields ...string) error {
+	errors, err := i.validate(fields...)
+	if err != nil {
+		return &ContainerError{i.trace(err)}
+	} else if len(errors) > 0 {
+		return i.validationError(errors)
+	}
+	return nil
+}
+
+// FullClean validates all the instance fields. See the Validate method.
+func (i Instance) FullClean() error {
+	return i.Validate()
+}
@@ -0,0 +1,183 @@
+package gomodel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// upperField is a custom field type used to test the Validator interface.
+type upperField struct {
+	CharField
+}
+
+// Validate implements the Validator interface.
+func (f upperField) Validate(val Value) error {
+	if s, ok := val.(string); ok && s != strings.ToUpper(s) {
+		return fmt.Errorf("value must be uppercase")
+	}
+	return nil
+}
+
+// TestValidation tests the Instance validation methods
+func TestValidation(t *testing.T) {
+	model := &Model{
+		name: "User",
+		pk:   "id",
+		fields: Fields{
+			"id":    IntegerField{Auto: true, PrimaryKey: true},
+			"email": EmailField{},
+			"name":  CharField{MaxLength: 5, Blank: true, DefaultEmpty: true},
+			"role": CharField{
+				MaxLength: 10,
+				Choices:   []Choice{{"admin", "Admin"}, {"user", "User"}},
+				Default:   "user",
+			},
+			"code":  upperField{CharField{MaxLength: 3, Null: true}},
+			"level": IntegerField{Null: true},
+		},
+		meta: Options{
+			Validators: map[string][]ValidatorFunc{
+				"level": {func(val Value) error {
+					if val != nil && val.(int32) > 10 {
+						return fmt.Errorf("max level is 10")
+					}
+					return nil
+				}},
+			},
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		instance := Instance{
+			model:     model,
+			container: Values{"email": "user@test.com"},
+		}
+		if err := instance.FullClean(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{
+			"name":  "Robert",
+			"role":  "guest",
+			"code":  "abc",
+			"level": int32(11),
+		}}
+		err := instance.FullClean()
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		expected := map[string][]string{
+			"email": {"this field cannot be null"},
+			"name":  {"ensure this value has at most 5 characters"},
+			"role":  {"invalid choice: guest"},
+			"code":  {"value must be uppercase"},
+			"level": {"max level is 10"},
+		}
+		if !reflect.DeepEqual(verr.Errors, expected) {
+			t.Errorf("expected %v, got %v", expected, verr.Errors)
+		}
+	})
+
+	t.Run("Blank", func(t *testing.T) {
+		instance := Instance{
+			model:     model,
+			container: Values{"email": "", "name": ""},
+		}
+		err := instance.FullClean()
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if _, ok := verr.Errors["name"]; ok {
+			t.Error("expected blank name to be valid")
+		}
+		expected := []string{"this field cannot be blank"}
+		if !reflect.DeepEqual(verr.Errors["email"], expected) {
+			t.Errorf("expected %v, got %v", expected, verr.Errors["email"])
+		}
+	})
+
+	t.Run("EmailFormat", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{"email": "user"}}
+		err := instance.Validate("email")
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		expected := []string{"invalid email address: user"}
+		if !reflect.DeepEqual(verr.Errors["email"], expected) {
+			t.Errorf("expected %v, got %v", expected, verr.Errors["email"])
+		}
+	})
+
+	t.Run("FieldValues", func(t *testing.T) {
+		tests := []struct {
+			field Field
+			val   Value
+			valid bool
+		}{
+			{IntegerField{}, "abc", false},
+			{IntegerField{}, int32(3), true},
+			{EnumField{Values: []Value{"a", "b"}}, "c", false},
+			{EnumField{Values: []Value{"a", "b"}}, "b", true},
+			{UUIDField{}, "abc", false},
+			{DecimalField{MaxDigits: 3, DecimalPlaces: 1}, "12.34", false},
+			{GenericIPAddressField{Protocol: "IPv4"}, "::1", false},
+		}
+		for _, test := range tests {
+			errs := validateField(test.field, test.val, true, nil)
+			if test.valid != (len(errs) == 0) {
+				t.Errorf("%v: unexpected errors %v", test.val, errs)
+			}
+		}
+	})
+
+	t.Run("EncryptedField", func(t *testing.T) {
+		field := EncryptedField{Base: EmailField{}}
+		errs := validateField(field, "user@test.com", true, nil)
+		if len(errs) > 0 {
+			t.Errorf("expected valid value without encryption, got %v", errs)
+		}
+		expected := []string{"invalid email address: user"}
+		errs = validateField(field, "user", true, nil)
+		if !reflect.DeepEqual(errs, expected) {
+			t.Errorf("expected %v, got %v", expected, errs)
+		}
+	})
+
+	t.Run("FieldOptions", func(t *testing.T) {
+		field := DateField{Blank: true, Choices: []Choice{{"2020-01-01", "A"}}}
+		blank, maxLength, choices := validationOptions(field)
+		if !blank || maxLength != 0 || len(choices) != 1 {
+			t.Errorf("wrong options: %v, %d, %v", blank, maxLength, choices)
+		}
+		blank, maxLength, choices = validationOptions(EmailField{MaxLength: 5})
+		if blank || maxLength != 5 || len(choices) != 0 {
+			t.Errorf("wrong options: %v, %d, %v", blank, maxLength, choices)
+		}
+		blank, maxLength, choices = validationOptions(VersionField{})
+		if blank || maxLength != 0 || len(choices) != 0 {
+			t.Errorf("wrong options: %v, %d, %v", blank, maxLength, choices)
+		}
+	})
+
+	t.Run("ValidateFields", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{"role": "admin"}}
+		if err := instance.Validate("role", "name"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ValidateUnknownField", func(t *testing.T) {
+		instance := Instance{model: model, container: Values{}}
+		err := instance.Validate("foo")
+		if _, ok := err.(*ContainerError); !ok {
+			t.Errorf("expected ContainerError, got %T", err)
+		}
+	})
+}